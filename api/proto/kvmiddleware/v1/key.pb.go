@@ -0,0 +1,6522 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: kvmiddleware/v1/key.proto
+
+package kvmiddlewarev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WatchKeysResponse_EventType int32
+
+const (
+	WatchKeysResponse_EVENT_TYPE_UNSPECIFIED WatchKeysResponse_EventType = 0
+	WatchKeysResponse_EVENT_TYPE_PUT         WatchKeysResponse_EventType = 1
+	WatchKeysResponse_EVENT_TYPE_DELETE      WatchKeysResponse_EventType = 2
+)
+
+// Enum value maps for WatchKeysResponse_EventType.
+var (
+	WatchKeysResponse_EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_PUT",
+		2: "EVENT_TYPE_DELETE",
+	}
+	WatchKeysResponse_EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED": 0,
+		"EVENT_TYPE_PUT":         1,
+		"EVENT_TYPE_DELETE":      2,
+	}
+)
+
+func (x WatchKeysResponse_EventType) Enum() *WatchKeysResponse_EventType {
+	p := new(WatchKeysResponse_EventType)
+	*p = x
+	return p
+}
+
+func (x WatchKeysResponse_EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchKeysResponse_EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_kvmiddleware_v1_key_proto_enumTypes[0].Descriptor()
+}
+
+func (WatchKeysResponse_EventType) Type() protoreflect.EnumType {
+	return &file_kvmiddleware_v1_key_proto_enumTypes[0]
+}
+
+func (x WatchKeysResponse_EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchKeysResponse_EventType.Descriptor instead.
+func (WatchKeysResponse_EventType) EnumDescriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{97, 0}
+}
+
+type KV struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key        string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value      string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Type       string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	CreatedBy  int64                  `protobuf:"varint,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	ApprovedBy int64                  `protobuf:"varint,8,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
+	// status mirrors the status constants of the key entity.
+	Status        int32  `protobuf:"varint,9,opt,name=status,proto3" json:"status,omitempty"`
+	StatusString  string `protobuf:"bytes,10,opt,name=status_string,json=statusString,proto3" json:"status_string,omitempty"`
+	CreatedByStr  string `protobuf:"bytes,11,opt,name=created_by_str,json=createdByStr,proto3" json:"created_by_str,omitempty"`
+	ChangeSetId   int64  `protobuf:"varint,12,opt,name=change_set_id,json=changeSetId,proto3" json:"change_set_id,omitempty"`
+	Environment   string `protobuf:"bytes,13,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KV) Reset() {
+	*x = KV{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KV) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KV) ProtoMessage() {}
+
+func (x *KV) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KV.ProtoReflect.Descriptor instead.
+func (*KV) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KV) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *KV) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KV) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *KV) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *KV) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *KV) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *KV) GetCreatedBy() int64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *KV) GetApprovedBy() int64 {
+	if x != nil {
+		return x.ApprovedBy
+	}
+	return 0
+}
+
+func (x *KV) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *KV) GetStatusString() string {
+	if x != nil {
+		return x.StatusString
+	}
+	return ""
+}
+
+func (x *KV) GetCreatedByStr() string {
+	if x != nil {
+		return x.CreatedByStr
+	}
+	return ""
+}
+
+func (x *KV) GetChangeSetId() int64 {
+	if x != nil {
+		return x.ChangeSetId
+	}
+	return 0
+}
+
+func (x *KV) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type GetKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// client and ip identify the reader in read telemetry.
+	Client string `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+	Ip     string `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyRequest) Reset() {
+	*x = GetKeyRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyRequest) ProtoMessage() {}
+
+func (x *GetKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetKeyRequest) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *GetKeyRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *GetKeyRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type GetKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kv            *KV                    `protobuf:"bytes,1,opt,name=kv,proto3" json:"kv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyResponse) Reset() {
+	*x = GetKeyResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyResponse) ProtoMessage() {}
+
+func (x *GetKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetKeyResponse) GetKv() *KV {
+	if x != nil {
+		return x.Kv
+	}
+	return nil
+}
+
+type GetKeysRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// ip of the caller, used to resolve canary values.
+	Ip string `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	// evaluate_prerequisites replaces value of key whose prerequisite is not met with its fallback value.
+	EvaluatePrerequisites bool `protobuf:"varint,3,opt,name=evaluate_prerequisites,json=evaluatePrerequisites,proto3" json:"evaluate_prerequisites,omitempty"`
+	// client identify the reader in read telemetry.
+	Client string `protobuf:"bytes,4,opt,name=client,proto3" json:"client,omitempty"`
+	// environment defaults to production.
+	Environment string `protobuf:"bytes,6,opt,name=environment,proto3" json:"environment,omitempty"`
+	// group is the canary group of the node, it receives canary values approved for the group.
+	Group         string `protobuf:"bytes,7,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeysRequest) Reset() {
+	*x = GetKeysRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeysRequest) ProtoMessage() {}
+
+func (x *GetKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeysRequest.ProtoReflect.Descriptor instead.
+func (*GetKeysRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetKeysRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *GetKeysRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *GetKeysRequest) GetEvaluatePrerequisites() bool {
+	if x != nil {
+		return x.EvaluatePrerequisites
+	}
+	return false
+}
+
+func (x *GetKeysRequest) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *GetKeysRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *GetKeysRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+type GetKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kvs           []*KV                  `protobuf:"bytes,1,rep,name=kvs,proto3" json:"kvs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeysResponse) Reset() {
+	*x = GetKeysResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeysResponse) ProtoMessage() {}
+
+func (x *GetKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeysResponse.ProtoReflect.Descriptor instead.
+func (*GetKeysResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetKeysResponse) GetKvs() []*KV {
+	if x != nil {
+		return x.Kvs
+	}
+	return nil
+}
+
+type BrowseKeysRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// separator returns only immediate children of the prefix, like consul ?keys&separator=/.
+	Separator string `protobuf:"bytes,2,opt,name=separator,proto3" json:"separator,omitempty"`
+	Cursor    string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit     int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,6,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowseKeysRequest) Reset() {
+	*x = BrowseKeysRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowseKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowseKeysRequest) ProtoMessage() {}
+
+func (x *BrowseKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowseKeysRequest.ProtoReflect.Descriptor instead.
+func (*BrowseKeysRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BrowseKeysRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *BrowseKeysRequest) GetSeparator() string {
+	if x != nil {
+		return x.Separator
+	}
+	return ""
+}
+
+func (x *BrowseKeysRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *BrowseKeysRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *BrowseKeysRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type BrowseNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	IsDir bool                   `protobuf:"varint,2,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+	// count is the number of keys under a directory.
+	Count int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// metadata of a key node, unset for directory and key without metadata.
+	Metadata *KeyMetadata `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// expired is set for temporary key past its expiry date.
+	Expired       bool `protobuf:"varint,5,opt,name=expired,proto3" json:"expired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowseNode) Reset() {
+	*x = BrowseNode{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowseNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowseNode) ProtoMessage() {}
+
+func (x *BrowseNode) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowseNode.ProtoReflect.Descriptor instead.
+func (*BrowseNode) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BrowseNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BrowseNode) GetIsDir() bool {
+	if x != nil {
+		return x.IsDir
+	}
+	return false
+}
+
+func (x *BrowseNode) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *BrowseNode) GetMetadata() *KeyMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BrowseNode) GetExpired() bool {
+	if x != nil {
+		return x.Expired
+	}
+	return false
+}
+
+type KeyMetadata struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Key         string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Tags        []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Owner       string                 `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	Ticket      string                 `protobuf:"bytes,5,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	// lifetime is permanent (default) or temporary, temporary key requires expiry_date.
+	Lifetime      string                 `protobuf:"bytes,6,opt,name=lifetime,proto3" json:"lifetime,omitempty"`
+	ExpiryDate    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	UpdatedBy     int64                  `protobuf:"varint,9,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyMetadata) Reset() {
+	*x = KeyMetadata{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyMetadata) ProtoMessage() {}
+
+func (x *KeyMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyMetadata.ProtoReflect.Descriptor instead.
+func (*KeyMetadata) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *KeyMetadata) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyMetadata) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *KeyMetadata) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *KeyMetadata) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *KeyMetadata) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *KeyMetadata) GetLifetime() string {
+	if x != nil {
+		return x.Lifetime
+	}
+	return ""
+}
+
+func (x *KeyMetadata) GetExpiryDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return nil
+}
+
+func (x *KeyMetadata) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *KeyMetadata) GetUpdatedBy() int64 {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return 0
+}
+
+type BrowseKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*BrowseNode          `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowseKeysResponse) Reset() {
+	*x = BrowseKeysResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowseKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowseKeysResponse) ProtoMessage() {}
+
+func (x *BrowseKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowseKeysResponse.ProtoReflect.Descriptor instead.
+func (*BrowseKeysResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BrowseKeysResponse) GetNodes() []*BrowseNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *BrowseKeysResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetHistoryKeyRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Key        string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	IsPrefix   bool                   `protobuf:"varint,2,opt,name=is_prefix,json=isPrefix,proto3" json:"is_prefix,omitempty"`
+	Limit      int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Statuses   []int32                `protobuf:"varint,4,rep,packed,name=statuses,proto3" json:"statuses,omitempty"`
+	CreatedBy  int64                  `protobuf:"varint,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	ApprovedBy int64                  `protobuf:"varint,6,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
+	From       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=from,proto3" json:"from,omitempty"`
+	To         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=to,proto3" json:"to,omitempty"`
+	Cursor     string                 `protobuf:"bytes,9,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,11,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryKeyRequest) Reset() {
+	*x = GetHistoryKeyRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryKeyRequest) ProtoMessage() {}
+
+func (x *GetHistoryKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetHistoryKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetHistoryKeyRequest) GetIsPrefix() bool {
+	if x != nil {
+		return x.IsPrefix
+	}
+	return false
+}
+
+func (x *GetHistoryKeyRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetHistoryKeyRequest) GetStatuses() []int32 {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *GetHistoryKeyRequest) GetCreatedBy() int64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *GetHistoryKeyRequest) GetApprovedBy() int64 {
+	if x != nil {
+		return x.ApprovedBy
+	}
+	return 0
+}
+
+func (x *GetHistoryKeyRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetHistoryKeyRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetHistoryKeyRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetHistoryKeyRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type GetHistoryKeyResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Kvs        []*KV                  `protobuf:"bytes,1,rep,name=kvs,proto3" json:"kvs,omitempty"`
+	NextCursor string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// comments of kvs ordered by create time.
+	Comments      []*Comment `protobuf:"bytes,3,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryKeyResponse) Reset() {
+	*x = GetHistoryKeyResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryKeyResponse) ProtoMessage() {}
+
+func (x *GetHistoryKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryKeyResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetHistoryKeyResponse) GetKvs() []*KV {
+	if x != nil {
+		return x.Kvs
+	}
+	return nil
+}
+
+func (x *GetHistoryKeyResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetHistoryKeyResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type PendingApprovalKeyRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// kinds is any of update, delete and canary, empty means all of them.
+	Kinds         []string `protobuf:"bytes,2,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	Author        int64    `protobuf:"varint,3,opt,name=author,proto3" json:"author,omitempty"`
+	MinAgeSeconds int64    `protobuf:"varint,4,opt,name=min_age_seconds,json=minAgeSeconds,proto3" json:"min_age_seconds,omitempty"`
+	MaxAgeSeconds int64    `protobuf:"varint,5,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"`
+	// sort is oldest (default) or newest.
+	Sort   string `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`
+	Limit  int32  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32  `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty"`
+	// approver keeps only changes routed to the username, see Ownership.
+	Approver string `protobuf:"bytes,10,opt,name=approver,proto3" json:"approver,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,11,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingApprovalKeyRequest) Reset() {
+	*x = PendingApprovalKeyRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingApprovalKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingApprovalKeyRequest) ProtoMessage() {}
+
+func (x *PendingApprovalKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingApprovalKeyRequest.ProtoReflect.Descriptor instead.
+func (*PendingApprovalKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PendingApprovalKeyRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *PendingApprovalKeyRequest) GetKinds() []string {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+func (x *PendingApprovalKeyRequest) GetAuthor() int64 {
+	if x != nil {
+		return x.Author
+	}
+	return 0
+}
+
+func (x *PendingApprovalKeyRequest) GetMinAgeSeconds() int64 {
+	if x != nil {
+		return x.MinAgeSeconds
+	}
+	return 0
+}
+
+func (x *PendingApprovalKeyRequest) GetMaxAgeSeconds() int64 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+func (x *PendingApprovalKeyRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *PendingApprovalKeyRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PendingApprovalKeyRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *PendingApprovalKeyRequest) GetApprover() string {
+	if x != nil {
+		return x.Approver
+	}
+	return ""
+}
+
+func (x *PendingApprovalKeyRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type PendingApprovalKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*PendingKV           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingApprovalKeyResponse) Reset() {
+	*x = PendingApprovalKeyResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingApprovalKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingApprovalKeyResponse) ProtoMessage() {}
+
+func (x *PendingApprovalKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingApprovalKeyResponse.ProtoReflect.Descriptor instead.
+func (*PendingApprovalKeyResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PendingApprovalKeyResponse) GetItems() []*PendingKV {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *PendingApprovalKeyResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type DiffLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffLine) Reset() {
+	*x = DiffLine{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffLine) ProtoMessage() {}
+
+func (x *DiffLine) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffLine.ProtoReflect.Descriptor instead.
+func (*DiffLine) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DiffLine) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *DiffLine) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// DiffChange is a single structural change of a json value, old and new are json encoded.
+type DiffChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Op            string                 `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`
+	Old           string                 `protobuf:"bytes,3,opt,name=old,proto3" json:"old,omitempty"`
+	New           string                 `protobuf:"bytes,4,opt,name=new,proto3" json:"new,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffChange) Reset() {
+	*x = DiffChange{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffChange) ProtoMessage() {}
+
+func (x *DiffChange) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffChange.ProtoReflect.Descriptor instead.
+func (*DiffChange) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DiffChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DiffChange) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *DiffChange) GetOld() string {
+	if x != nil {
+		return x.Old
+	}
+	return ""
+}
+
+func (x *DiffChange) GetNew() string {
+	if x != nil {
+		return x.New
+	}
+	return ""
+}
+
+type Diff struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// kind is one of line, json or scalar.
+	Kind          string        `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Old           string        `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	New           string        `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+	Lines         []*DiffLine   `protobuf:"bytes,4,rep,name=lines,proto3" json:"lines,omitempty"`
+	Changes       []*DiffChange `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Diff) Reset() {
+	*x = Diff{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Diff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diff) ProtoMessage() {}
+
+func (x *Diff) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diff.ProtoReflect.Descriptor instead.
+func (*Diff) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Diff) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Diff) GetOld() string {
+	if x != nil {
+		return x.Old
+	}
+	return ""
+}
+
+func (x *Diff) GetNew() string {
+	if x != nil {
+		return x.New
+	}
+	return ""
+}
+
+func (x *Diff) GetLines() []*DiffLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Diff) GetChanges() []*DiffChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type PendingKV struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kv            *KV                    `protobuf:"bytes,1,opt,name=kv,proto3" json:"kv,omitempty"`
+	Active        *KV                    `protobuf:"bytes,2,opt,name=active,proto3" json:"active,omitempty"`
+	Diff          *Diff                  `protobuf:"bytes,3,opt,name=diff,proto3" json:"diff,omitempty"`
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Comments      []*Comment             `protobuf:"bytes,5,rep,name=comments,proto3" json:"comments,omitempty"`
+	Ownership     *Ownership             `protobuf:"bytes,6,opt,name=ownership,proto3" json:"ownership,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingKV) Reset() {
+	*x = PendingKV{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingKV) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingKV) ProtoMessage() {}
+
+func (x *PendingKV) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingKV.ProtoReflect.Descriptor instead.
+func (*PendingKV) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PendingKV) GetKv() *KV {
+	if x != nil {
+		return x.Kv
+	}
+	return nil
+}
+
+func (x *PendingKV) GetActive() *KV {
+	if x != nil {
+		return x.Active
+	}
+	return nil
+}
+
+func (x *PendingKV) GetDiff() *Diff {
+	if x != nil {
+		return x.Diff
+	}
+	return nil
+}
+
+func (x *PendingKV) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PendingKV) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *PendingKV) GetOwnership() *Ownership {
+	if x != nil {
+		return x.Ownership
+	}
+	return nil
+}
+
+// Ownership of a prefix, like CODEOWNERS the most specific prefix covering a key wins.
+// Changes are routed to user owners, or to every superuser of the key when there is none.
+type Ownership struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Owners        []*Owner               `protobuf:"bytes,3,rep,name=owners,proto3" json:"owners,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	OnCall        string                 `protobuf:"bytes,5,opt,name=on_call,json=onCall,proto3" json:"on_call,omitempty"`
+	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	UpdatedBy     int64                  `protobuf:"varint,8,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ownership) Reset() {
+	*x = Ownership{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ownership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ownership) ProtoMessage() {}
+
+func (x *Ownership) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ownership.ProtoReflect.Descriptor instead.
+func (*Ownership) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Ownership) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Ownership) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *Ownership) GetOwners() []*Owner {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *Ownership) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Ownership) GetOnCall() string {
+	if x != nil {
+		return x.OnCall
+	}
+	return ""
+}
+
+func (x *Ownership) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Ownership) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *Ownership) GetUpdatedBy() int64 {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return 0
+}
+
+type Owner struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// kind is team or user, name is the team handle or the username.
+	Kind          string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Owner) Reset() {
+	*x = Owner{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Owner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Owner) ProtoMessage() {}
+
+func (x *Owner) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Owner.ProtoReflect.Descriptor instead.
+func (*Owner) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Owner) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Owner) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Comment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	KeyId int64                  `protobuf:"varint,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// parent_id is 0 for the first comment of a thread.
+	ParentId int64 `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// kind is comment, justification or disapproval.
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Body          string                 `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedBy     int64                  `protobuf:"varint,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Comment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Comment) GetKeyId() int64 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *Comment) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Comment) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Comment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedBy() int64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *Comment) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+type DiffHistoryKeyRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FromId int64                  `protobuf:"varint,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToId   int64                  `protobuf:"varint,2,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,4,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffHistoryKeyRequest) Reset() {
+	*x = DiffHistoryKeyRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffHistoryKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffHistoryKeyRequest) ProtoMessage() {}
+
+func (x *DiffHistoryKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffHistoryKeyRequest.ProtoReflect.Descriptor instead.
+func (*DiffHistoryKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DiffHistoryKeyRequest) GetFromId() int64 {
+	if x != nil {
+		return x.FromId
+	}
+	return 0
+}
+
+func (x *DiffHistoryKeyRequest) GetToId() int64 {
+	if x != nil {
+		return x.ToId
+	}
+	return 0
+}
+
+func (x *DiffHistoryKeyRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type DiffHistoryKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Diff          *Diff                  `protobuf:"bytes,1,opt,name=diff,proto3" json:"diff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffHistoryKeyResponse) Reset() {
+	*x = DiffHistoryKeyResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffHistoryKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffHistoryKeyResponse) ProtoMessage() {}
+
+func (x *DiffHistoryKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffHistoryKeyResponse.ProtoReflect.Descriptor instead.
+func (*DiffHistoryKeyResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DiffHistoryKeyResponse) GetDiff() *Diff {
+	if x != nil {
+		return x.Diff
+	}
+	return nil
+}
+
+type SearchKeysRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Text   string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	// mode is substring (default), regex or jsonpath.
+	Mode    string `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	InKey   bool   `protobuf:"varint,5,opt,name=in_key,json=inKey,proto3" json:"in_key,omitempty"`
+	InValue bool   `protobuf:"varint,6,opt,name=in_value,json=inValue,proto3" json:"in_value,omitempty"`
+	Limit   int32  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	// in_metadata matches description, owner and ticket of the key.
+	InMetadata bool `protobuf:"varint,8,opt,name=in_metadata,json=inMetadata,proto3" json:"in_metadata,omitempty"`
+	// tags keeps only keys having every tag, text may be empty when tags are set.
+	Tags []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// expired keeps only temporary keys past their expiry date, text may be empty when it is set.
+	Expired bool `protobuf:"varint,10,opt,name=expired,proto3" json:"expired,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,11,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchKeysRequest) Reset() {
+	*x = SearchKeysRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchKeysRequest) ProtoMessage() {}
+
+func (x *SearchKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchKeysRequest.ProtoReflect.Descriptor instead.
+func (*SearchKeysRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SearchKeysRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SearchKeysRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SearchKeysRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *SearchKeysRequest) GetInKey() bool {
+	if x != nil {
+		return x.InKey
+	}
+	return false
+}
+
+func (x *SearchKeysRequest) GetInValue() bool {
+	if x != nil {
+		return x.InValue
+	}
+	return false
+}
+
+func (x *SearchKeysRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchKeysRequest) GetInMetadata() bool {
+	if x != nil {
+		return x.InMetadata
+	}
+	return false
+}
+
+func (x *SearchKeysRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SearchKeysRequest) GetExpired() bool {
+	if x != nil {
+		return x.Expired
+	}
+	return false
+}
+
+func (x *SearchKeysRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type SearchKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kvs           []*KV                  `protobuf:"bytes,1,rep,name=kvs,proto3" json:"kvs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchKeysResponse) Reset() {
+	*x = SearchKeysResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchKeysResponse) ProtoMessage() {}
+
+func (x *SearchKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchKeysResponse.ProtoReflect.Descriptor instead.
+func (*SearchKeysResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SearchKeysResponse) GetKvs() []*KV {
+	if x != nil {
+		return x.Kvs
+	}
+	return nil
+}
+
+type ExportPrefixRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// format is yaml or json.
+	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,4,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportPrefixRequest) Reset() {
+	*x = ExportPrefixRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportPrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPrefixRequest) ProtoMessage() {}
+
+func (x *ExportPrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPrefixRequest.ProtoReflect.Descriptor instead.
+func (*ExportPrefixRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ExportPrefixRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ExportPrefixRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportPrefixRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type ExportPrefixResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportPrefixResponse) Reset() {
+	*x = ExportPrefixResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportPrefixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPrefixResponse) ProtoMessage() {}
+
+func (x *ExportPrefixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPrefixResponse.ProtoReflect.Descriptor instead.
+func (*ExportPrefixResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ExportPrefixResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportPrefixRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Format string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Data   []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportPrefixRequest) Reset() {
+	*x = ImportPrefixRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportPrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportPrefixRequest) ProtoMessage() {}
+
+func (x *ImportPrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportPrefixRequest.ProtoReflect.Descriptor instead.
+func (*ImportPrefixRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ImportPrefixRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ImportPrefixRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportPrefixRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportPrefixRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type ImportPrefixResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangeSetId   int64                  `protobuf:"varint,1,opt,name=change_set_id,json=changeSetId,proto3" json:"change_set_id,omitempty"`
+	Changed       []string               `protobuf:"bytes,2,rep,name=changed,proto3" json:"changed,omitempty"`
+	Unchanged     []string               `protobuf:"bytes,3,rep,name=unchanged,proto3" json:"unchanged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportPrefixResponse) Reset() {
+	*x = ImportPrefixResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportPrefixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportPrefixResponse) ProtoMessage() {}
+
+func (x *ImportPrefixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportPrefixResponse.ProtoReflect.Descriptor instead.
+func (*ImportPrefixResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ImportPrefixResponse) GetChangeSetId() int64 {
+	if x != nil {
+		return x.ChangeSetId
+	}
+	return 0
+}
+
+func (x *ImportPrefixResponse) GetChanged() []string {
+	if x != nil {
+		return x.Changed
+	}
+	return nil
+}
+
+func (x *ImportPrefixResponse) GetUnchanged() []string {
+	if x != nil {
+		return x.Unchanged
+	}
+	return nil
+}
+
+type PromoteKeysRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SourceEnvironment string                 `protobuf:"bytes,1,opt,name=source_environment,json=sourceEnvironment,proto3" json:"source_environment,omitempty"`
+	TargetEnvironment string                 `protobuf:"bytes,2,opt,name=target_environment,json=targetEnvironment,proto3" json:"target_environment,omitempty"`
+	Prefix            string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// keys to promote, empty means every key under the prefix.
+	Keys []string `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`
+	// dry_run only returns the diff preview.
+	DryRun        bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteKeysRequest) Reset() {
+	*x = PromoteKeysRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteKeysRequest) ProtoMessage() {}
+
+func (x *PromoteKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteKeysRequest.ProtoReflect.Descriptor instead.
+func (*PromoteKeysRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PromoteKeysRequest) GetSourceEnvironment() string {
+	if x != nil {
+		return x.SourceEnvironment
+	}
+	return ""
+}
+
+func (x *PromoteKeysRequest) GetTargetEnvironment() string {
+	if x != nil {
+		return x.TargetEnvironment
+	}
+	return ""
+}
+
+func (x *PromoteKeysRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *PromoteKeysRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *PromoteKeysRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type PromotionItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Source        *KV                    `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Target        *KV                    `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	Diff          *Diff                  `protobuf:"bytes,4,opt,name=diff,proto3" json:"diff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromotionItem) Reset() {
+	*x = PromotionItem{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromotionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromotionItem) ProtoMessage() {}
+
+func (x *PromotionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromotionItem.ProtoReflect.Descriptor instead.
+func (*PromotionItem) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PromotionItem) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PromotionItem) GetSource() *KV {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *PromotionItem) GetTarget() *KV {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *PromotionItem) GetDiff() *Diff {
+	if x != nil {
+		return x.Diff
+	}
+	return nil
+}
+
+type PromoteKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangeSetId   int64                  `protobuf:"varint,1,opt,name=change_set_id,json=changeSetId,proto3" json:"change_set_id,omitempty"`
+	Items         []*PromotionItem       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Unchanged     []string               `protobuf:"bytes,3,rep,name=unchanged,proto3" json:"unchanged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteKeysResponse) Reset() {
+	*x = PromoteKeysResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteKeysResponse) ProtoMessage() {}
+
+func (x *PromoteKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteKeysResponse.ProtoReflect.Descriptor instead.
+func (*PromoteKeysResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PromoteKeysResponse) GetChangeSetId() int64 {
+	if x != nil {
+		return x.ChangeSetId
+	}
+	return 0
+}
+
+func (x *PromoteKeysResponse) GetItems() []*PromotionItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *PromoteKeysResponse) GetUnchanged() []string {
+	if x != nil {
+		return x.Unchanged
+	}
+	return nil
+}
+
+type UpdateKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Type  string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// base_id is id of the active value the change is based on, 0 when the key has no active value.
+	// Stale base is rejected with ABORTED.
+	BaseId int64 `protobuf:"varint,5,opt,name=base_id,json=baseId,proto3" json:"base_id,omitempty"`
+	// justification is stored as the first comment of the change.
+	Justification string `protobuf:"bytes,6,opt,name=justification,proto3" json:"justification,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,7,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateKeyRequest) Reset() {
+	*x = UpdateKeyRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateKeyRequest) ProtoMessage() {}
+
+func (x *UpdateKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateKeyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UpdateKeyRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *UpdateKeyRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateKeyRequest) GetBaseId() int64 {
+	if x != nil {
+		return x.BaseId
+	}
+	return 0
+}
+
+func (x *UpdateKeyRequest) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+func (x *UpdateKeyRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type UpdateKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateKeyResponse) Reset() {
+	*x = UpdateKeyResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateKeyResponse) ProtoMessage() {}
+
+func (x *UpdateKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateKeyResponse.ProtoReflect.Descriptor instead.
+func (*UpdateKeyResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{32}
+}
+
+type CreateDeleteKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Justification string                 `protobuf:"bytes,3,opt,name=justification,proto3" json:"justification,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,4,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDeleteKeyRequest) Reset() {
+	*x = CreateDeleteKeyRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDeleteKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDeleteKeyRequest) ProtoMessage() {}
+
+func (x *CreateDeleteKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDeleteKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateDeleteKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateDeleteKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CreateDeleteKeyRequest) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+func (x *CreateDeleteKeyRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type CreateDeleteKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDeleteKeyResponse) Reset() {
+	*x = CreateDeleteKeyResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDeleteKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDeleteKeyResponse) ProtoMessage() {}
+
+func (x *CreateDeleteKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDeleteKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateDeleteKeyResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{34}
+}
+
+type AmendPlacedKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// type keeps the placed type when empty.
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AmendPlacedKeyRequest) Reset() {
+	*x = AmendPlacedKeyRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendPlacedKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendPlacedKeyRequest) ProtoMessage() {}
+
+func (x *AmendPlacedKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendPlacedKeyRequest.ProtoReflect.Descriptor instead.
+func (*AmendPlacedKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AmendPlacedKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AmendPlacedKeyRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *AmendPlacedKeyRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AmendPlacedKeyRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type AmendPlacedKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AmendPlacedKeyResponse) Reset() {
+	*x = AmendPlacedKeyResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendPlacedKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendPlacedKeyResponse) ProtoMessage() {}
+
+func (x *AmendPlacedKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendPlacedKeyResponse.ProtoReflect.Descriptor instead.
+func (*AmendPlacedKeyResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{36}
+}
+
+type WithdrawPlacedKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,3,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawPlacedKeyRequest) Reset() {
+	*x = WithdrawPlacedKeyRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawPlacedKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawPlacedKeyRequest) ProtoMessage() {}
+
+func (x *WithdrawPlacedKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawPlacedKeyRequest.ProtoReflect.Descriptor instead.
+func (*WithdrawPlacedKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *WithdrawPlacedKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *WithdrawPlacedKeyRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type WithdrawPlacedKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawPlacedKeyResponse) Reset() {
+	*x = WithdrawPlacedKeyResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawPlacedKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawPlacedKeyResponse) ProtoMessage() {}
+
+func (x *WithdrawPlacedKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawPlacedKeyResponse.ProtoReflect.Descriptor instead.
+func (*WithdrawPlacedKeyResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{38}
+}
+
+type AddCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         int64                  `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	ParentId      int64                  `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AddCommentRequest) GetKeyId() int64 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *AddCommentRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *AddCommentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type AddCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentResponse) Reset() {
+	*x = AddCommentResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentResponse) ProtoMessage() {}
+
+func (x *AddCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentResponse.ProtoReflect.Descriptor instead.
+func (*AddCommentResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AddCommentResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         int64                  `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommentsRequest) Reset() {
+	*x = GetCommentsRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentsRequest) ProtoMessage() {}
+
+func (x *GetCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentsRequest.ProtoReflect.Descriptor instead.
+func (*GetCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetCommentsRequest) GetKeyId() int64 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+type GetCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommentsResponse) Reset() {
+	*x = GetCommentsResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentsResponse) ProtoMessage() {}
+
+func (x *GetCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentsResponse.ProtoReflect.Descriptor instead.
+func (*GetCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type SetOwnershipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ownership     *Ownership             `protobuf:"bytes,1,opt,name=ownership,proto3" json:"ownership,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOwnershipRequest) Reset() {
+	*x = SetOwnershipRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOwnershipRequest) ProtoMessage() {}
+
+func (x *SetOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*SetOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetOwnershipRequest) GetOwnership() *Ownership {
+	if x != nil {
+		return x.Ownership
+	}
+	return nil
+}
+
+type SetOwnershipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOwnershipResponse) Reset() {
+	*x = SetOwnershipResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOwnershipResponse) ProtoMessage() {}
+
+func (x *SetOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOwnershipResponse.ProtoReflect.Descriptor instead.
+func (*SetOwnershipResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{44}
+}
+
+type GetOwnershipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOwnershipRequest) Reset() {
+	*x = GetOwnershipRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOwnershipRequest) ProtoMessage() {}
+
+func (x *GetOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*GetOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetOwnershipRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetOwnershipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ownership     *Ownership             `protobuf:"bytes,1,opt,name=ownership,proto3" json:"ownership,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOwnershipResponse) Reset() {
+	*x = GetOwnershipResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOwnershipResponse) ProtoMessage() {}
+
+func (x *GetOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOwnershipResponse.ProtoReflect.Descriptor instead.
+func (*GetOwnershipResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetOwnershipResponse) GetOwnership() *Ownership {
+	if x != nil {
+		return x.Ownership
+	}
+	return nil
+}
+
+type UpdateKeyMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *KeyMetadata           `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateKeyMetadataRequest) Reset() {
+	*x = UpdateKeyMetadataRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateKeyMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateKeyMetadataRequest) ProtoMessage() {}
+
+func (x *UpdateKeyMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateKeyMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateKeyMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *UpdateKeyMetadataRequest) GetMetadata() *KeyMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type UpdateKeyMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateKeyMetadataResponse) Reset() {
+	*x = UpdateKeyMetadataResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateKeyMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateKeyMetadataResponse) ProtoMessage() {}
+
+func (x *UpdateKeyMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateKeyMetadataResponse.ProtoReflect.Descriptor instead.
+func (*UpdateKeyMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{48}
+}
+
+type GetKeyMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyMetadataRequest) Reset() {
+	*x = GetKeyMetadataRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyMetadataRequest) ProtoMessage() {}
+
+func (x *GetKeyMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyMetadataRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetKeyMetadataRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetKeyMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *KeyMetadata           `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyMetadataResponse) Reset() {
+	*x = GetKeyMetadataResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyMetadataResponse) ProtoMessage() {}
+
+func (x *GetKeyMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyMetadataResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetKeyMetadataResponse) GetMetadata() *KeyMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ApproveKeyRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Key    string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Status int32                  `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	// reason is required to disapprove.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveKeyRequest) Reset() {
+	*x = ApproveKeyRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveKeyRequest) ProtoMessage() {}
+
+func (x *ApproveKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveKeyRequest.ProtoReflect.Descriptor instead.
+func (*ApproveKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ApproveKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ApproveKeyRequest) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ApproveKeyRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ApproveKeyRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type ApproveKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveKeyResponse) Reset() {
+	*x = ApproveKeyResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveKeyResponse) ProtoMessage() {}
+
+func (x *ApproveKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveKeyResponse.ProtoReflect.Descriptor instead.
+func (*ApproveKeyResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{52}
+}
+
+type ApproveDeleteKeyRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Key    string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Status int32                  `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	// reason is required to disapprove.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeleteKeyRequest) Reset() {
+	*x = ApproveDeleteKeyRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeleteKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeleteKeyRequest) ProtoMessage() {}
+
+func (x *ApproveDeleteKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeleteKeyRequest.ProtoReflect.Descriptor instead.
+func (*ApproveDeleteKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ApproveDeleteKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ApproveDeleteKeyRequest) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ApproveDeleteKeyRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ApproveDeleteKeyRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type ApproveDeleteKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeleteKeyResponse) Reset() {
+	*x = ApproveDeleteKeyResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeleteKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeleteKeyResponse) ProtoMessage() {}
+
+func (x *ApproveDeleteKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeleteKeyResponse.ProtoReflect.Descriptor instead.
+func (*ApproveDeleteKeyResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{54}
+}
+
+type ApproveKeyCanaryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Key    string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Status int32                  `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	// nodes_ip accepts ipv4, ipv6 and cidr ranges.
+	NodesIp []string `protobuf:"bytes,4,rep,name=nodes_ip,json=nodesIp,proto3" json:"nodes_ip,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveKeyCanaryRequest) Reset() {
+	*x = ApproveKeyCanaryRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveKeyCanaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveKeyCanaryRequest) ProtoMessage() {}
+
+func (x *ApproveKeyCanaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveKeyCanaryRequest.ProtoReflect.Descriptor instead.
+func (*ApproveKeyCanaryRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ApproveKeyCanaryRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ApproveKeyCanaryRequest) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ApproveKeyCanaryRequest) GetNodesIp() []string {
+	if x != nil {
+		return x.NodesIp
+	}
+	return nil
+}
+
+func (x *ApproveKeyCanaryRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type ApproveKeyCanaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveKeyCanaryResponse) Reset() {
+	*x = ApproveKeyCanaryResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveKeyCanaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveKeyCanaryResponse) ProtoMessage() {}
+
+func (x *ApproveKeyCanaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveKeyCanaryResponse.ProtoReflect.Descriptor instead.
+func (*ApproveKeyCanaryResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{56}
+}
+
+type DeleteKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	KeyId int64                  `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,3,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteKeyRequest) Reset() {
+	*x = DeleteKeyRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteKeyRequest) ProtoMessage() {}
+
+func (x *DeleteKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteKeyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DeleteKeyRequest) GetKeyId() int64 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *DeleteKeyRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type DeleteKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteKeyResponse) Reset() {
+	*x = DeleteKeyResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteKeyResponse) ProtoMessage() {}
+
+func (x *DeleteKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteKeyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteKeyResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{58}
+}
+
+type CreateServiceRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Tribe    string                 `protobuf:"bytes,2,opt,name=tribe,proto3" json:"tribe,omitempty"`
+	Service  string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	// namespace to create the service in, empty means the default service namespace.
+	Namespace     string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServiceRequest) Reset() {
+	*x = CreateServiceRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceRequest) ProtoMessage() {}
+
+func (x *CreateServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceRequest.ProtoReflect.Descriptor instead.
+func (*CreateServiceRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateServiceRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateServiceRequest) GetTribe() string {
+	if x != nil {
+		return x.Tribe
+	}
+	return ""
+}
+
+func (x *CreateServiceRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *CreateServiceRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type CreateServiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServiceResponse) Reset() {
+	*x = CreateServiceResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceResponse) ProtoMessage() {}
+
+func (x *CreateServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceResponse.ProtoReflect.Descriptor instead.
+func (*CreateServiceResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{60}
+}
+
+type ApproveKeyCanaryGroupRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Key    string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Status int32                  `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	Group  string                 `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveKeyCanaryGroupRequest) Reset() {
+	*x = ApproveKeyCanaryGroupRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveKeyCanaryGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveKeyCanaryGroupRequest) ProtoMessage() {}
+
+func (x *ApproveKeyCanaryGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveKeyCanaryGroupRequest.ProtoReflect.Descriptor instead.
+func (*ApproveKeyCanaryGroupRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ApproveKeyCanaryGroupRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ApproveKeyCanaryGroupRequest) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ApproveKeyCanaryGroupRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ApproveKeyCanaryGroupRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type ApproveKeyCanaryGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveKeyCanaryGroupResponse) Reset() {
+	*x = ApproveKeyCanaryGroupResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveKeyCanaryGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveKeyCanaryGroupResponse) ProtoMessage() {}
+
+func (x *ApproveKeyCanaryGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveKeyCanaryGroupResponse.ProtoReflect.Descriptor instead.
+func (*ApproveKeyCanaryGroupResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{62}
+}
+
+type CanaryTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	NodeId        string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CanaryTarget) Reset() {
+	*x = CanaryTarget{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CanaryTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryTarget) ProtoMessage() {}
+
+func (x *CanaryTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryTarget.ProtoReflect.Descriptor instead.
+func (*CanaryTarget) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *CanaryTarget) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *CanaryTarget) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *CanaryTarget) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *CanaryTarget) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type RegisterCanaryGroupRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// service_prefix identify the service, e.g. service/tribe/name.
+	ServicePrefix string `protobuf:"bytes,1,opt,name=service_prefix,json=servicePrefix,proto3" json:"service_prefix,omitempty"`
+	Group         string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterCanaryGroupRequest) Reset() {
+	*x = RegisterCanaryGroupRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterCanaryGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterCanaryGroupRequest) ProtoMessage() {}
+
+func (x *RegisterCanaryGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterCanaryGroupRequest.ProtoReflect.Descriptor instead.
+func (*RegisterCanaryGroupRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RegisterCanaryGroupRequest) GetServicePrefix() string {
+	if x != nil {
+		return x.ServicePrefix
+	}
+	return ""
+}
+
+func (x *RegisterCanaryGroupRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+type RegisterCanaryGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterCanaryGroupResponse) Reset() {
+	*x = RegisterCanaryGroupResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterCanaryGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterCanaryGroupResponse) ProtoMessage() {}
+
+func (x *RegisterCanaryGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterCanaryGroupResponse.ProtoReflect.Descriptor instead.
+func (*RegisterCanaryGroupResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{65}
+}
+
+type HeartbeatCanaryTargetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServicePrefix string                 `protobuf:"bytes,1,opt,name=service_prefix,json=servicePrefix,proto3" json:"service_prefix,omitempty"`
+	Target        *CanaryTarget          `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	TtlSeconds    int64                  `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatCanaryTargetRequest) Reset() {
+	*x = HeartbeatCanaryTargetRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatCanaryTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatCanaryTargetRequest) ProtoMessage() {}
+
+func (x *HeartbeatCanaryTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatCanaryTargetRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatCanaryTargetRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *HeartbeatCanaryTargetRequest) GetServicePrefix() string {
+	if x != nil {
+		return x.ServicePrefix
+	}
+	return ""
+}
+
+func (x *HeartbeatCanaryTargetRequest) GetTarget() *CanaryTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *HeartbeatCanaryTargetRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type HeartbeatCanaryTargetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatCanaryTargetResponse) Reset() {
+	*x = HeartbeatCanaryTargetResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatCanaryTargetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatCanaryTargetResponse) ProtoMessage() {}
+
+func (x *HeartbeatCanaryTargetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatCanaryTargetResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatCanaryTargetResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{67}
+}
+
+type DeregisterCanaryTargetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServicePrefix string                 `protobuf:"bytes,1,opt,name=service_prefix,json=servicePrefix,proto3" json:"service_prefix,omitempty"`
+	Target        *CanaryTarget          `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterCanaryTargetRequest) Reset() {
+	*x = DeregisterCanaryTargetRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterCanaryTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterCanaryTargetRequest) ProtoMessage() {}
+
+func (x *DeregisterCanaryTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterCanaryTargetRequest.ProtoReflect.Descriptor instead.
+func (*DeregisterCanaryTargetRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DeregisterCanaryTargetRequest) GetServicePrefix() string {
+	if x != nil {
+		return x.ServicePrefix
+	}
+	return ""
+}
+
+func (x *DeregisterCanaryTargetRequest) GetTarget() *CanaryTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+type DeregisterCanaryTargetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterCanaryTargetResponse) Reset() {
+	*x = DeregisterCanaryTargetResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterCanaryTargetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterCanaryTargetResponse) ProtoMessage() {}
+
+func (x *DeregisterCanaryTargetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterCanaryTargetResponse.ProtoReflect.Descriptor instead.
+func (*DeregisterCanaryTargetResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{69}
+}
+
+type GetKeyCanaryIPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         int64                  `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyCanaryIPRequest) Reset() {
+	*x = GetKeyCanaryIPRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyCanaryIPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyCanaryIPRequest) ProtoMessage() {}
+
+func (x *GetKeyCanaryIPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyCanaryIPRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyCanaryIPRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GetKeyCanaryIPRequest) GetKeyId() int64 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+type GetKeyCanaryIPResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CanaryIps          []string               `protobuf:"bytes,1,rep,name=canary_ips,json=canaryIps,proto3" json:"canary_ips,omitempty"`
+	RecommendedTargets []*CanaryTarget        `protobuf:"bytes,3,rep,name=recommended_targets,json=recommendedTargets,proto3" json:"recommended_targets,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetKeyCanaryIPResponse) Reset() {
+	*x = GetKeyCanaryIPResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyCanaryIPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyCanaryIPResponse) ProtoMessage() {}
+
+func (x *GetKeyCanaryIPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyCanaryIPResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyCanaryIPResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetKeyCanaryIPResponse) GetCanaryIps() []string {
+	if x != nil {
+		return x.CanaryIps
+	}
+	return nil
+}
+
+func (x *GetKeyCanaryIPResponse) GetRecommendedTargets() []*CanaryTarget {
+	if x != nil {
+		return x.RecommendedTargets
+	}
+	return nil
+}
+
+type SetCanaryGateRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Key            string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	MinBakeSeconds int64                  `protobuf:"varint,3,opt,name=min_bake_seconds,json=minBakeSeconds,proto3" json:"min_bake_seconds,omitempty"`
+	// checker is http or prometheus.
+	Checker string `protobuf:"bytes,4,opt,name=checker,proto3" json:"checker,omitempty"`
+	// target is the url for http checker and the query for prometheus checker.
+	Target string `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+	// threshold is the highest healthy value of the prometheus query.
+	Threshold float64 `protobuf:"fixed64,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// failure_threshold is number of unhealthy checks in a row that revert the canary, 0 means 3.
+	FailureThreshold int32 `protobuf:"varint,7,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetCanaryGateRequest) Reset() {
+	*x = SetCanaryGateRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCanaryGateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCanaryGateRequest) ProtoMessage() {}
+
+func (x *SetCanaryGateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCanaryGateRequest.ProtoReflect.Descriptor instead.
+func (*SetCanaryGateRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *SetCanaryGateRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetCanaryGateRequest) GetMinBakeSeconds() int64 {
+	if x != nil {
+		return x.MinBakeSeconds
+	}
+	return 0
+}
+
+func (x *SetCanaryGateRequest) GetChecker() string {
+	if x != nil {
+		return x.Checker
+	}
+	return ""
+}
+
+func (x *SetCanaryGateRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *SetCanaryGateRequest) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *SetCanaryGateRequest) GetFailureThreshold() int32 {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return 0
+}
+
+type SetCanaryGateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCanaryGateResponse) Reset() {
+	*x = SetCanaryGateResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCanaryGateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCanaryGateResponse) ProtoMessage() {}
+
+func (x *SetCanaryGateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCanaryGateResponse.ProtoReflect.Descriptor instead.
+func (*SetCanaryGateResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{73}
+}
+
+type CanaryDecision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	KeyId int64                  `protobuf:"varint,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// decision is promoted or reverted.
+	Decision      string                 `protobuf:"bytes,3,opt,name=decision,proto3" json:"decision,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CanaryDecision) Reset() {
+	*x = CanaryDecision{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CanaryDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryDecision) ProtoMessage() {}
+
+func (x *CanaryDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryDecision.ProtoReflect.Descriptor instead.
+func (*CanaryDecision) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CanaryDecision) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CanaryDecision) GetKeyId() int64 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *CanaryDecision) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *CanaryDecision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CanaryDecision) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+type GetCanaryDecisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         int64                  `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCanaryDecisionsRequest) Reset() {
+	*x = GetCanaryDecisionsRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCanaryDecisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCanaryDecisionsRequest) ProtoMessage() {}
+
+func (x *GetCanaryDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCanaryDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*GetCanaryDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetCanaryDecisionsRequest) GetKeyId() int64 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+type GetCanaryDecisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Decisions     []*CanaryDecision      `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCanaryDecisionsResponse) Reset() {
+	*x = GetCanaryDecisionsResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCanaryDecisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCanaryDecisionsResponse) ProtoMessage() {}
+
+func (x *GetCanaryDecisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCanaryDecisionsResponse.ProtoReflect.Descriptor instead.
+func (*GetCanaryDecisionsResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GetCanaryDecisionsResponse) GetDecisions() []*CanaryDecision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+type Prerequisite struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// key is exact key or prefix ending with /*.
+	Key           string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	RequiredKey   string `protobuf:"bytes,3,opt,name=required_key,json=requiredKey,proto3" json:"required_key,omitempty"`
+	RequiredValue string `protobuf:"bytes,4,opt,name=required_value,json=requiredValue,proto3" json:"required_value,omitempty"`
+	FallbackValue string `protobuf:"bytes,5,opt,name=fallback_value,json=fallbackValue,proto3" json:"fallback_value,omitempty"`
+	CreatedBy     int64  `protobuf:"varint,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Prerequisite) Reset() {
+	*x = Prerequisite{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Prerequisite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prerequisite) ProtoMessage() {}
+
+func (x *Prerequisite) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prerequisite.ProtoReflect.Descriptor instead.
+func (*Prerequisite) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *Prerequisite) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Prerequisite) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Prerequisite) GetRequiredKey() string {
+	if x != nil {
+		return x.RequiredKey
+	}
+	return ""
+}
+
+func (x *Prerequisite) GetRequiredValue() string {
+	if x != nil {
+		return x.RequiredValue
+	}
+	return ""
+}
+
+func (x *Prerequisite) GetFallbackValue() string {
+	if x != nil {
+		return x.FallbackValue
+	}
+	return ""
+}
+
+func (x *Prerequisite) GetCreatedBy() int64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+type AddPrerequisiteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prerequisite  *Prerequisite          `protobuf:"bytes,1,opt,name=prerequisite,proto3" json:"prerequisite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPrerequisiteRequest) Reset() {
+	*x = AddPrerequisiteRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPrerequisiteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPrerequisiteRequest) ProtoMessage() {}
+
+func (x *AddPrerequisiteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPrerequisiteRequest.ProtoReflect.Descriptor instead.
+func (*AddPrerequisiteRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *AddPrerequisiteRequest) GetPrerequisite() *Prerequisite {
+	if x != nil {
+		return x.Prerequisite
+	}
+	return nil
+}
+
+type AddPrerequisiteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPrerequisiteResponse) Reset() {
+	*x = AddPrerequisiteResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPrerequisiteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPrerequisiteResponse) ProtoMessage() {}
+
+func (x *AddPrerequisiteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPrerequisiteResponse.ProtoReflect.Descriptor instead.
+func (*AddPrerequisiteResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{79}
+}
+
+type RemovePrerequisiteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePrerequisiteRequest) Reset() {
+	*x = RemovePrerequisiteRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePrerequisiteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePrerequisiteRequest) ProtoMessage() {}
+
+func (x *RemovePrerequisiteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePrerequisiteRequest.ProtoReflect.Descriptor instead.
+func (*RemovePrerequisiteRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *RemovePrerequisiteRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RemovePrerequisiteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePrerequisiteResponse) Reset() {
+	*x = RemovePrerequisiteResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePrerequisiteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePrerequisiteResponse) ProtoMessage() {}
+
+func (x *RemovePrerequisiteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePrerequisiteResponse.ProtoReflect.Descriptor instead.
+func (*RemovePrerequisiteResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{81}
+}
+
+type GetPrerequisitesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPrerequisitesRequest) Reset() {
+	*x = GetPrerequisitesRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPrerequisitesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPrerequisitesRequest) ProtoMessage() {}
+
+func (x *GetPrerequisitesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPrerequisitesRequest.ProtoReflect.Descriptor instead.
+func (*GetPrerequisitesRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetPrerequisitesRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetPrerequisitesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prerequisites []*Prerequisite        `protobuf:"bytes,1,rep,name=prerequisites,proto3" json:"prerequisites,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPrerequisitesResponse) Reset() {
+	*x = GetPrerequisitesResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPrerequisitesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPrerequisitesResponse) ProtoMessage() {}
+
+func (x *GetPrerequisitesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPrerequisitesResponse.ProtoReflect.Descriptor instead.
+func (*GetPrerequisitesResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *GetPrerequisitesResponse) GetPrerequisites() []*Prerequisite {
+	if x != nil {
+		return x.Prerequisites
+	}
+	return nil
+}
+
+type StaleKey struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Kv         *KV                    `protobuf:"bytes,1,opt,name=kv,proto3" json:"kv,omitempty"`
+	AgeSeconds int64                  `protobuf:"varint,2,opt,name=age_seconds,json=ageSeconds,proto3" json:"age_seconds,omitempty"`
+	Changes    int32                  `protobuf:"varint,3,opt,name=changes,proto3" json:"changes,omitempty"`
+	LastRead   *KeyRead               `protobuf:"bytes,4,opt,name=last_read,json=lastRead,proto3" json:"last_read,omitempty"`
+	// expired temporary key is listed whatever its age.
+	Expired       bool `protobuf:"varint,5,opt,name=expired,proto3" json:"expired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StaleKey) Reset() {
+	*x = StaleKey{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StaleKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StaleKey) ProtoMessage() {}
+
+func (x *StaleKey) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StaleKey.ProtoReflect.Descriptor instead.
+func (*StaleKey) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *StaleKey) GetKv() *KV {
+	if x != nil {
+		return x.Kv
+	}
+	return nil
+}
+
+func (x *StaleKey) GetAgeSeconds() int64 {
+	if x != nil {
+		return x.AgeSeconds
+	}
+	return 0
+}
+
+func (x *StaleKey) GetChanges() int32 {
+	if x != nil {
+		return x.Changes
+	}
+	return 0
+}
+
+func (x *StaleKey) GetLastRead() *KeyRead {
+	if x != nil {
+		return x.LastRead
+	}
+	return nil
+}
+
+func (x *StaleKey) GetExpired() bool {
+	if x != nil {
+		return x.Expired
+	}
+	return false
+}
+
+type GetStaleKeysRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// min_age_seconds defaults to 90 days.
+	MinAgeSeconds int64 `protobuf:"varint,2,opt,name=min_age_seconds,json=minAgeSeconds,proto3" json:"min_age_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStaleKeysRequest) Reset() {
+	*x = GetStaleKeysRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStaleKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStaleKeysRequest) ProtoMessage() {}
+
+func (x *GetStaleKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStaleKeysRequest.ProtoReflect.Descriptor instead.
+func (*GetStaleKeysRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *GetStaleKeysRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *GetStaleKeysRequest) GetMinAgeSeconds() int64 {
+	if x != nil {
+		return x.MinAgeSeconds
+	}
+	return 0
+}
+
+type GetStaleKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*StaleKey            `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStaleKeysResponse) Reset() {
+	*x = GetStaleKeysResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStaleKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStaleKeysResponse) ProtoMessage() {}
+
+func (x *GetStaleKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStaleKeysResponse.ProtoReflect.Descriptor instead.
+func (*GetStaleKeysResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *GetStaleKeysResponse) GetKeys() []*StaleKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type CreateStaleDeleteRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	MinAgeSeconds int64                  `protobuf:"varint,2,opt,name=min_age_seconds,json=minAgeSeconds,proto3" json:"min_age_seconds,omitempty"`
+	// keys to delete, empty means every stale key under the prefix.
+	Keys          []string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStaleDeleteRequestsRequest) Reset() {
+	*x = CreateStaleDeleteRequestsRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStaleDeleteRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStaleDeleteRequestsRequest) ProtoMessage() {}
+
+func (x *CreateStaleDeleteRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStaleDeleteRequestsRequest.ProtoReflect.Descriptor instead.
+func (*CreateStaleDeleteRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *CreateStaleDeleteRequestsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *CreateStaleDeleteRequestsRequest) GetMinAgeSeconds() int64 {
+	if x != nil {
+		return x.MinAgeSeconds
+	}
+	return 0
+}
+
+func (x *CreateStaleDeleteRequestsRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type CreateStaleDeleteRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangeSetId   int64                  `protobuf:"varint,1,opt,name=change_set_id,json=changeSetId,proto3" json:"change_set_id,omitempty"`
+	Keys          []string               `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStaleDeleteRequestsResponse) Reset() {
+	*x = CreateStaleDeleteRequestsResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStaleDeleteRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStaleDeleteRequestsResponse) ProtoMessage() {}
+
+func (x *CreateStaleDeleteRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStaleDeleteRequestsResponse.ProtoReflect.Descriptor instead.
+func (*CreateStaleDeleteRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *CreateStaleDeleteRequestsResponse) GetChangeSetId() int64 {
+	if x != nil {
+		return x.ChangeSetId
+	}
+	return 0
+}
+
+func (x *CreateStaleDeleteRequestsResponse) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type KeyRead struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Key    string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Client string                 `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+	Ip     string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	// count is estimated from sampled reads.
+	Count         int64                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	LastReadTime  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_read_time,json=lastReadTime,proto3" json:"last_read_time,omitempty"`
+	Environment   string                 `protobuf:"bytes,6,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyRead) Reset() {
+	*x = KeyRead{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyRead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRead) ProtoMessage() {}
+
+func (x *KeyRead) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRead.ProtoReflect.Descriptor instead.
+func (*KeyRead) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *KeyRead) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyRead) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *KeyRead) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *KeyRead) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *KeyRead) GetLastReadTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastReadTime
+	}
+	return nil
+}
+
+func (x *KeyRead) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type GetKeyReadsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,3,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyReadsRequest) Reset() {
+	*x = GetKeyReadsRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyReadsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyReadsRequest) ProtoMessage() {}
+
+func (x *GetKeyReadsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyReadsRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyReadsRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *GetKeyReadsRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetKeyReadsRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type GetKeyReadsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reads         []*KeyRead             `protobuf:"bytes,1,rep,name=reads,proto3" json:"reads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyReadsResponse) Reset() {
+	*x = GetKeyReadsResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyReadsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyReadsResponse) ProtoMessage() {}
+
+func (x *GetKeyReadsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyReadsResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyReadsResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GetKeyReadsResponse) GetReads() []*KeyRead {
+	if x != nil {
+		return x.Reads
+	}
+	return nil
+}
+
+type GetKeyDetailRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// environment defaults to production.
+	Environment   string `protobuf:"bytes,3,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyDetailRequest) Reset() {
+	*x = GetKeyDetailRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyDetailRequest) ProtoMessage() {}
+
+func (x *GetKeyDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyDetailRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GetKeyDetailRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetKeyDetailRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type GetKeyDetailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kv            *KV                    `protobuf:"bytes,1,opt,name=kv,proto3" json:"kv,omitempty"`
+	Reads         []*KeyRead             `protobuf:"bytes,2,rep,name=reads,proto3" json:"reads,omitempty"`
+	Metadata      *KeyMetadata           `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyDetailResponse) Reset() {
+	*x = GetKeyDetailResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyDetailResponse) ProtoMessage() {}
+
+func (x *GetKeyDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyDetailResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyDetailResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *GetKeyDetailResponse) GetKv() *KV {
+	if x != nil {
+		return x.Kv
+	}
+	return nil
+}
+
+func (x *GetKeyDetailResponse) GetReads() []*KeyRead {
+	if x != nil {
+		return x.Reads
+	}
+	return nil
+}
+
+func (x *GetKeyDetailResponse) GetMetadata() *KeyMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type RotateSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateSecretsRequest) Reset() {
+	*x = RotateSecretsRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateSecretsRequest) ProtoMessage() {}
+
+func (x *RotateSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateSecretsRequest.ProtoReflect.Descriptor instead.
+func (*RotateSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *RotateSecretsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+type RotateSecretsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rotated       int64                  `protobuf:"varint,1,opt,name=rotated,proto3" json:"rotated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateSecretsResponse) Reset() {
+	*x = RotateSecretsResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateSecretsResponse) ProtoMessage() {}
+
+func (x *RotateSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateSecretsResponse.ProtoReflect.Descriptor instead.
+func (*RotateSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *RotateSecretsResponse) GetRotated() int64 {
+	if x != nil {
+		return x.Rotated
+	}
+	return 0
+}
+
+type WatchKeysRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Ip     string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Client string                 `protobuf:"bytes,3,opt,name=client,proto3" json:"client,omitempty"`
+	// environment defaults to production.
+	Environment string `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	// group is the canary group of the node, it receives canary values approved for the group.
+	Group         string `protobuf:"bytes,6,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchKeysRequest) Reset() {
+	*x = WatchKeysRequest{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchKeysRequest) ProtoMessage() {}
+
+func (x *WatchKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchKeysRequest.ProtoReflect.Descriptor instead.
+func (*WatchKeysRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *WatchKeysRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *WatchKeysRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *WatchKeysRequest) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *WatchKeysRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *WatchKeysRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+type WatchKeysResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Type          WatchKeysResponse_EventType `protobuf:"varint,1,opt,name=type,proto3,enum=kvmiddleware.v1.WatchKeysResponse_EventType" json:"type,omitempty"`
+	Kv            *KV                         `protobuf:"bytes,2,opt,name=kv,proto3" json:"kv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchKeysResponse) Reset() {
+	*x = WatchKeysResponse{}
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchKeysResponse) ProtoMessage() {}
+
+func (x *WatchKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_key_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchKeysResponse.ProtoReflect.Descriptor instead.
+func (*WatchKeysResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_key_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *WatchKeysResponse) GetType() WatchKeysResponse_EventType {
+	if x != nil {
+		return x.Type
+	}
+	return WatchKeysResponse_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *WatchKeysResponse) GetKv() *KV {
+	if x != nil {
+		return x.Kv
+	}
+	return nil
+}
+
+var File_kvmiddleware_v1_key_proto protoreflect.FileDescriptor
+
+const file_kvmiddleware_v1_key_proto_rawDesc = "" +
+	"\n" +
+	"\x19kvmiddleware/v1/key.proto\x12\x0fkvmiddleware.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x03\n" +
+	"\x02KV\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12;\n" +
+	"\vcreate_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\x03R\tcreatedBy\x12\x1f\n" +
+	"\vapproved_by\x18\b \x01(\x03R\n" +
+	"approvedBy\x12\x16\n" +
+	"\x06status\x18\t \x01(\x05R\x06status\x12#\n" +
+	"\rstatus_string\x18\n" +
+	" \x01(\tR\fstatusString\x12$\n" +
+	"\x0ecreated_by_str\x18\v \x01(\tR\fcreatedByStr\x12\"\n" +
+	"\rchange_set_id\x18\f \x01(\x03R\vchangeSetId\x12 \n" +
+	"\venvironment\x18\r \x01(\tR\venvironment\"q\n" +
+	"\rGetKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06client\x18\x02 \x01(\tR\x06client\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12 \n" +
+	"\venvironment\x18\x05 \x01(\tR\venvironmentJ\x04\b\x04\x10\x05\"5\n" +
+	"\x0eGetKeyResponse\x12#\n" +
+	"\x02kv\x18\x01 \x01(\v2\x13.kvmiddleware.v1.KVR\x02kv\"\xc5\x01\n" +
+	"\x0eGetKeysRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x125\n" +
+	"\x16evaluate_prerequisites\x18\x03 \x01(\bR\x15evaluatePrerequisites\x12\x16\n" +
+	"\x06client\x18\x04 \x01(\tR\x06client\x12 \n" +
+	"\venvironment\x18\x06 \x01(\tR\venvironment\x12\x14\n" +
+	"\x05group\x18\a \x01(\tR\x05groupJ\x04\b\x05\x10\x06\"8\n" +
+	"\x0fGetKeysResponse\x12%\n" +
+	"\x03kvs\x18\x01 \x03(\v2\x13.kvmiddleware.v1.KVR\x03kvs\"\x9f\x01\n" +
+	"\x11BrowseKeysRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x1c\n" +
+	"\tseparator\x18\x02 \x01(\tR\tseparator\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12 \n" +
+	"\venvironment\x18\x06 \x01(\tR\venvironmentJ\x04\b\x05\x10\x06\"\xa1\x01\n" +
+	"\n" +
+	"BrowseNode\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x06is_dir\x18\x02 \x01(\bR\x05isDir\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x128\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x1c.kvmiddleware.v1.KeyMetadataR\bmetadata\x12\x18\n" +
+	"\aexpired\x18\x05 \x01(\bR\aexpired\"\xb8\x02\n" +
+	"\vKeyMetadata\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\x12\x16\n" +
+	"\x06ticket\x18\x05 \x01(\tR\x06ticket\x12\x1a\n" +
+	"\blifetime\x18\x06 \x01(\tR\blifetime\x12;\n" +
+	"\vexpiry_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expiryDate\x12;\n" +
+	"\vupdate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\t \x01(\x03R\tupdatedBy\"t\n" +
+	"\x12BrowseKeysResponse\x121\n" +
+	"\x05nodes\x18\x02 \x03(\v2\x1b.kvmiddleware.v1.BrowseNodeR\x05nodes\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursorJ\x04\b\x01\x10\x02R\x04keys\"\xd3\x02\n" +
+	"\x14GetHistoryKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1b\n" +
+	"\tis_prefix\x18\x02 \x01(\bR\bisPrefix\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1a\n" +
+	"\bstatuses\x18\x04 \x03(\x05R\bstatuses\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\x03R\tcreatedBy\x12\x1f\n" +
+	"\vapproved_by\x18\x06 \x01(\x03R\n" +
+	"approvedBy\x12.\n" +
+	"\x04from\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x16\n" +
+	"\x06cursor\x18\t \x01(\tR\x06cursor\x12 \n" +
+	"\venvironment\x18\v \x01(\tR\venvironmentJ\x04\b\n" +
+	"\x10\v\"\x95\x01\n" +
+	"\x15GetHistoryKeyResponse\x12%\n" +
+	"\x03kvs\x18\x01 \x03(\v2\x13.kvmiddleware.v1.KVR\x03kvs\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x124\n" +
+	"\bcomments\x18\x03 \x03(\v2\x18.kvmiddleware.v1.CommentR\bcomments\"\xb7\x02\n" +
+	"\x19PendingApprovalKeyRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05kinds\x18\x02 \x03(\tR\x05kinds\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\x03R\x06author\x12&\n" +
+	"\x0fmin_age_seconds\x18\x04 \x01(\x03R\rminAgeSeconds\x12&\n" +
+	"\x0fmax_age_seconds\x18\x05 \x01(\x03R\rmaxAgeSeconds\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\b \x01(\x05R\x06offset\x12\x1a\n" +
+	"\bapprover\x18\n" +
+	" \x01(\tR\bapprover\x12 \n" +
+	"\venvironment\x18\v \x01(\tR\venvironmentJ\x04\b\t\x10\n" +
+	"\"o\n" +
+	"\x1aPendingApprovalKeyResponse\x120\n" +
+	"\x05items\x18\x02 \x03(\v2\x1a.kvmiddleware.v1.PendingKVR\x05items\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05totalJ\x04\b\x01\x10\x02R\x03kvs\".\n" +
+	"\bDiffLine\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"T\n" +
+	"\n" +
+	"DiffChange\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x0e\n" +
+	"\x02op\x18\x02 \x01(\tR\x02op\x12\x10\n" +
+	"\x03old\x18\x03 \x01(\tR\x03old\x12\x10\n" +
+	"\x03new\x18\x04 \x01(\tR\x03new\"\xa6\x01\n" +
+	"\x04Diff\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03old\x18\x02 \x01(\tR\x03old\x12\x10\n" +
+	"\x03new\x18\x03 \x01(\tR\x03new\x12/\n" +
+	"\x05lines\x18\x04 \x03(\v2\x19.kvmiddleware.v1.DiffLineR\x05lines\x125\n" +
+	"\achanges\x18\x05 \x03(\v2\x1b.kvmiddleware.v1.DiffChangeR\achanges\"\x8c\x02\n" +
+	"\tPendingKV\x12#\n" +
+	"\x02kv\x18\x01 \x01(\v2\x13.kvmiddleware.v1.KVR\x02kv\x12+\n" +
+	"\x06active\x18\x02 \x01(\v2\x13.kvmiddleware.v1.KVR\x06active\x12)\n" +
+	"\x04diff\x18\x03 \x01(\v2\x15.kvmiddleware.v1.DiffR\x04diff\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x124\n" +
+	"\bcomments\x18\x05 \x03(\v2\x18.kvmiddleware.v1.CommentR\bcomments\x128\n" +
+	"\townership\x18\x06 \x01(\v2\x1a.kvmiddleware.v1.OwnershipR\townership\"\x8e\x02\n" +
+	"\tOwnership\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12.\n" +
+	"\x06owners\x18\x03 \x03(\v2\x16.kvmiddleware.v1.OwnerR\x06owners\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x17\n" +
+	"\aon_call\x18\x05 \x01(\tR\x06onCall\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12;\n" +
+	"\vupdate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\b \x01(\x03R\tupdatedBy\"/\n" +
+	"\x05Owner\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xd1\x01\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\x03R\x05keyId\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\x03R\bparentId\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04body\x18\x05 \x01(\tR\x04body\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\x03R\tcreatedBy\x12;\n" +
+	"\vcreate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"m\n" +
+	"\x15DiffHistoryKeyRequest\x12\x17\n" +
+	"\afrom_id\x18\x01 \x01(\x03R\x06fromId\x12\x13\n" +
+	"\x05to_id\x18\x02 \x01(\x03R\x04toId\x12 \n" +
+	"\venvironment\x18\x04 \x01(\tR\venvironmentJ\x04\b\x03\x10\x04\"C\n" +
+	"\x16DiffHistoryKeyResponse\x12)\n" +
+	"\x04diff\x18\x01 \x01(\v2\x15.kvmiddleware.v1.DiffR\x04diff\"\x92\x02\n" +
+	"\x11SearchKeysRequest\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x12\n" +
+	"\x04mode\x18\x04 \x01(\tR\x04mode\x12\x15\n" +
+	"\x06in_key\x18\x05 \x01(\bR\x05inKey\x12\x19\n" +
+	"\bin_value\x18\x06 \x01(\bR\ainValue\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vin_metadata\x18\b \x01(\bR\n" +
+	"inMetadata\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12\x18\n" +
+	"\aexpired\x18\n" +
+	" \x01(\bR\aexpired\x12 \n" +
+	"\venvironment\x18\v \x01(\tR\venvironmentJ\x04\b\x01\x10\x02\";\n" +
+	"\x12SearchKeysResponse\x12%\n" +
+	"\x03kvs\x18\x01 \x03(\v2\x13.kvmiddleware.v1.KVR\x03kvs\"m\n" +
+	"\x13ExportPrefixRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12 \n" +
+	"\venvironment\x18\x04 \x01(\tR\venvironmentJ\x04\b\x03\x10\x04\"*\n" +
+	"\x14ExportPrefixResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x81\x01\n" +
+	"\x13ImportPrefixRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12 \n" +
+	"\venvironment\x18\x05 \x01(\tR\venvironmentJ\x04\b\x04\x10\x05\"r\n" +
+	"\x14ImportPrefixResponse\x12\"\n" +
+	"\rchange_set_id\x18\x01 \x01(\x03R\vchangeSetId\x12\x18\n" +
+	"\achanged\x18\x02 \x03(\tR\achanged\x12\x1c\n" +
+	"\tunchanged\x18\x03 \x03(\tR\tunchanged\"\xbd\x01\n" +
+	"\x12PromoteKeysRequest\x12-\n" +
+	"\x12source_environment\x18\x01 \x01(\tR\x11sourceEnvironment\x12-\n" +
+	"\x12target_environment\x18\x02 \x01(\tR\x11targetEnvironment\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12\x12\n" +
+	"\x04keys\x18\x04 \x03(\tR\x04keys\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRunJ\x04\b\x06\x10\a\"\xa6\x01\n" +
+	"\rPromotionItem\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x06source\x18\x02 \x01(\v2\x13.kvmiddleware.v1.KVR\x06source\x12+\n" +
+	"\x06target\x18\x03 \x01(\v2\x13.kvmiddleware.v1.KVR\x06target\x12)\n" +
+	"\x04diff\x18\x04 \x01(\v2\x15.kvmiddleware.v1.DiffR\x04diff\"\x8d\x01\n" +
+	"\x13PromoteKeysResponse\x12\"\n" +
+	"\rchange_set_id\x18\x01 \x01(\x03R\vchangeSetId\x124\n" +
+	"\x05items\x18\x02 \x03(\v2\x1e.kvmiddleware.v1.PromotionItemR\x05items\x12\x1c\n" +
+	"\tunchanged\x18\x03 \x03(\tR\tunchanged\"\xb5\x01\n" +
+	"\x10UpdateKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x17\n" +
+	"\abase_id\x18\x05 \x01(\x03R\x06baseId\x12$\n" +
+	"\rjustification\x18\x06 \x01(\tR\rjustification\x12 \n" +
+	"\venvironment\x18\a \x01(\tR\venvironmentJ\x04\b\x04\x10\x05\"\x13\n" +
+	"\x11UpdateKeyResponse\"x\n" +
+	"\x16CreateDeleteKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12$\n" +
+	"\rjustification\x18\x03 \x01(\tR\rjustification\x12 \n" +
+	"\venvironment\x18\x04 \x01(\tR\venvironmentJ\x04\b\x02\x10\x03\"\x19\n" +
+	"\x17CreateDeleteKeyResponse\"{\n" +
+	"\x15AmendPlacedKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12 \n" +
+	"\venvironment\x18\x05 \x01(\tR\venvironmentJ\x04\b\x04\x10\x05\"\x18\n" +
+	"\x16AmendPlacedKeyResponse\"T\n" +
+	"\x18WithdrawPlacedKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12 \n" +
+	"\venvironment\x18\x03 \x01(\tR\venvironmentJ\x04\b\x02\x10\x03\"\x1b\n" +
+	"\x19WithdrawPlacedKeyResponse\"a\n" +
+	"\x11AddCommentRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\x03R\x05keyId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\x03R\bparentId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04bodyJ\x04\b\x04\x10\x05\"$\n" +
+	"\x12AddCommentResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"1\n" +
+	"\x12GetCommentsRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\x03R\x05keyIdJ\x04\b\x02\x10\x03\"K\n" +
+	"\x13GetCommentsResponse\x124\n" +
+	"\bcomments\x18\x01 \x03(\v2\x18.kvmiddleware.v1.CommentR\bcomments\"U\n" +
+	"\x13SetOwnershipRequest\x128\n" +
+	"\townership\x18\x01 \x01(\v2\x1a.kvmiddleware.v1.OwnershipR\townershipJ\x04\b\x02\x10\x03\"\x16\n" +
+	"\x14SetOwnershipResponse\"-\n" +
+	"\x13GetOwnershipRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03keyJ\x04\b\x02\x10\x03\"P\n" +
+	"\x14GetOwnershipResponse\x128\n" +
+	"\townership\x18\x01 \x01(\v2\x1a.kvmiddleware.v1.OwnershipR\townership\"Z\n" +
+	"\x18UpdateKeyMetadataRequest\x128\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1c.kvmiddleware.v1.KeyMetadataR\bmetadataJ\x04\b\x02\x10\x03\"\x1b\n" +
+	"\x19UpdateKeyMetadataResponse\"/\n" +
+	"\x15GetKeyMetadataRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03keyJ\x04\b\x02\x10\x03\"R\n" +
+	"\x16GetKeyMetadataResponse\x128\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1c.kvmiddleware.v1.KeyMetadataR\bmetadata\"}\n" +
+	"\x11ApproveKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\x05R\x06status\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12 \n" +
+	"\venvironment\x18\x05 \x01(\tR\venvironmentJ\x04\b\x02\x10\x03\"\x14\n" +
+	"\x12ApproveKeyResponse\"\x83\x01\n" +
+	"\x17ApproveDeleteKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\x05R\x06status\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12 \n" +
+	"\venvironment\x18\x05 \x01(\tR\venvironmentJ\x04\b\x02\x10\x03\"\x1a\n" +
+	"\x18ApproveDeleteKeyResponse\"\x86\x01\n" +
+	"\x17ApproveKeyCanaryRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\x05R\x06status\x12\x19\n" +
+	"\bnodes_ip\x18\x04 \x03(\tR\anodesIp\x12 \n" +
+	"\venvironment\x18\x05 \x01(\tR\venvironmentJ\x04\b\x02\x10\x03\"\x1a\n" +
+	"\x18ApproveKeyCanaryResponse\"Q\n" +
+	"\x10DeleteKeyRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\x03R\x05keyId\x12 \n" +
+	"\venvironment\x18\x03 \x01(\tR\venvironmentJ\x04\b\x02\x10\x03\"\x13\n" +
+	"\x11DeleteKeyResponse\"\x86\x01\n" +
+	"\x14CreateServiceRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05tribe\x18\x02 \x01(\tR\x05tribe\x12\x18\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespaceJ\x04\b\x05\x10\x06\"\x17\n" +
+	"\x15CreateServiceResponse\"\x86\x01\n" +
+	"\x1cApproveKeyCanaryGroupRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\x05R\x06status\x12\x14\n" +
+	"\x05group\x18\x04 \x01(\tR\x05group\x12 \n" +
+	"\venvironment\x18\x05 \x01(\tR\venvironmentJ\x04\b\x02\x10\x03\"\x1f\n" +
+	"\x1dApproveKeyCanaryGroupResponse\"\x88\x01\n" +
+	"\fCanaryTarget\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x17\n" +
+	"\anode_id\x18\x02 \x01(\tR\x06nodeId\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"_\n" +
+	"\x1aRegisterCanaryGroupRequest\x12%\n" +
+	"\x0eservice_prefix\x18\x01 \x01(\tR\rservicePrefix\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05groupJ\x04\b\x03\x10\x04\"\x1d\n" +
+	"\x1bRegisterCanaryGroupResponse\"\xa3\x01\n" +
+	"\x1cHeartbeatCanaryTargetRequest\x12%\n" +
+	"\x0eservice_prefix\x18\x01 \x01(\tR\rservicePrefix\x125\n" +
+	"\x06target\x18\x02 \x01(\v2\x1d.kvmiddleware.v1.CanaryTargetR\x06target\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x03R\n" +
+	"ttlSecondsJ\x04\b\x04\x10\x05\"\x1f\n" +
+	"\x1dHeartbeatCanaryTargetResponse\"\x83\x01\n" +
+	"\x1dDeregisterCanaryTargetRequest\x12%\n" +
+	"\x0eservice_prefix\x18\x01 \x01(\tR\rservicePrefix\x125\n" +
+	"\x06target\x18\x02 \x01(\v2\x1d.kvmiddleware.v1.CanaryTargetR\x06targetJ\x04\b\x03\x10\x04\" \n" +
+	"\x1eDeregisterCanaryTargetResponse\"4\n" +
+	"\x15GetKeyCanaryIPRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\x03R\x05keyIdJ\x04\b\x02\x10\x03\"\x9e\x01\n" +
+	"\x16GetKeyCanaryIPResponse\x12\x1d\n" +
+	"\n" +
+	"canary_ips\x18\x01 \x03(\tR\tcanaryIps\x12N\n" +
+	"\x13recommended_targets\x18\x03 \x03(\v2\x1d.kvmiddleware.v1.CanaryTargetR\x12recommendedTargetsJ\x04\b\x02\x10\x03R\x0frecommended_ips\"\xd5\x01\n" +
+	"\x14SetCanaryGateRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12(\n" +
+	"\x10min_bake_seconds\x18\x03 \x01(\x03R\x0eminBakeSeconds\x12\x18\n" +
+	"\achecker\x18\x04 \x01(\tR\achecker\x12\x16\n" +
+	"\x06target\x18\x05 \x01(\tR\x06target\x12\x1c\n" +
+	"\tthreshold\x18\x06 \x01(\x01R\tthreshold\x12+\n" +
+	"\x11failure_threshold\x18\a \x01(\x05R\x10failureThresholdJ\x04\b\x02\x10\x03\"\x17\n" +
+	"\x15SetCanaryGateResponse\"\xa8\x01\n" +
+	"\x0eCanaryDecision\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\x03R\x05keyId\x12\x1a\n" +
+	"\bdecision\x18\x03 \x01(\tR\bdecision\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12;\n" +
+	"\vcreate_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"2\n" +
+	"\x19GetCanaryDecisionsRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\x03R\x05keyId\"[\n" +
+	"\x1aGetCanaryDecisionsResponse\x12=\n" +
+	"\tdecisions\x18\x01 \x03(\v2\x1f.kvmiddleware.v1.CanaryDecisionR\tdecisions\"\xc0\x01\n" +
+	"\fPrerequisite\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12!\n" +
+	"\frequired_key\x18\x03 \x01(\tR\vrequiredKey\x12%\n" +
+	"\x0erequired_value\x18\x04 \x01(\tR\rrequiredValue\x12%\n" +
+	"\x0efallback_value\x18\x05 \x01(\tR\rfallbackValue\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\x03R\tcreatedBy\"a\n" +
+	"\x16AddPrerequisiteRequest\x12A\n" +
+	"\fprerequisite\x18\x01 \x01(\v2\x1d.kvmiddleware.v1.PrerequisiteR\fprerequisiteJ\x04\b\x02\x10\x03\"\x19\n" +
+	"\x17AddPrerequisiteResponse\"1\n" +
+	"\x19RemovePrerequisiteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02idJ\x04\b\x02\x10\x03\"\x1c\n" +
+	"\x1aRemovePrerequisiteResponse\"+\n" +
+	"\x17GetPrerequisitesRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"_\n" +
+	"\x18GetPrerequisitesResponse\x12C\n" +
+	"\rprerequisites\x18\x01 \x03(\v2\x1d.kvmiddleware.v1.PrerequisiteR\rprerequisites\"\xbb\x01\n" +
+	"\bStaleKey\x12#\n" +
+	"\x02kv\x18\x01 \x01(\v2\x13.kvmiddleware.v1.KVR\x02kv\x12\x1f\n" +
+	"\vage_seconds\x18\x02 \x01(\x03R\n" +
+	"ageSeconds\x12\x18\n" +
+	"\achanges\x18\x03 \x01(\x05R\achanges\x125\n" +
+	"\tlast_read\x18\x04 \x01(\v2\x18.kvmiddleware.v1.KeyReadR\blastRead\x12\x18\n" +
+	"\aexpired\x18\x05 \x01(\bR\aexpired\"[\n" +
+	"\x13GetStaleKeysRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12&\n" +
+	"\x0fmin_age_seconds\x18\x02 \x01(\x03R\rminAgeSecondsJ\x04\b\x03\x10\x04\"E\n" +
+	"\x14GetStaleKeysResponse\x12-\n" +
+	"\x04keys\x18\x01 \x03(\v2\x19.kvmiddleware.v1.StaleKeyR\x04keys\"|\n" +
+	" CreateStaleDeleteRequestsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12&\n" +
+	"\x0fmin_age_seconds\x18\x02 \x01(\x03R\rminAgeSeconds\x12\x12\n" +
+	"\x04keys\x18\x03 \x03(\tR\x04keysJ\x04\b\x04\x10\x05\"[\n" +
+	"!CreateStaleDeleteRequestsResponse\x12\"\n" +
+	"\rchange_set_id\x18\x01 \x01(\x03R\vchangeSetId\x12\x12\n" +
+	"\x04keys\x18\x02 \x03(\tR\x04keys\"\xbd\x01\n" +
+	"\aKeyRead\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06client\x18\x02 \x01(\tR\x06client\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\x12@\n" +
+	"\x0elast_read_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\flastReadTime\x12 \n" +
+	"\venvironment\x18\x06 \x01(\tR\venvironment\"N\n" +
+	"\x12GetKeyReadsRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12 \n" +
+	"\venvironment\x18\x03 \x01(\tR\venvironmentJ\x04\b\x02\x10\x03\"E\n" +
+	"\x13GetKeyReadsResponse\x12.\n" +
+	"\x05reads\x18\x01 \x03(\v2\x18.kvmiddleware.v1.KeyReadR\x05reads\"O\n" +
+	"\x13GetKeyDetailRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12 \n" +
+	"\venvironment\x18\x03 \x01(\tR\venvironmentJ\x04\b\x02\x10\x03\"\xa5\x01\n" +
+	"\x14GetKeyDetailResponse\x12#\n" +
+	"\x02kv\x18\x01 \x01(\v2\x13.kvmiddleware.v1.KVR\x02kv\x12.\n" +
+	"\x05reads\x18\x02 \x03(\v2\x18.kvmiddleware.v1.KeyReadR\x05reads\x128\n" +
+	"\bmetadata\x18\x03 \x01(\v2\x1c.kvmiddleware.v1.KeyMetadataR\bmetadata\"4\n" +
+	"\x14RotateSecretsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefixJ\x04\b\x02\x10\x03\"1\n" +
+	"\x15RotateSecretsResponse\x12\x18\n" +
+	"\arotated\x18\x01 \x01(\x03R\arotated\"\x90\x01\n" +
+	"\x10WatchKeysRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x16\n" +
+	"\x06client\x18\x03 \x01(\tR\x06client\x12 \n" +
+	"\venvironment\x18\x05 \x01(\tR\venvironment\x12\x14\n" +
+	"\x05group\x18\x06 \x01(\tR\x05groupJ\x04\b\x04\x10\x05\"\xce\x01\n" +
+	"\x11WatchKeysResponse\x12@\n" +
+	"\x04type\x18\x01 \x01(\x0e2,.kvmiddleware.v1.WatchKeysResponse.EventTypeR\x04type\x12#\n" +
+	"\x02kv\x18\x02 \x01(\v2\x13.kvmiddleware.v1.KVR\x02kv\"R\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eEVENT_TYPE_PUT\x10\x01\x12\x15\n" +
+	"\x11EVENT_TYPE_DELETE\x10\x022\xd5\x1f\n" +
+	"\n" +
+	"KeyService\x12I\n" +
+	"\x06GetKey\x12\x1e.kvmiddleware.v1.GetKeyRequest\x1a\x1f.kvmiddleware.v1.GetKeyResponse\x12L\n" +
+	"\aGetKeys\x12\x1f.kvmiddleware.v1.GetKeysRequest\x1a .kvmiddleware.v1.GetKeysResponse\x12U\n" +
+	"\n" +
+	"BrowseKeys\x12\".kvmiddleware.v1.BrowseKeysRequest\x1a#.kvmiddleware.v1.BrowseKeysResponse\x12^\n" +
+	"\rGetHistoryKey\x12%.kvmiddleware.v1.GetHistoryKeyRequest\x1a&.kvmiddleware.v1.GetHistoryKeyResponse\x12m\n" +
+	"\x12PendingApprovalKey\x12*.kvmiddleware.v1.PendingApprovalKeyRequest\x1a+.kvmiddleware.v1.PendingApprovalKeyResponse\x12a\n" +
+	"\x0eDiffHistoryKey\x12&.kvmiddleware.v1.DiffHistoryKeyRequest\x1a'.kvmiddleware.v1.DiffHistoryKeyResponse\x12U\n" +
+	"\n" +
+	"SearchKeys\x12\".kvmiddleware.v1.SearchKeysRequest\x1a#.kvmiddleware.v1.SearchKeysResponse\x12[\n" +
+	"\fExportPrefix\x12$.kvmiddleware.v1.ExportPrefixRequest\x1a%.kvmiddleware.v1.ExportPrefixResponse\x12[\n" +
+	"\fImportPrefix\x12$.kvmiddleware.v1.ImportPrefixRequest\x1a%.kvmiddleware.v1.ImportPrefixResponse\x12X\n" +
+	"\vPromoteKeys\x12#.kvmiddleware.v1.PromoteKeysRequest\x1a$.kvmiddleware.v1.PromoteKeysResponse\x12R\n" +
+	"\tUpdateKey\x12!.kvmiddleware.v1.UpdateKeyRequest\x1a\".kvmiddleware.v1.UpdateKeyResponse\x12d\n" +
+	"\x0fCreateDeleteKey\x12'.kvmiddleware.v1.CreateDeleteKeyRequest\x1a(.kvmiddleware.v1.CreateDeleteKeyResponse\x12a\n" +
+	"\x0eAmendPlacedKey\x12&.kvmiddleware.v1.AmendPlacedKeyRequest\x1a'.kvmiddleware.v1.AmendPlacedKeyResponse\x12j\n" +
+	"\x11WithdrawPlacedKey\x12).kvmiddleware.v1.WithdrawPlacedKeyRequest\x1a*.kvmiddleware.v1.WithdrawPlacedKeyResponse\x12U\n" +
+	"\n" +
+	"AddComment\x12\".kvmiddleware.v1.AddCommentRequest\x1a#.kvmiddleware.v1.AddCommentResponse\x12X\n" +
+	"\vGetComments\x12#.kvmiddleware.v1.GetCommentsRequest\x1a$.kvmiddleware.v1.GetCommentsResponse\x12[\n" +
+	"\fSetOwnership\x12$.kvmiddleware.v1.SetOwnershipRequest\x1a%.kvmiddleware.v1.SetOwnershipResponse\x12[\n" +
+	"\fGetOwnership\x12$.kvmiddleware.v1.GetOwnershipRequest\x1a%.kvmiddleware.v1.GetOwnershipResponse\x12j\n" +
+	"\x11UpdateKeyMetadata\x12).kvmiddleware.v1.UpdateKeyMetadataRequest\x1a*.kvmiddleware.v1.UpdateKeyMetadataResponse\x12a\n" +
+	"\x0eGetKeyMetadata\x12&.kvmiddleware.v1.GetKeyMetadataRequest\x1a'.kvmiddleware.v1.GetKeyMetadataResponse\x12U\n" +
+	"\n" +
+	"ApproveKey\x12\".kvmiddleware.v1.ApproveKeyRequest\x1a#.kvmiddleware.v1.ApproveKeyResponse\x12g\n" +
+	"\x10ApproveDeleteKey\x12(.kvmiddleware.v1.ApproveDeleteKeyRequest\x1a).kvmiddleware.v1.ApproveDeleteKeyResponse\x12g\n" +
+	"\x10ApproveKeyCanary\x12(.kvmiddleware.v1.ApproveKeyCanaryRequest\x1a).kvmiddleware.v1.ApproveKeyCanaryResponse\x12R\n" +
+	"\tDeleteKey\x12!.kvmiddleware.v1.DeleteKeyRequest\x1a\".kvmiddleware.v1.DeleteKeyResponse\x12^\n" +
+	"\rCreateService\x12%.kvmiddleware.v1.CreateServiceRequest\x1a&.kvmiddleware.v1.CreateServiceResponse\x12v\n" +
+	"\x15ApproveKeyCanaryGroup\x12-.kvmiddleware.v1.ApproveKeyCanaryGroupRequest\x1a..kvmiddleware.v1.ApproveKeyCanaryGroupResponse\x12p\n" +
+	"\x13RegisterCanaryGroup\x12+.kvmiddleware.v1.RegisterCanaryGroupRequest\x1a,.kvmiddleware.v1.RegisterCanaryGroupResponse\x12v\n" +
+	"\x15HeartbeatCanaryTarget\x12-.kvmiddleware.v1.HeartbeatCanaryTargetRequest\x1a..kvmiddleware.v1.HeartbeatCanaryTargetResponse\x12y\n" +
+	"\x16DeregisterCanaryTarget\x12..kvmiddleware.v1.DeregisterCanaryTargetRequest\x1a/.kvmiddleware.v1.DeregisterCanaryTargetResponse\x12a\n" +
+	"\x0eGetKeyCanaryIP\x12&.kvmiddleware.v1.GetKeyCanaryIPRequest\x1a'.kvmiddleware.v1.GetKeyCanaryIPResponse\x12^\n" +
+	"\rSetCanaryGate\x12%.kvmiddleware.v1.SetCanaryGateRequest\x1a&.kvmiddleware.v1.SetCanaryGateResponse\x12m\n" +
+	"\x12GetCanaryDecisions\x12*.kvmiddleware.v1.GetCanaryDecisionsRequest\x1a+.kvmiddleware.v1.GetCanaryDecisionsResponse\x12d\n" +
+	"\x0fAddPrerequisite\x12'.kvmiddleware.v1.AddPrerequisiteRequest\x1a(.kvmiddleware.v1.AddPrerequisiteResponse\x12m\n" +
+	"\x12RemovePrerequisite\x12*.kvmiddleware.v1.RemovePrerequisiteRequest\x1a+.kvmiddleware.v1.RemovePrerequisiteResponse\x12g\n" +
+	"\x10GetPrerequisites\x12(.kvmiddleware.v1.GetPrerequisitesRequest\x1a).kvmiddleware.v1.GetPrerequisitesResponse\x12[\n" +
+	"\fGetStaleKeys\x12$.kvmiddleware.v1.GetStaleKeysRequest\x1a%.kvmiddleware.v1.GetStaleKeysResponse\x12\x82\x01\n" +
+	"\x19CreateStaleDeleteRequests\x121.kvmiddleware.v1.CreateStaleDeleteRequestsRequest\x1a2.kvmiddleware.v1.CreateStaleDeleteRequestsResponse\x12X\n" +
+	"\vGetKeyReads\x12#.kvmiddleware.v1.GetKeyReadsRequest\x1a$.kvmiddleware.v1.GetKeyReadsResponse\x12[\n" +
+	"\fGetKeyDetail\x12$.kvmiddleware.v1.GetKeyDetailRequest\x1a%.kvmiddleware.v1.GetKeyDetailResponse\x12^\n" +
+	"\rRotateSecrets\x12%.kvmiddleware.v1.RotateSecretsRequest\x1a&.kvmiddleware.v1.RotateSecretsResponse\x12T\n" +
+	"\tWatchKeys\x12!.kvmiddleware.v1.WatchKeysRequest\x1a\".kvmiddleware.v1.WatchKeysResponse0\x01BIZGgithub.com/marde12345/key-flag/api/proto/kvmiddleware/v1;kvmiddlewarev1b\x06proto3"
+
+var (
+	file_kvmiddleware_v1_key_proto_rawDescOnce sync.Once
+	file_kvmiddleware_v1_key_proto_rawDescData []byte
+)
+
+func file_kvmiddleware_v1_key_proto_rawDescGZIP() []byte {
+	file_kvmiddleware_v1_key_proto_rawDescOnce.Do(func() {
+		file_kvmiddleware_v1_key_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kvmiddleware_v1_key_proto_rawDesc), len(file_kvmiddleware_v1_key_proto_rawDesc)))
+	})
+	return file_kvmiddleware_v1_key_proto_rawDescData
+}
+
+var file_kvmiddleware_v1_key_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_kvmiddleware_v1_key_proto_msgTypes = make([]protoimpl.MessageInfo, 98)
+var file_kvmiddleware_v1_key_proto_goTypes = []any{
+	(WatchKeysResponse_EventType)(0),          // 0: kvmiddleware.v1.WatchKeysResponse.EventType
+	(*KV)(nil),                                // 1: kvmiddleware.v1.KV
+	(*GetKeyRequest)(nil),                     // 2: kvmiddleware.v1.GetKeyRequest
+	(*GetKeyResponse)(nil),                    // 3: kvmiddleware.v1.GetKeyResponse
+	(*GetKeysRequest)(nil),                    // 4: kvmiddleware.v1.GetKeysRequest
+	(*GetKeysResponse)(nil),                   // 5: kvmiddleware.v1.GetKeysResponse
+	(*BrowseKeysRequest)(nil),                 // 6: kvmiddleware.v1.BrowseKeysRequest
+	(*BrowseNode)(nil),                        // 7: kvmiddleware.v1.BrowseNode
+	(*KeyMetadata)(nil),                       // 8: kvmiddleware.v1.KeyMetadata
+	(*BrowseKeysResponse)(nil),                // 9: kvmiddleware.v1.BrowseKeysResponse
+	(*GetHistoryKeyRequest)(nil),              // 10: kvmiddleware.v1.GetHistoryKeyRequest
+	(*GetHistoryKeyResponse)(nil),             // 11: kvmiddleware.v1.GetHistoryKeyResponse
+	(*PendingApprovalKeyRequest)(nil),         // 12: kvmiddleware.v1.PendingApprovalKeyRequest
+	(*PendingApprovalKeyResponse)(nil),        // 13: kvmiddleware.v1.PendingApprovalKeyResponse
+	(*DiffLine)(nil),                          // 14: kvmiddleware.v1.DiffLine
+	(*DiffChange)(nil),                        // 15: kvmiddleware.v1.DiffChange
+	(*Diff)(nil),                              // 16: kvmiddleware.v1.Diff
+	(*PendingKV)(nil),                         // 17: kvmiddleware.v1.PendingKV
+	(*Ownership)(nil),                         // 18: kvmiddleware.v1.Ownership
+	(*Owner)(nil),                             // 19: kvmiddleware.v1.Owner
+	(*Comment)(nil),                           // 20: kvmiddleware.v1.Comment
+	(*DiffHistoryKeyRequest)(nil),             // 21: kvmiddleware.v1.DiffHistoryKeyRequest
+	(*DiffHistoryKeyResponse)(nil),            // 22: kvmiddleware.v1.DiffHistoryKeyResponse
+	(*SearchKeysRequest)(nil),                 // 23: kvmiddleware.v1.SearchKeysRequest
+	(*SearchKeysResponse)(nil),                // 24: kvmiddleware.v1.SearchKeysResponse
+	(*ExportPrefixRequest)(nil),               // 25: kvmiddleware.v1.ExportPrefixRequest
+	(*ExportPrefixResponse)(nil),              // 26: kvmiddleware.v1.ExportPrefixResponse
+	(*ImportPrefixRequest)(nil),               // 27: kvmiddleware.v1.ImportPrefixRequest
+	(*ImportPrefixResponse)(nil),              // 28: kvmiddleware.v1.ImportPrefixResponse
+	(*PromoteKeysRequest)(nil),                // 29: kvmiddleware.v1.PromoteKeysRequest
+	(*PromotionItem)(nil),                     // 30: kvmiddleware.v1.PromotionItem
+	(*PromoteKeysResponse)(nil),               // 31: kvmiddleware.v1.PromoteKeysResponse
+	(*UpdateKeyRequest)(nil),                  // 32: kvmiddleware.v1.UpdateKeyRequest
+	(*UpdateKeyResponse)(nil),                 // 33: kvmiddleware.v1.UpdateKeyResponse
+	(*CreateDeleteKeyRequest)(nil),            // 34: kvmiddleware.v1.CreateDeleteKeyRequest
+	(*CreateDeleteKeyResponse)(nil),           // 35: kvmiddleware.v1.CreateDeleteKeyResponse
+	(*AmendPlacedKeyRequest)(nil),             // 36: kvmiddleware.v1.AmendPlacedKeyRequest
+	(*AmendPlacedKeyResponse)(nil),            // 37: kvmiddleware.v1.AmendPlacedKeyResponse
+	(*WithdrawPlacedKeyRequest)(nil),          // 38: kvmiddleware.v1.WithdrawPlacedKeyRequest
+	(*WithdrawPlacedKeyResponse)(nil),         // 39: kvmiddleware.v1.WithdrawPlacedKeyResponse
+	(*AddCommentRequest)(nil),                 // 40: kvmiddleware.v1.AddCommentRequest
+	(*AddCommentResponse)(nil),                // 41: kvmiddleware.v1.AddCommentResponse
+	(*GetCommentsRequest)(nil),                // 42: kvmiddleware.v1.GetCommentsRequest
+	(*GetCommentsResponse)(nil),               // 43: kvmiddleware.v1.GetCommentsResponse
+	(*SetOwnershipRequest)(nil),               // 44: kvmiddleware.v1.SetOwnershipRequest
+	(*SetOwnershipResponse)(nil),              // 45: kvmiddleware.v1.SetOwnershipResponse
+	(*GetOwnershipRequest)(nil),               // 46: kvmiddleware.v1.GetOwnershipRequest
+	(*GetOwnershipResponse)(nil),              // 47: kvmiddleware.v1.GetOwnershipResponse
+	(*UpdateKeyMetadataRequest)(nil),          // 48: kvmiddleware.v1.UpdateKeyMetadataRequest
+	(*UpdateKeyMetadataResponse)(nil),         // 49: kvmiddleware.v1.UpdateKeyMetadataResponse
+	(*GetKeyMetadataRequest)(nil),             // 50: kvmiddleware.v1.GetKeyMetadataRequest
+	(*GetKeyMetadataResponse)(nil),            // 51: kvmiddleware.v1.GetKeyMetadataResponse
+	(*ApproveKeyRequest)(nil),                 // 52: kvmiddleware.v1.ApproveKeyRequest
+	(*ApproveKeyResponse)(nil),                // 53: kvmiddleware.v1.ApproveKeyResponse
+	(*ApproveDeleteKeyRequest)(nil),           // 54: kvmiddleware.v1.ApproveDeleteKeyRequest
+	(*ApproveDeleteKeyResponse)(nil),          // 55: kvmiddleware.v1.ApproveDeleteKeyResponse
+	(*ApproveKeyCanaryRequest)(nil),           // 56: kvmiddleware.v1.ApproveKeyCanaryRequest
+	(*ApproveKeyCanaryResponse)(nil),          // 57: kvmiddleware.v1.ApproveKeyCanaryResponse
+	(*DeleteKeyRequest)(nil),                  // 58: kvmiddleware.v1.DeleteKeyRequest
+	(*DeleteKeyResponse)(nil),                 // 59: kvmiddleware.v1.DeleteKeyResponse
+	(*CreateServiceRequest)(nil),              // 60: kvmiddleware.v1.CreateServiceRequest
+	(*CreateServiceResponse)(nil),             // 61: kvmiddleware.v1.CreateServiceResponse
+	(*ApproveKeyCanaryGroupRequest)(nil),      // 62: kvmiddleware.v1.ApproveKeyCanaryGroupRequest
+	(*ApproveKeyCanaryGroupResponse)(nil),     // 63: kvmiddleware.v1.ApproveKeyCanaryGroupResponse
+	(*CanaryTarget)(nil),                      // 64: kvmiddleware.v1.CanaryTarget
+	(*RegisterCanaryGroupRequest)(nil),        // 65: kvmiddleware.v1.RegisterCanaryGroupRequest
+	(*RegisterCanaryGroupResponse)(nil),       // 66: kvmiddleware.v1.RegisterCanaryGroupResponse
+	(*HeartbeatCanaryTargetRequest)(nil),      // 67: kvmiddleware.v1.HeartbeatCanaryTargetRequest
+	(*HeartbeatCanaryTargetResponse)(nil),     // 68: kvmiddleware.v1.HeartbeatCanaryTargetResponse
+	(*DeregisterCanaryTargetRequest)(nil),     // 69: kvmiddleware.v1.DeregisterCanaryTargetRequest
+	(*DeregisterCanaryTargetResponse)(nil),    // 70: kvmiddleware.v1.DeregisterCanaryTargetResponse
+	(*GetKeyCanaryIPRequest)(nil),             // 71: kvmiddleware.v1.GetKeyCanaryIPRequest
+	(*GetKeyCanaryIPResponse)(nil),            // 72: kvmiddleware.v1.GetKeyCanaryIPResponse
+	(*SetCanaryGateRequest)(nil),              // 73: kvmiddleware.v1.SetCanaryGateRequest
+	(*SetCanaryGateResponse)(nil),             // 74: kvmiddleware.v1.SetCanaryGateResponse
+	(*CanaryDecision)(nil),                    // 75: kvmiddleware.v1.CanaryDecision
+	(*GetCanaryDecisionsRequest)(nil),         // 76: kvmiddleware.v1.GetCanaryDecisionsRequest
+	(*GetCanaryDecisionsResponse)(nil),        // 77: kvmiddleware.v1.GetCanaryDecisionsResponse
+	(*Prerequisite)(nil),                      // 78: kvmiddleware.v1.Prerequisite
+	(*AddPrerequisiteRequest)(nil),            // 79: kvmiddleware.v1.AddPrerequisiteRequest
+	(*AddPrerequisiteResponse)(nil),           // 80: kvmiddleware.v1.AddPrerequisiteResponse
+	(*RemovePrerequisiteRequest)(nil),         // 81: kvmiddleware.v1.RemovePrerequisiteRequest
+	(*RemovePrerequisiteResponse)(nil),        // 82: kvmiddleware.v1.RemovePrerequisiteResponse
+	(*GetPrerequisitesRequest)(nil),           // 83: kvmiddleware.v1.GetPrerequisitesRequest
+	(*GetPrerequisitesResponse)(nil),          // 84: kvmiddleware.v1.GetPrerequisitesResponse
+	(*StaleKey)(nil),                          // 85: kvmiddleware.v1.StaleKey
+	(*GetStaleKeysRequest)(nil),               // 86: kvmiddleware.v1.GetStaleKeysRequest
+	(*GetStaleKeysResponse)(nil),              // 87: kvmiddleware.v1.GetStaleKeysResponse
+	(*CreateStaleDeleteRequestsRequest)(nil),  // 88: kvmiddleware.v1.CreateStaleDeleteRequestsRequest
+	(*CreateStaleDeleteRequestsResponse)(nil), // 89: kvmiddleware.v1.CreateStaleDeleteRequestsResponse
+	(*KeyRead)(nil),                           // 90: kvmiddleware.v1.KeyRead
+	(*GetKeyReadsRequest)(nil),                // 91: kvmiddleware.v1.GetKeyReadsRequest
+	(*GetKeyReadsResponse)(nil),               // 92: kvmiddleware.v1.GetKeyReadsResponse
+	(*GetKeyDetailRequest)(nil),               // 93: kvmiddleware.v1.GetKeyDetailRequest
+	(*GetKeyDetailResponse)(nil),              // 94: kvmiddleware.v1.GetKeyDetailResponse
+	(*RotateSecretsRequest)(nil),              // 95: kvmiddleware.v1.RotateSecretsRequest
+	(*RotateSecretsResponse)(nil),             // 96: kvmiddleware.v1.RotateSecretsResponse
+	(*WatchKeysRequest)(nil),                  // 97: kvmiddleware.v1.WatchKeysRequest
+	(*WatchKeysResponse)(nil),                 // 98: kvmiddleware.v1.WatchKeysResponse
+	(*timestamppb.Timestamp)(nil),             // 99: google.protobuf.Timestamp
+}
+var file_kvmiddleware_v1_key_proto_depIdxs = []int32{
+	99, // 0: kvmiddleware.v1.KV.create_time:type_name -> google.protobuf.Timestamp
+	99, // 1: kvmiddleware.v1.KV.update_time:type_name -> google.protobuf.Timestamp
+	1,  // 2: kvmiddleware.v1.GetKeyResponse.kv:type_name -> kvmiddleware.v1.KV
+	1,  // 3: kvmiddleware.v1.GetKeysResponse.kvs:type_name -> kvmiddleware.v1.KV
+	8,  // 4: kvmiddleware.v1.BrowseNode.metadata:type_name -> kvmiddleware.v1.KeyMetadata
+	99, // 5: kvmiddleware.v1.KeyMetadata.expiry_date:type_name -> google.protobuf.Timestamp
+	99, // 6: kvmiddleware.v1.KeyMetadata.update_time:type_name -> google.protobuf.Timestamp
+	7,  // 7: kvmiddleware.v1.BrowseKeysResponse.nodes:type_name -> kvmiddleware.v1.BrowseNode
+	99, // 8: kvmiddleware.v1.GetHistoryKeyRequest.from:type_name -> google.protobuf.Timestamp
+	99, // 9: kvmiddleware.v1.GetHistoryKeyRequest.to:type_name -> google.protobuf.Timestamp
+	1,  // 10: kvmiddleware.v1.GetHistoryKeyResponse.kvs:type_name -> kvmiddleware.v1.KV
+	20, // 11: kvmiddleware.v1.GetHistoryKeyResponse.comments:type_name -> kvmiddleware.v1.Comment
+	17, // 12: kvmiddleware.v1.PendingApprovalKeyResponse.items:type_name -> kvmiddleware.v1.PendingKV
+	14, // 13: kvmiddleware.v1.Diff.lines:type_name -> kvmiddleware.v1.DiffLine
+	15, // 14: kvmiddleware.v1.Diff.changes:type_name -> kvmiddleware.v1.DiffChange
+	1,  // 15: kvmiddleware.v1.PendingKV.kv:type_name -> kvmiddleware.v1.KV
+	1,  // 16: kvmiddleware.v1.PendingKV.active:type_name -> kvmiddleware.v1.KV
+	16, // 17: kvmiddleware.v1.PendingKV.diff:type_name -> kvmiddleware.v1.Diff
+	20, // 18: kvmiddleware.v1.PendingKV.comments:type_name -> kvmiddleware.v1.Comment
+	18, // 19: kvmiddleware.v1.PendingKV.ownership:type_name -> kvmiddleware.v1.Ownership
+	19, // 20: kvmiddleware.v1.Ownership.owners:type_name -> kvmiddleware.v1.Owner
+	99, // 21: kvmiddleware.v1.Ownership.update_time:type_name -> google.protobuf.Timestamp
+	99, // 22: kvmiddleware.v1.Comment.create_time:type_name -> google.protobuf.Timestamp
+	16, // 23: kvmiddleware.v1.DiffHistoryKeyResponse.diff:type_name -> kvmiddleware.v1.Diff
+	1,  // 24: kvmiddleware.v1.SearchKeysResponse.kvs:type_name -> kvmiddleware.v1.KV
+	1,  // 25: kvmiddleware.v1.PromotionItem.source:type_name -> kvmiddleware.v1.KV
+	1,  // 26: kvmiddleware.v1.PromotionItem.target:type_name -> kvmiddleware.v1.KV
+	16, // 27: kvmiddleware.v1.PromotionItem.diff:type_name -> kvmiddleware.v1.Diff
+	30, // 28: kvmiddleware.v1.PromoteKeysResponse.items:type_name -> kvmiddleware.v1.PromotionItem
+	20, // 29: kvmiddleware.v1.GetCommentsResponse.comments:type_name -> kvmiddleware.v1.Comment
+	18, // 30: kvmiddleware.v1.SetOwnershipRequest.ownership:type_name -> kvmiddleware.v1.Ownership
+	18, // 31: kvmiddleware.v1.GetOwnershipResponse.ownership:type_name -> kvmiddleware.v1.Ownership
+	8,  // 32: kvmiddleware.v1.UpdateKeyMetadataRequest.metadata:type_name -> kvmiddleware.v1.KeyMetadata
+	8,  // 33: kvmiddleware.v1.GetKeyMetadataResponse.metadata:type_name -> kvmiddleware.v1.KeyMetadata
+	99, // 34: kvmiddleware.v1.CanaryTarget.expires_at:type_name -> google.protobuf.Timestamp
+	64, // 35: kvmiddleware.v1.HeartbeatCanaryTargetRequest.target:type_name -> kvmiddleware.v1.CanaryTarget
+	64, // 36: kvmiddleware.v1.DeregisterCanaryTargetRequest.target:type_name -> kvmiddleware.v1.CanaryTarget
+	64, // 37: kvmiddleware.v1.GetKeyCanaryIPResponse.recommended_targets:type_name -> kvmiddleware.v1.CanaryTarget
+	99, // 38: kvmiddleware.v1.CanaryDecision.create_time:type_name -> google.protobuf.Timestamp
+	75, // 39: kvmiddleware.v1.GetCanaryDecisionsResponse.decisions:type_name -> kvmiddleware.v1.CanaryDecision
+	78, // 40: kvmiddleware.v1.AddPrerequisiteRequest.prerequisite:type_name -> kvmiddleware.v1.Prerequisite
+	78, // 41: kvmiddleware.v1.GetPrerequisitesResponse.prerequisites:type_name -> kvmiddleware.v1.Prerequisite
+	1,  // 42: kvmiddleware.v1.StaleKey.kv:type_name -> kvmiddleware.v1.KV
+	90, // 43: kvmiddleware.v1.StaleKey.last_read:type_name -> kvmiddleware.v1.KeyRead
+	85, // 44: kvmiddleware.v1.GetStaleKeysResponse.keys:type_name -> kvmiddleware.v1.StaleKey
+	99, // 45: kvmiddleware.v1.KeyRead.last_read_time:type_name -> google.protobuf.Timestamp
+	90, // 46: kvmiddleware.v1.GetKeyReadsResponse.reads:type_name -> kvmiddleware.v1.KeyRead
+	1,  // 47: kvmiddleware.v1.GetKeyDetailResponse.kv:type_name -> kvmiddleware.v1.KV
+	90, // 48: kvmiddleware.v1.GetKeyDetailResponse.reads:type_name -> kvmiddleware.v1.KeyRead
+	8,  // 49: kvmiddleware.v1.GetKeyDetailResponse.metadata:type_name -> kvmiddleware.v1.KeyMetadata
+	0,  // 50: kvmiddleware.v1.WatchKeysResponse.type:type_name -> kvmiddleware.v1.WatchKeysResponse.EventType
+	1,  // 51: kvmiddleware.v1.WatchKeysResponse.kv:type_name -> kvmiddleware.v1.KV
+	2,  // 52: kvmiddleware.v1.KeyService.GetKey:input_type -> kvmiddleware.v1.GetKeyRequest
+	4,  // 53: kvmiddleware.v1.KeyService.GetKeys:input_type -> kvmiddleware.v1.GetKeysRequest
+	6,  // 54: kvmiddleware.v1.KeyService.BrowseKeys:input_type -> kvmiddleware.v1.BrowseKeysRequest
+	10, // 55: kvmiddleware.v1.KeyService.GetHistoryKey:input_type -> kvmiddleware.v1.GetHistoryKeyRequest
+	12, // 56: kvmiddleware.v1.KeyService.PendingApprovalKey:input_type -> kvmiddleware.v1.PendingApprovalKeyRequest
+	21, // 57: kvmiddleware.v1.KeyService.DiffHistoryKey:input_type -> kvmiddleware.v1.DiffHistoryKeyRequest
+	23, // 58: kvmiddleware.v1.KeyService.SearchKeys:input_type -> kvmiddleware.v1.SearchKeysRequest
+	25, // 59: kvmiddleware.v1.KeyService.ExportPrefix:input_type -> kvmiddleware.v1.ExportPrefixRequest
+	27, // 60: kvmiddleware.v1.KeyService.ImportPrefix:input_type -> kvmiddleware.v1.ImportPrefixRequest
+	29, // 61: kvmiddleware.v1.KeyService.PromoteKeys:input_type -> kvmiddleware.v1.PromoteKeysRequest
+	32, // 62: kvmiddleware.v1.KeyService.UpdateKey:input_type -> kvmiddleware.v1.UpdateKeyRequest
+	34, // 63: kvmiddleware.v1.KeyService.CreateDeleteKey:input_type -> kvmiddleware.v1.CreateDeleteKeyRequest
+	36, // 64: kvmiddleware.v1.KeyService.AmendPlacedKey:input_type -> kvmiddleware.v1.AmendPlacedKeyRequest
+	38, // 65: kvmiddleware.v1.KeyService.WithdrawPlacedKey:input_type -> kvmiddleware.v1.WithdrawPlacedKeyRequest
+	40, // 66: kvmiddleware.v1.KeyService.AddComment:input_type -> kvmiddleware.v1.AddCommentRequest
+	42, // 67: kvmiddleware.v1.KeyService.GetComments:input_type -> kvmiddleware.v1.GetCommentsRequest
+	44, // 68: kvmiddleware.v1.KeyService.SetOwnership:input_type -> kvmiddleware.v1.SetOwnershipRequest
+	46, // 69: kvmiddleware.v1.KeyService.GetOwnership:input_type -> kvmiddleware.v1.GetOwnershipRequest
+	48, // 70: kvmiddleware.v1.KeyService.UpdateKeyMetadata:input_type -> kvmiddleware.v1.UpdateKeyMetadataRequest
+	50, // 71: kvmiddleware.v1.KeyService.GetKeyMetadata:input_type -> kvmiddleware.v1.GetKeyMetadataRequest
+	52, // 72: kvmiddleware.v1.KeyService.ApproveKey:input_type -> kvmiddleware.v1.ApproveKeyRequest
+	54, // 73: kvmiddleware.v1.KeyService.ApproveDeleteKey:input_type -> kvmiddleware.v1.ApproveDeleteKeyRequest
+	56, // 74: kvmiddleware.v1.KeyService.ApproveKeyCanary:input_type -> kvmiddleware.v1.ApproveKeyCanaryRequest
+	58, // 75: kvmiddleware.v1.KeyService.DeleteKey:input_type -> kvmiddleware.v1.DeleteKeyRequest
+	60, // 76: kvmiddleware.v1.KeyService.CreateService:input_type -> kvmiddleware.v1.CreateServiceRequest
+	62, // 77: kvmiddleware.v1.KeyService.ApproveKeyCanaryGroup:input_type -> kvmiddleware.v1.ApproveKeyCanaryGroupRequest
+	65, // 78: kvmiddleware.v1.KeyService.RegisterCanaryGroup:input_type -> kvmiddleware.v1.RegisterCanaryGroupRequest
+	67, // 79: kvmiddleware.v1.KeyService.HeartbeatCanaryTarget:input_type -> kvmiddleware.v1.HeartbeatCanaryTargetRequest
+	69, // 80: kvmiddleware.v1.KeyService.DeregisterCanaryTarget:input_type -> kvmiddleware.v1.DeregisterCanaryTargetRequest
+	71, // 81: kvmiddleware.v1.KeyService.GetKeyCanaryIP:input_type -> kvmiddleware.v1.GetKeyCanaryIPRequest
+	73, // 82: kvmiddleware.v1.KeyService.SetCanaryGate:input_type -> kvmiddleware.v1.SetCanaryGateRequest
+	76, // 83: kvmiddleware.v1.KeyService.GetCanaryDecisions:input_type -> kvmiddleware.v1.GetCanaryDecisionsRequest
+	79, // 84: kvmiddleware.v1.KeyService.AddPrerequisite:input_type -> kvmiddleware.v1.AddPrerequisiteRequest
+	81, // 85: kvmiddleware.v1.KeyService.RemovePrerequisite:input_type -> kvmiddleware.v1.RemovePrerequisiteRequest
+	83, // 86: kvmiddleware.v1.KeyService.GetPrerequisites:input_type -> kvmiddleware.v1.GetPrerequisitesRequest
+	86, // 87: kvmiddleware.v1.KeyService.GetStaleKeys:input_type -> kvmiddleware.v1.GetStaleKeysRequest
+	88, // 88: kvmiddleware.v1.KeyService.CreateStaleDeleteRequests:input_type -> kvmiddleware.v1.CreateStaleDeleteRequestsRequest
+	91, // 89: kvmiddleware.v1.KeyService.GetKeyReads:input_type -> kvmiddleware.v1.GetKeyReadsRequest
+	93, // 90: kvmiddleware.v1.KeyService.GetKeyDetail:input_type -> kvmiddleware.v1.GetKeyDetailRequest
+	95, // 91: kvmiddleware.v1.KeyService.RotateSecrets:input_type -> kvmiddleware.v1.RotateSecretsRequest
+	97, // 92: kvmiddleware.v1.KeyService.WatchKeys:input_type -> kvmiddleware.v1.WatchKeysRequest
+	3,  // 93: kvmiddleware.v1.KeyService.GetKey:output_type -> kvmiddleware.v1.GetKeyResponse
+	5,  // 94: kvmiddleware.v1.KeyService.GetKeys:output_type -> kvmiddleware.v1.GetKeysResponse
+	9,  // 95: kvmiddleware.v1.KeyService.BrowseKeys:output_type -> kvmiddleware.v1.BrowseKeysResponse
+	11, // 96: kvmiddleware.v1.KeyService.GetHistoryKey:output_type -> kvmiddleware.v1.GetHistoryKeyResponse
+	13, // 97: kvmiddleware.v1.KeyService.PendingApprovalKey:output_type -> kvmiddleware.v1.PendingApprovalKeyResponse
+	22, // 98: kvmiddleware.v1.KeyService.DiffHistoryKey:output_type -> kvmiddleware.v1.DiffHistoryKeyResponse
+	24, // 99: kvmiddleware.v1.KeyService.SearchKeys:output_type -> kvmiddleware.v1.SearchKeysResponse
+	26, // 100: kvmiddleware.v1.KeyService.ExportPrefix:output_type -> kvmiddleware.v1.ExportPrefixResponse
+	28, // 101: kvmiddleware.v1.KeyService.ImportPrefix:output_type -> kvmiddleware.v1.ImportPrefixResponse
+	31, // 102: kvmiddleware.v1.KeyService.PromoteKeys:output_type -> kvmiddleware.v1.PromoteKeysResponse
+	33, // 103: kvmiddleware.v1.KeyService.UpdateKey:output_type -> kvmiddleware.v1.UpdateKeyResponse
+	35, // 104: kvmiddleware.v1.KeyService.CreateDeleteKey:output_type -> kvmiddleware.v1.CreateDeleteKeyResponse
+	37, // 105: kvmiddleware.v1.KeyService.AmendPlacedKey:output_type -> kvmiddleware.v1.AmendPlacedKeyResponse
+	39, // 106: kvmiddleware.v1.KeyService.WithdrawPlacedKey:output_type -> kvmiddleware.v1.WithdrawPlacedKeyResponse
+	41, // 107: kvmiddleware.v1.KeyService.AddComment:output_type -> kvmiddleware.v1.AddCommentResponse
+	43, // 108: kvmiddleware.v1.KeyService.GetComments:output_type -> kvmiddleware.v1.GetCommentsResponse
+	45, // 109: kvmiddleware.v1.KeyService.SetOwnership:output_type -> kvmiddleware.v1.SetOwnershipResponse
+	47, // 110: kvmiddleware.v1.KeyService.GetOwnership:output_type -> kvmiddleware.v1.GetOwnershipResponse
+	49, // 111: kvmiddleware.v1.KeyService.UpdateKeyMetadata:output_type -> kvmiddleware.v1.UpdateKeyMetadataResponse
+	51, // 112: kvmiddleware.v1.KeyService.GetKeyMetadata:output_type -> kvmiddleware.v1.GetKeyMetadataResponse
+	53, // 113: kvmiddleware.v1.KeyService.ApproveKey:output_type -> kvmiddleware.v1.ApproveKeyResponse
+	55, // 114: kvmiddleware.v1.KeyService.ApproveDeleteKey:output_type -> kvmiddleware.v1.ApproveDeleteKeyResponse
+	57, // 115: kvmiddleware.v1.KeyService.ApproveKeyCanary:output_type -> kvmiddleware.v1.ApproveKeyCanaryResponse
+	59, // 116: kvmiddleware.v1.KeyService.DeleteKey:output_type -> kvmiddleware.v1.DeleteKeyResponse
+	61, // 117: kvmiddleware.v1.KeyService.CreateService:output_type -> kvmiddleware.v1.CreateServiceResponse
+	63, // 118: kvmiddleware.v1.KeyService.ApproveKeyCanaryGroup:output_type -> kvmiddleware.v1.ApproveKeyCanaryGroupResponse
+	66, // 119: kvmiddleware.v1.KeyService.RegisterCanaryGroup:output_type -> kvmiddleware.v1.RegisterCanaryGroupResponse
+	68, // 120: kvmiddleware.v1.KeyService.HeartbeatCanaryTarget:output_type -> kvmiddleware.v1.HeartbeatCanaryTargetResponse
+	70, // 121: kvmiddleware.v1.KeyService.DeregisterCanaryTarget:output_type -> kvmiddleware.v1.DeregisterCanaryTargetResponse
+	72, // 122: kvmiddleware.v1.KeyService.GetKeyCanaryIP:output_type -> kvmiddleware.v1.GetKeyCanaryIPResponse
+	74, // 123: kvmiddleware.v1.KeyService.SetCanaryGate:output_type -> kvmiddleware.v1.SetCanaryGateResponse
+	77, // 124: kvmiddleware.v1.KeyService.GetCanaryDecisions:output_type -> kvmiddleware.v1.GetCanaryDecisionsResponse
+	80, // 125: kvmiddleware.v1.KeyService.AddPrerequisite:output_type -> kvmiddleware.v1.AddPrerequisiteResponse
+	82, // 126: kvmiddleware.v1.KeyService.RemovePrerequisite:output_type -> kvmiddleware.v1.RemovePrerequisiteResponse
+	84, // 127: kvmiddleware.v1.KeyService.GetPrerequisites:output_type -> kvmiddleware.v1.GetPrerequisitesResponse
+	87, // 128: kvmiddleware.v1.KeyService.GetStaleKeys:output_type -> kvmiddleware.v1.GetStaleKeysResponse
+	89, // 129: kvmiddleware.v1.KeyService.CreateStaleDeleteRequests:output_type -> kvmiddleware.v1.CreateStaleDeleteRequestsResponse
+	92, // 130: kvmiddleware.v1.KeyService.GetKeyReads:output_type -> kvmiddleware.v1.GetKeyReadsResponse
+	94, // 131: kvmiddleware.v1.KeyService.GetKeyDetail:output_type -> kvmiddleware.v1.GetKeyDetailResponse
+	96, // 132: kvmiddleware.v1.KeyService.RotateSecrets:output_type -> kvmiddleware.v1.RotateSecretsResponse
+	98, // 133: kvmiddleware.v1.KeyService.WatchKeys:output_type -> kvmiddleware.v1.WatchKeysResponse
+	93, // [93:134] is the sub-list for method output_type
+	52, // [52:93] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
+}
+
+func init() { file_kvmiddleware_v1_key_proto_init() }
+func file_kvmiddleware_v1_key_proto_init() {
+	if File_kvmiddleware_v1_key_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kvmiddleware_v1_key_proto_rawDesc), len(file_kvmiddleware_v1_key_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   98,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kvmiddleware_v1_key_proto_goTypes,
+		DependencyIndexes: file_kvmiddleware_v1_key_proto_depIdxs,
+		EnumInfos:         file_kvmiddleware_v1_key_proto_enumTypes,
+		MessageInfos:      file_kvmiddleware_v1_key_proto_msgTypes,
+	}.Build()
+	File_kvmiddleware_v1_key_proto = out.File
+	file_kvmiddleware_v1_key_proto_goTypes = nil
+	file_kvmiddleware_v1_key_proto_depIdxs = nil
+}
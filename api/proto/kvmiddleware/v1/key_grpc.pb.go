@@ -0,0 +1,1669 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: kvmiddleware/v1/key.proto
+
+package kvmiddlewarev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	KeyService_GetKey_FullMethodName                    = "/kvmiddleware.v1.KeyService/GetKey"
+	KeyService_GetKeys_FullMethodName                   = "/kvmiddleware.v1.KeyService/GetKeys"
+	KeyService_BrowseKeys_FullMethodName                = "/kvmiddleware.v1.KeyService/BrowseKeys"
+	KeyService_GetHistoryKey_FullMethodName             = "/kvmiddleware.v1.KeyService/GetHistoryKey"
+	KeyService_PendingApprovalKey_FullMethodName        = "/kvmiddleware.v1.KeyService/PendingApprovalKey"
+	KeyService_DiffHistoryKey_FullMethodName            = "/kvmiddleware.v1.KeyService/DiffHistoryKey"
+	KeyService_SearchKeys_FullMethodName                = "/kvmiddleware.v1.KeyService/SearchKeys"
+	KeyService_ExportPrefix_FullMethodName              = "/kvmiddleware.v1.KeyService/ExportPrefix"
+	KeyService_ImportPrefix_FullMethodName              = "/kvmiddleware.v1.KeyService/ImportPrefix"
+	KeyService_PromoteKeys_FullMethodName               = "/kvmiddleware.v1.KeyService/PromoteKeys"
+	KeyService_UpdateKey_FullMethodName                 = "/kvmiddleware.v1.KeyService/UpdateKey"
+	KeyService_CreateDeleteKey_FullMethodName           = "/kvmiddleware.v1.KeyService/CreateDeleteKey"
+	KeyService_AmendPlacedKey_FullMethodName            = "/kvmiddleware.v1.KeyService/AmendPlacedKey"
+	KeyService_WithdrawPlacedKey_FullMethodName         = "/kvmiddleware.v1.KeyService/WithdrawPlacedKey"
+	KeyService_AddComment_FullMethodName                = "/kvmiddleware.v1.KeyService/AddComment"
+	KeyService_GetComments_FullMethodName               = "/kvmiddleware.v1.KeyService/GetComments"
+	KeyService_SetOwnership_FullMethodName              = "/kvmiddleware.v1.KeyService/SetOwnership"
+	KeyService_GetOwnership_FullMethodName              = "/kvmiddleware.v1.KeyService/GetOwnership"
+	KeyService_UpdateKeyMetadata_FullMethodName         = "/kvmiddleware.v1.KeyService/UpdateKeyMetadata"
+	KeyService_GetKeyMetadata_FullMethodName            = "/kvmiddleware.v1.KeyService/GetKeyMetadata"
+	KeyService_ApproveKey_FullMethodName                = "/kvmiddleware.v1.KeyService/ApproveKey"
+	KeyService_ApproveDeleteKey_FullMethodName          = "/kvmiddleware.v1.KeyService/ApproveDeleteKey"
+	KeyService_ApproveKeyCanary_FullMethodName          = "/kvmiddleware.v1.KeyService/ApproveKeyCanary"
+	KeyService_DeleteKey_FullMethodName                 = "/kvmiddleware.v1.KeyService/DeleteKey"
+	KeyService_CreateService_FullMethodName             = "/kvmiddleware.v1.KeyService/CreateService"
+	KeyService_ApproveKeyCanaryGroup_FullMethodName     = "/kvmiddleware.v1.KeyService/ApproveKeyCanaryGroup"
+	KeyService_RegisterCanaryGroup_FullMethodName       = "/kvmiddleware.v1.KeyService/RegisterCanaryGroup"
+	KeyService_HeartbeatCanaryTarget_FullMethodName     = "/kvmiddleware.v1.KeyService/HeartbeatCanaryTarget"
+	KeyService_DeregisterCanaryTarget_FullMethodName    = "/kvmiddleware.v1.KeyService/DeregisterCanaryTarget"
+	KeyService_GetKeyCanaryIP_FullMethodName            = "/kvmiddleware.v1.KeyService/GetKeyCanaryIP"
+	KeyService_SetCanaryGate_FullMethodName             = "/kvmiddleware.v1.KeyService/SetCanaryGate"
+	KeyService_GetCanaryDecisions_FullMethodName        = "/kvmiddleware.v1.KeyService/GetCanaryDecisions"
+	KeyService_AddPrerequisite_FullMethodName           = "/kvmiddleware.v1.KeyService/AddPrerequisite"
+	KeyService_RemovePrerequisite_FullMethodName        = "/kvmiddleware.v1.KeyService/RemovePrerequisite"
+	KeyService_GetPrerequisites_FullMethodName          = "/kvmiddleware.v1.KeyService/GetPrerequisites"
+	KeyService_GetStaleKeys_FullMethodName              = "/kvmiddleware.v1.KeyService/GetStaleKeys"
+	KeyService_CreateStaleDeleteRequests_FullMethodName = "/kvmiddleware.v1.KeyService/CreateStaleDeleteRequests"
+	KeyService_GetKeyReads_FullMethodName               = "/kvmiddleware.v1.KeyService/GetKeyReads"
+	KeyService_GetKeyDetail_FullMethodName              = "/kvmiddleware.v1.KeyService/GetKeyDetail"
+	KeyService_RotateSecrets_FullMethodName             = "/kvmiddleware.v1.KeyService/RotateSecrets"
+	KeyService_WatchKeys_FullMethodName                 = "/kvmiddleware.v1.KeyService/WatchKeys"
+)
+
+// KeyServiceClient is the client API for KeyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// KeyService exposes key.Usecase to internal services.
+// The caller is authenticated by the x-username and authorization ("Bearer <token>") metadata,
+// GetKey, GetKeys and WatchKeys also serve anonymous readers with secret values masked.
+type KeyServiceClient interface {
+	GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*GetKeyResponse, error)
+	GetKeys(ctx context.Context, in *GetKeysRequest, opts ...grpc.CallOption) (*GetKeysResponse, error)
+	BrowseKeys(ctx context.Context, in *BrowseKeysRequest, opts ...grpc.CallOption) (*BrowseKeysResponse, error)
+	GetHistoryKey(ctx context.Context, in *GetHistoryKeyRequest, opts ...grpc.CallOption) (*GetHistoryKeyResponse, error)
+	PendingApprovalKey(ctx context.Context, in *PendingApprovalKeyRequest, opts ...grpc.CallOption) (*PendingApprovalKeyResponse, error)
+	DiffHistoryKey(ctx context.Context, in *DiffHistoryKeyRequest, opts ...grpc.CallOption) (*DiffHistoryKeyResponse, error)
+	SearchKeys(ctx context.Context, in *SearchKeysRequest, opts ...grpc.CallOption) (*SearchKeysResponse, error)
+	ExportPrefix(ctx context.Context, in *ExportPrefixRequest, opts ...grpc.CallOption) (*ExportPrefixResponse, error)
+	ImportPrefix(ctx context.Context, in *ImportPrefixRequest, opts ...grpc.CallOption) (*ImportPrefixResponse, error)
+	PromoteKeys(ctx context.Context, in *PromoteKeysRequest, opts ...grpc.CallOption) (*PromoteKeysResponse, error)
+	UpdateKey(ctx context.Context, in *UpdateKeyRequest, opts ...grpc.CallOption) (*UpdateKeyResponse, error)
+	CreateDeleteKey(ctx context.Context, in *CreateDeleteKeyRequest, opts ...grpc.CallOption) (*CreateDeleteKeyResponse, error)
+	// AmendPlacedKey and WithdrawPlacedKey are allowed for the author of the placed key or lead of the key.
+	AmendPlacedKey(ctx context.Context, in *AmendPlacedKeyRequest, opts ...grpc.CallOption) (*AmendPlacedKeyResponse, error)
+	WithdrawPlacedKey(ctx context.Context, in *WithdrawPlacedKeyRequest, opts ...grpc.CallOption) (*WithdrawPlacedKeyResponse, error)
+	// AddComment comments on a pending change, parent_id replies to another comment of the same change.
+	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*AddCommentResponse, error)
+	GetComments(ctx context.Context, in *GetCommentsRequest, opts ...grpc.CallOption) (*GetCommentsResponse, error)
+	// SetOwnership replaces ownership of a prefix, only lead of the prefix can change it.
+	SetOwnership(ctx context.Context, in *SetOwnershipRequest, opts ...grpc.CallOption) (*SetOwnershipResponse, error)
+	// GetOwnership returns ownership of the most specific prefix covering the key.
+	GetOwnership(ctx context.Context, in *GetOwnershipRequest, opts ...grpc.CallOption) (*GetOwnershipResponse, error)
+	// UpdateKeyMetadata replaces metadata of a key, it does not go through value approval.
+	UpdateKeyMetadata(ctx context.Context, in *UpdateKeyMetadataRequest, opts ...grpc.CallOption) (*UpdateKeyMetadataResponse, error)
+	GetKeyMetadata(ctx context.Context, in *GetKeyMetadataRequest, opts ...grpc.CallOption) (*GetKeyMetadataResponse, error)
+	ApproveKey(ctx context.Context, in *ApproveKeyRequest, opts ...grpc.CallOption) (*ApproveKeyResponse, error)
+	ApproveDeleteKey(ctx context.Context, in *ApproveDeleteKeyRequest, opts ...grpc.CallOption) (*ApproveDeleteKeyResponse, error)
+	ApproveKeyCanary(ctx context.Context, in *ApproveKeyCanaryRequest, opts ...grpc.CallOption) (*ApproveKeyCanaryResponse, error)
+	DeleteKey(ctx context.Context, in *DeleteKeyRequest, opts ...grpc.CallOption) (*DeleteKeyResponse, error)
+	CreateService(ctx context.Context, in *CreateServiceRequest, opts ...grpc.CallOption) (*CreateServiceResponse, error)
+	ApproveKeyCanaryGroup(ctx context.Context, in *ApproveKeyCanaryGroupRequest, opts ...grpc.CallOption) (*ApproveKeyCanaryGroupResponse, error)
+	RegisterCanaryGroup(ctx context.Context, in *RegisterCanaryGroupRequest, opts ...grpc.CallOption) (*RegisterCanaryGroupResponse, error)
+	// HeartbeatCanaryTarget is called periodically by the node to stay in its canary group.
+	HeartbeatCanaryTarget(ctx context.Context, in *HeartbeatCanaryTargetRequest, opts ...grpc.CallOption) (*HeartbeatCanaryTargetResponse, error)
+	DeregisterCanaryTarget(ctx context.Context, in *DeregisterCanaryTargetRequest, opts ...grpc.CallOption) (*DeregisterCanaryTargetResponse, error)
+	GetKeyCanaryIP(ctx context.Context, in *GetKeyCanaryIPRequest, opts ...grpc.CallOption) (*GetKeyCanaryIPResponse, error)
+	SetCanaryGate(ctx context.Context, in *SetCanaryGateRequest, opts ...grpc.CallOption) (*SetCanaryGateResponse, error)
+	GetCanaryDecisions(ctx context.Context, in *GetCanaryDecisionsRequest, opts ...grpc.CallOption) (*GetCanaryDecisionsResponse, error)
+	AddPrerequisite(ctx context.Context, in *AddPrerequisiteRequest, opts ...grpc.CallOption) (*AddPrerequisiteResponse, error)
+	RemovePrerequisite(ctx context.Context, in *RemovePrerequisiteRequest, opts ...grpc.CallOption) (*RemovePrerequisiteResponse, error)
+	GetPrerequisites(ctx context.Context, in *GetPrerequisitesRequest, opts ...grpc.CallOption) (*GetPrerequisitesResponse, error)
+	GetStaleKeys(ctx context.Context, in *GetStaleKeysRequest, opts ...grpc.CallOption) (*GetStaleKeysResponse, error)
+	CreateStaleDeleteRequests(ctx context.Context, in *CreateStaleDeleteRequestsRequest, opts ...grpc.CallOption) (*CreateStaleDeleteRequestsResponse, error)
+	GetKeyReads(ctx context.Context, in *GetKeyReadsRequest, opts ...grpc.CallOption) (*GetKeyReadsResponse, error)
+	GetKeyDetail(ctx context.Context, in *GetKeyDetailRequest, opts ...grpc.CallOption) (*GetKeyDetailResponse, error)
+	// RotateSecrets rewraps secret values under the prefix with the current key encryption key.
+	RotateSecrets(ctx context.Context, in *RotateSecretsRequest, opts ...grpc.CallOption) (*RotateSecretsResponse, error)
+	// WatchKeys sends the current keys under a prefix and then every change to them.
+	WatchKeys(ctx context.Context, in *WatchKeysRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchKeysResponse], error)
+}
+
+type keyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKeyServiceClient(cc grpc.ClientConnInterface) KeyServiceClient {
+	return &keyServiceClient{cc}
+}
+
+func (c *keyServiceClient) GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*GetKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeyResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetKeys(ctx context.Context, in *GetKeysRequest, opts ...grpc.CallOption) (*GetKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeysResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) BrowseKeys(ctx context.Context, in *BrowseKeysRequest, opts ...grpc.CallOption) (*BrowseKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BrowseKeysResponse)
+	err := c.cc.Invoke(ctx, KeyService_BrowseKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetHistoryKey(ctx context.Context, in *GetHistoryKeyRequest, opts ...grpc.CallOption) (*GetHistoryKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHistoryKeyResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetHistoryKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) PendingApprovalKey(ctx context.Context, in *PendingApprovalKeyRequest, opts ...grpc.CallOption) (*PendingApprovalKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PendingApprovalKeyResponse)
+	err := c.cc.Invoke(ctx, KeyService_PendingApprovalKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) DiffHistoryKey(ctx context.Context, in *DiffHistoryKeyRequest, opts ...grpc.CallOption) (*DiffHistoryKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffHistoryKeyResponse)
+	err := c.cc.Invoke(ctx, KeyService_DiffHistoryKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) SearchKeys(ctx context.Context, in *SearchKeysRequest, opts ...grpc.CallOption) (*SearchKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchKeysResponse)
+	err := c.cc.Invoke(ctx, KeyService_SearchKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) ExportPrefix(ctx context.Context, in *ExportPrefixRequest, opts ...grpc.CallOption) (*ExportPrefixResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportPrefixResponse)
+	err := c.cc.Invoke(ctx, KeyService_ExportPrefix_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) ImportPrefix(ctx context.Context, in *ImportPrefixRequest, opts ...grpc.CallOption) (*ImportPrefixResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportPrefixResponse)
+	err := c.cc.Invoke(ctx, KeyService_ImportPrefix_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) PromoteKeys(ctx context.Context, in *PromoteKeysRequest, opts ...grpc.CallOption) (*PromoteKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoteKeysResponse)
+	err := c.cc.Invoke(ctx, KeyService_PromoteKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) UpdateKey(ctx context.Context, in *UpdateKeyRequest, opts ...grpc.CallOption) (*UpdateKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateKeyResponse)
+	err := c.cc.Invoke(ctx, KeyService_UpdateKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) CreateDeleteKey(ctx context.Context, in *CreateDeleteKeyRequest, opts ...grpc.CallOption) (*CreateDeleteKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateDeleteKeyResponse)
+	err := c.cc.Invoke(ctx, KeyService_CreateDeleteKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) AmendPlacedKey(ctx context.Context, in *AmendPlacedKeyRequest, opts ...grpc.CallOption) (*AmendPlacedKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AmendPlacedKeyResponse)
+	err := c.cc.Invoke(ctx, KeyService_AmendPlacedKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) WithdrawPlacedKey(ctx context.Context, in *WithdrawPlacedKeyRequest, opts ...grpc.CallOption) (*WithdrawPlacedKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WithdrawPlacedKeyResponse)
+	err := c.cc.Invoke(ctx, KeyService_WithdrawPlacedKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*AddCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddCommentResponse)
+	err := c.cc.Invoke(ctx, KeyService_AddComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetComments(ctx context.Context, in *GetCommentsRequest, opts ...grpc.CallOption) (*GetCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCommentsResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) SetOwnership(ctx context.Context, in *SetOwnershipRequest, opts ...grpc.CallOption) (*SetOwnershipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOwnershipResponse)
+	err := c.cc.Invoke(ctx, KeyService_SetOwnership_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetOwnership(ctx context.Context, in *GetOwnershipRequest, opts ...grpc.CallOption) (*GetOwnershipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOwnershipResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetOwnership_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) UpdateKeyMetadata(ctx context.Context, in *UpdateKeyMetadataRequest, opts ...grpc.CallOption) (*UpdateKeyMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateKeyMetadataResponse)
+	err := c.cc.Invoke(ctx, KeyService_UpdateKeyMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetKeyMetadata(ctx context.Context, in *GetKeyMetadataRequest, opts ...grpc.CallOption) (*GetKeyMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeyMetadataResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetKeyMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) ApproveKey(ctx context.Context, in *ApproveKeyRequest, opts ...grpc.CallOption) (*ApproveKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveKeyResponse)
+	err := c.cc.Invoke(ctx, KeyService_ApproveKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) ApproveDeleteKey(ctx context.Context, in *ApproveDeleteKeyRequest, opts ...grpc.CallOption) (*ApproveDeleteKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveDeleteKeyResponse)
+	err := c.cc.Invoke(ctx, KeyService_ApproveDeleteKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) ApproveKeyCanary(ctx context.Context, in *ApproveKeyCanaryRequest, opts ...grpc.CallOption) (*ApproveKeyCanaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveKeyCanaryResponse)
+	err := c.cc.Invoke(ctx, KeyService_ApproveKeyCanary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) DeleteKey(ctx context.Context, in *DeleteKeyRequest, opts ...grpc.CallOption) (*DeleteKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteKeyResponse)
+	err := c.cc.Invoke(ctx, KeyService_DeleteKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) CreateService(ctx context.Context, in *CreateServiceRequest, opts ...grpc.CallOption) (*CreateServiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateServiceResponse)
+	err := c.cc.Invoke(ctx, KeyService_CreateService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) ApproveKeyCanaryGroup(ctx context.Context, in *ApproveKeyCanaryGroupRequest, opts ...grpc.CallOption) (*ApproveKeyCanaryGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveKeyCanaryGroupResponse)
+	err := c.cc.Invoke(ctx, KeyService_ApproveKeyCanaryGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) RegisterCanaryGroup(ctx context.Context, in *RegisterCanaryGroupRequest, opts ...grpc.CallOption) (*RegisterCanaryGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterCanaryGroupResponse)
+	err := c.cc.Invoke(ctx, KeyService_RegisterCanaryGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) HeartbeatCanaryTarget(ctx context.Context, in *HeartbeatCanaryTargetRequest, opts ...grpc.CallOption) (*HeartbeatCanaryTargetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatCanaryTargetResponse)
+	err := c.cc.Invoke(ctx, KeyService_HeartbeatCanaryTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) DeregisterCanaryTarget(ctx context.Context, in *DeregisterCanaryTargetRequest, opts ...grpc.CallOption) (*DeregisterCanaryTargetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeregisterCanaryTargetResponse)
+	err := c.cc.Invoke(ctx, KeyService_DeregisterCanaryTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetKeyCanaryIP(ctx context.Context, in *GetKeyCanaryIPRequest, opts ...grpc.CallOption) (*GetKeyCanaryIPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeyCanaryIPResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetKeyCanaryIP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) SetCanaryGate(ctx context.Context, in *SetCanaryGateRequest, opts ...grpc.CallOption) (*SetCanaryGateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCanaryGateResponse)
+	err := c.cc.Invoke(ctx, KeyService_SetCanaryGate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetCanaryDecisions(ctx context.Context, in *GetCanaryDecisionsRequest, opts ...grpc.CallOption) (*GetCanaryDecisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCanaryDecisionsResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetCanaryDecisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) AddPrerequisite(ctx context.Context, in *AddPrerequisiteRequest, opts ...grpc.CallOption) (*AddPrerequisiteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddPrerequisiteResponse)
+	err := c.cc.Invoke(ctx, KeyService_AddPrerequisite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) RemovePrerequisite(ctx context.Context, in *RemovePrerequisiteRequest, opts ...grpc.CallOption) (*RemovePrerequisiteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemovePrerequisiteResponse)
+	err := c.cc.Invoke(ctx, KeyService_RemovePrerequisite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetPrerequisites(ctx context.Context, in *GetPrerequisitesRequest, opts ...grpc.CallOption) (*GetPrerequisitesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPrerequisitesResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetPrerequisites_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetStaleKeys(ctx context.Context, in *GetStaleKeysRequest, opts ...grpc.CallOption) (*GetStaleKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStaleKeysResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetStaleKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) CreateStaleDeleteRequests(ctx context.Context, in *CreateStaleDeleteRequestsRequest, opts ...grpc.CallOption) (*CreateStaleDeleteRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateStaleDeleteRequestsResponse)
+	err := c.cc.Invoke(ctx, KeyService_CreateStaleDeleteRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetKeyReads(ctx context.Context, in *GetKeyReadsRequest, opts ...grpc.CallOption) (*GetKeyReadsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeyReadsResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetKeyReads_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) GetKeyDetail(ctx context.Context, in *GetKeyDetailRequest, opts ...grpc.CallOption) (*GetKeyDetailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeyDetailResponse)
+	err := c.cc.Invoke(ctx, KeyService_GetKeyDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) RotateSecrets(ctx context.Context, in *RotateSecretsRequest, opts ...grpc.CallOption) (*RotateSecretsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateSecretsResponse)
+	err := c.cc.Invoke(ctx, KeyService_RotateSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyServiceClient) WatchKeys(ctx context.Context, in *WatchKeysRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchKeysResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KeyService_ServiceDesc.Streams[0], KeyService_WatchKeys_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchKeysRequest, WatchKeysResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeyService_WatchKeysClient = grpc.ServerStreamingClient[WatchKeysResponse]
+
+// KeyServiceServer is the server API for KeyService service.
+// All implementations must embed UnimplementedKeyServiceServer
+// for forward compatibility.
+//
+// KeyService exposes key.Usecase to internal services.
+// The caller is authenticated by the x-username and authorization ("Bearer <token>") metadata,
+// GetKey, GetKeys and WatchKeys also serve anonymous readers with secret values masked.
+type KeyServiceServer interface {
+	GetKey(context.Context, *GetKeyRequest) (*GetKeyResponse, error)
+	GetKeys(context.Context, *GetKeysRequest) (*GetKeysResponse, error)
+	BrowseKeys(context.Context, *BrowseKeysRequest) (*BrowseKeysResponse, error)
+	GetHistoryKey(context.Context, *GetHistoryKeyRequest) (*GetHistoryKeyResponse, error)
+	PendingApprovalKey(context.Context, *PendingApprovalKeyRequest) (*PendingApprovalKeyResponse, error)
+	DiffHistoryKey(context.Context, *DiffHistoryKeyRequest) (*DiffHistoryKeyResponse, error)
+	SearchKeys(context.Context, *SearchKeysRequest) (*SearchKeysResponse, error)
+	ExportPrefix(context.Context, *ExportPrefixRequest) (*ExportPrefixResponse, error)
+	ImportPrefix(context.Context, *ImportPrefixRequest) (*ImportPrefixResponse, error)
+	PromoteKeys(context.Context, *PromoteKeysRequest) (*PromoteKeysResponse, error)
+	UpdateKey(context.Context, *UpdateKeyRequest) (*UpdateKeyResponse, error)
+	CreateDeleteKey(context.Context, *CreateDeleteKeyRequest) (*CreateDeleteKeyResponse, error)
+	// AmendPlacedKey and WithdrawPlacedKey are allowed for the author of the placed key or lead of the key.
+	AmendPlacedKey(context.Context, *AmendPlacedKeyRequest) (*AmendPlacedKeyResponse, error)
+	WithdrawPlacedKey(context.Context, *WithdrawPlacedKeyRequest) (*WithdrawPlacedKeyResponse, error)
+	// AddComment comments on a pending change, parent_id replies to another comment of the same change.
+	AddComment(context.Context, *AddCommentRequest) (*AddCommentResponse, error)
+	GetComments(context.Context, *GetCommentsRequest) (*GetCommentsResponse, error)
+	// SetOwnership replaces ownership of a prefix, only lead of the prefix can change it.
+	SetOwnership(context.Context, *SetOwnershipRequest) (*SetOwnershipResponse, error)
+	// GetOwnership returns ownership of the most specific prefix covering the key.
+	GetOwnership(context.Context, *GetOwnershipRequest) (*GetOwnershipResponse, error)
+	// UpdateKeyMetadata replaces metadata of a key, it does not go through value approval.
+	UpdateKeyMetadata(context.Context, *UpdateKeyMetadataRequest) (*UpdateKeyMetadataResponse, error)
+	GetKeyMetadata(context.Context, *GetKeyMetadataRequest) (*GetKeyMetadataResponse, error)
+	ApproveKey(context.Context, *ApproveKeyRequest) (*ApproveKeyResponse, error)
+	ApproveDeleteKey(context.Context, *ApproveDeleteKeyRequest) (*ApproveDeleteKeyResponse, error)
+	ApproveKeyCanary(context.Context, *ApproveKeyCanaryRequest) (*ApproveKeyCanaryResponse, error)
+	DeleteKey(context.Context, *DeleteKeyRequest) (*DeleteKeyResponse, error)
+	CreateService(context.Context, *CreateServiceRequest) (*CreateServiceResponse, error)
+	ApproveKeyCanaryGroup(context.Context, *ApproveKeyCanaryGroupRequest) (*ApproveKeyCanaryGroupResponse, error)
+	RegisterCanaryGroup(context.Context, *RegisterCanaryGroupRequest) (*RegisterCanaryGroupResponse, error)
+	// HeartbeatCanaryTarget is called periodically by the node to stay in its canary group.
+	HeartbeatCanaryTarget(context.Context, *HeartbeatCanaryTargetRequest) (*HeartbeatCanaryTargetResponse, error)
+	DeregisterCanaryTarget(context.Context, *DeregisterCanaryTargetRequest) (*DeregisterCanaryTargetResponse, error)
+	GetKeyCanaryIP(context.Context, *GetKeyCanaryIPRequest) (*GetKeyCanaryIPResponse, error)
+	SetCanaryGate(context.Context, *SetCanaryGateRequest) (*SetCanaryGateResponse, error)
+	GetCanaryDecisions(context.Context, *GetCanaryDecisionsRequest) (*GetCanaryDecisionsResponse, error)
+	AddPrerequisite(context.Context, *AddPrerequisiteRequest) (*AddPrerequisiteResponse, error)
+	RemovePrerequisite(context.Context, *RemovePrerequisiteRequest) (*RemovePrerequisiteResponse, error)
+	GetPrerequisites(context.Context, *GetPrerequisitesRequest) (*GetPrerequisitesResponse, error)
+	GetStaleKeys(context.Context, *GetStaleKeysRequest) (*GetStaleKeysResponse, error)
+	CreateStaleDeleteRequests(context.Context, *CreateStaleDeleteRequestsRequest) (*CreateStaleDeleteRequestsResponse, error)
+	GetKeyReads(context.Context, *GetKeyReadsRequest) (*GetKeyReadsResponse, error)
+	GetKeyDetail(context.Context, *GetKeyDetailRequest) (*GetKeyDetailResponse, error)
+	// RotateSecrets rewraps secret values under the prefix with the current key encryption key.
+	RotateSecrets(context.Context, *RotateSecretsRequest) (*RotateSecretsResponse, error)
+	// WatchKeys sends the current keys under a prefix and then every change to them.
+	WatchKeys(*WatchKeysRequest, grpc.ServerStreamingServer[WatchKeysResponse]) error
+	mustEmbedUnimplementedKeyServiceServer()
+}
+
+// UnimplementedKeyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKeyServiceServer struct{}
+
+func (UnimplementedKeyServiceServer) GetKey(context.Context, *GetKeyRequest) (*GetKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetKey not implemented")
+}
+func (UnimplementedKeyServiceServer) GetKeys(context.Context, *GetKeysRequest) (*GetKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetKeys not implemented")
+}
+func (UnimplementedKeyServiceServer) BrowseKeys(context.Context, *BrowseKeysRequest) (*BrowseKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BrowseKeys not implemented")
+}
+func (UnimplementedKeyServiceServer) GetHistoryKey(context.Context, *GetHistoryKeyRequest) (*GetHistoryKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHistoryKey not implemented")
+}
+func (UnimplementedKeyServiceServer) PendingApprovalKey(context.Context, *PendingApprovalKeyRequest) (*PendingApprovalKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PendingApprovalKey not implemented")
+}
+func (UnimplementedKeyServiceServer) DiffHistoryKey(context.Context, *DiffHistoryKeyRequest) (*DiffHistoryKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiffHistoryKey not implemented")
+}
+func (UnimplementedKeyServiceServer) SearchKeys(context.Context, *SearchKeysRequest) (*SearchKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchKeys not implemented")
+}
+func (UnimplementedKeyServiceServer) ExportPrefix(context.Context, *ExportPrefixRequest) (*ExportPrefixResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportPrefix not implemented")
+}
+func (UnimplementedKeyServiceServer) ImportPrefix(context.Context, *ImportPrefixRequest) (*ImportPrefixResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportPrefix not implemented")
+}
+func (UnimplementedKeyServiceServer) PromoteKeys(context.Context, *PromoteKeysRequest) (*PromoteKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PromoteKeys not implemented")
+}
+func (UnimplementedKeyServiceServer) UpdateKey(context.Context, *UpdateKeyRequest) (*UpdateKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateKey not implemented")
+}
+func (UnimplementedKeyServiceServer) CreateDeleteKey(context.Context, *CreateDeleteKeyRequest) (*CreateDeleteKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateDeleteKey not implemented")
+}
+func (UnimplementedKeyServiceServer) AmendPlacedKey(context.Context, *AmendPlacedKeyRequest) (*AmendPlacedKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AmendPlacedKey not implemented")
+}
+func (UnimplementedKeyServiceServer) WithdrawPlacedKey(context.Context, *WithdrawPlacedKeyRequest) (*WithdrawPlacedKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WithdrawPlacedKey not implemented")
+}
+func (UnimplementedKeyServiceServer) AddComment(context.Context, *AddCommentRequest) (*AddCommentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddComment not implemented")
+}
+func (UnimplementedKeyServiceServer) GetComments(context.Context, *GetCommentsRequest) (*GetCommentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetComments not implemented")
+}
+func (UnimplementedKeyServiceServer) SetOwnership(context.Context, *SetOwnershipRequest) (*SetOwnershipResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetOwnership not implemented")
+}
+func (UnimplementedKeyServiceServer) GetOwnership(context.Context, *GetOwnershipRequest) (*GetOwnershipResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOwnership not implemented")
+}
+func (UnimplementedKeyServiceServer) UpdateKeyMetadata(context.Context, *UpdateKeyMetadataRequest) (*UpdateKeyMetadataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateKeyMetadata not implemented")
+}
+func (UnimplementedKeyServiceServer) GetKeyMetadata(context.Context, *GetKeyMetadataRequest) (*GetKeyMetadataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetKeyMetadata not implemented")
+}
+func (UnimplementedKeyServiceServer) ApproveKey(context.Context, *ApproveKeyRequest) (*ApproveKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveKey not implemented")
+}
+func (UnimplementedKeyServiceServer) ApproveDeleteKey(context.Context, *ApproveDeleteKeyRequest) (*ApproveDeleteKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveDeleteKey not implemented")
+}
+func (UnimplementedKeyServiceServer) ApproveKeyCanary(context.Context, *ApproveKeyCanaryRequest) (*ApproveKeyCanaryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveKeyCanary not implemented")
+}
+func (UnimplementedKeyServiceServer) DeleteKey(context.Context, *DeleteKeyRequest) (*DeleteKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteKey not implemented")
+}
+func (UnimplementedKeyServiceServer) CreateService(context.Context, *CreateServiceRequest) (*CreateServiceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateService not implemented")
+}
+func (UnimplementedKeyServiceServer) ApproveKeyCanaryGroup(context.Context, *ApproveKeyCanaryGroupRequest) (*ApproveKeyCanaryGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveKeyCanaryGroup not implemented")
+}
+func (UnimplementedKeyServiceServer) RegisterCanaryGroup(context.Context, *RegisterCanaryGroupRequest) (*RegisterCanaryGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterCanaryGroup not implemented")
+}
+func (UnimplementedKeyServiceServer) HeartbeatCanaryTarget(context.Context, *HeartbeatCanaryTargetRequest) (*HeartbeatCanaryTargetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method HeartbeatCanaryTarget not implemented")
+}
+func (UnimplementedKeyServiceServer) DeregisterCanaryTarget(context.Context, *DeregisterCanaryTargetRequest) (*DeregisterCanaryTargetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeregisterCanaryTarget not implemented")
+}
+func (UnimplementedKeyServiceServer) GetKeyCanaryIP(context.Context, *GetKeyCanaryIPRequest) (*GetKeyCanaryIPResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetKeyCanaryIP not implemented")
+}
+func (UnimplementedKeyServiceServer) SetCanaryGate(context.Context, *SetCanaryGateRequest) (*SetCanaryGateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCanaryGate not implemented")
+}
+func (UnimplementedKeyServiceServer) GetCanaryDecisions(context.Context, *GetCanaryDecisionsRequest) (*GetCanaryDecisionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCanaryDecisions not implemented")
+}
+func (UnimplementedKeyServiceServer) AddPrerequisite(context.Context, *AddPrerequisiteRequest) (*AddPrerequisiteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddPrerequisite not implemented")
+}
+func (UnimplementedKeyServiceServer) RemovePrerequisite(context.Context, *RemovePrerequisiteRequest) (*RemovePrerequisiteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemovePrerequisite not implemented")
+}
+func (UnimplementedKeyServiceServer) GetPrerequisites(context.Context, *GetPrerequisitesRequest) (*GetPrerequisitesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPrerequisites not implemented")
+}
+func (UnimplementedKeyServiceServer) GetStaleKeys(context.Context, *GetStaleKeysRequest) (*GetStaleKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStaleKeys not implemented")
+}
+func (UnimplementedKeyServiceServer) CreateStaleDeleteRequests(context.Context, *CreateStaleDeleteRequestsRequest) (*CreateStaleDeleteRequestsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateStaleDeleteRequests not implemented")
+}
+func (UnimplementedKeyServiceServer) GetKeyReads(context.Context, *GetKeyReadsRequest) (*GetKeyReadsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetKeyReads not implemented")
+}
+func (UnimplementedKeyServiceServer) GetKeyDetail(context.Context, *GetKeyDetailRequest) (*GetKeyDetailResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetKeyDetail not implemented")
+}
+func (UnimplementedKeyServiceServer) RotateSecrets(context.Context, *RotateSecretsRequest) (*RotateSecretsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RotateSecrets not implemented")
+}
+func (UnimplementedKeyServiceServer) WatchKeys(*WatchKeysRequest, grpc.ServerStreamingServer[WatchKeysResponse]) error {
+	return status.Error(codes.Unimplemented, "method WatchKeys not implemented")
+}
+func (UnimplementedKeyServiceServer) mustEmbedUnimplementedKeyServiceServer() {}
+func (UnimplementedKeyServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeKeyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KeyServiceServer will
+// result in compilation errors.
+type UnsafeKeyServiceServer interface {
+	mustEmbedUnimplementedKeyServiceServer()
+}
+
+func RegisterKeyServiceServer(s grpc.ServiceRegistrar, srv KeyServiceServer) {
+	// If the following call panics, it indicates UnimplementedKeyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KeyService_ServiceDesc, srv)
+}
+
+func _KeyService_GetKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetKey(ctx, req.(*GetKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetKeys(ctx, req.(*GetKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_BrowseKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BrowseKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).BrowseKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_BrowseKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).BrowseKeys(ctx, req.(*BrowseKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetHistoryKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetHistoryKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetHistoryKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetHistoryKey(ctx, req.(*GetHistoryKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_PendingApprovalKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingApprovalKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).PendingApprovalKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_PendingApprovalKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).PendingApprovalKey(ctx, req.(*PendingApprovalKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_DiffHistoryKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffHistoryKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).DiffHistoryKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_DiffHistoryKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).DiffHistoryKey(ctx, req.(*DiffHistoryKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_SearchKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).SearchKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_SearchKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).SearchKeys(ctx, req.(*SearchKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_ExportPrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPrefixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).ExportPrefix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_ExportPrefix_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).ExportPrefix(ctx, req.(*ExportPrefixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_ImportPrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportPrefixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).ImportPrefix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_ImportPrefix_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).ImportPrefix(ctx, req.(*ImportPrefixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_PromoteKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).PromoteKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_PromoteKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).PromoteKeys(ctx, req.(*PromoteKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_UpdateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).UpdateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_UpdateKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).UpdateKey(ctx, req.(*UpdateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_CreateDeleteKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeleteKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).CreateDeleteKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_CreateDeleteKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).CreateDeleteKey(ctx, req.(*CreateDeleteKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_AmendPlacedKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendPlacedKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).AmendPlacedKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_AmendPlacedKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).AmendPlacedKey(ctx, req.(*AmendPlacedKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_WithdrawPlacedKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawPlacedKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).WithdrawPlacedKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_WithdrawPlacedKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).WithdrawPlacedKey(ctx, req.(*WithdrawPlacedKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).AddComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_AddComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).AddComment(ctx, req.(*AddCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetComments(ctx, req.(*GetCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_SetOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).SetOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_SetOwnership_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).SetOwnership(ctx, req.(*SetOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetOwnership_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetOwnership(ctx, req.(*GetOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_UpdateKeyMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateKeyMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).UpdateKeyMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_UpdateKeyMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).UpdateKeyMetadata(ctx, req.(*UpdateKeyMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetKeyMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetKeyMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetKeyMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetKeyMetadata(ctx, req.(*GetKeyMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_ApproveKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).ApproveKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_ApproveKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).ApproveKey(ctx, req.(*ApproveKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_ApproveDeleteKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDeleteKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).ApproveDeleteKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_ApproveDeleteKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).ApproveDeleteKey(ctx, req.(*ApproveDeleteKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_ApproveKeyCanary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveKeyCanaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).ApproveKeyCanary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_ApproveKeyCanary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).ApproveKeyCanary(ctx, req.(*ApproveKeyCanaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_DeleteKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).DeleteKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_DeleteKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).DeleteKey(ctx, req.(*DeleteKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_CreateService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).CreateService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_CreateService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).CreateService(ctx, req.(*CreateServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_ApproveKeyCanaryGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveKeyCanaryGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).ApproveKeyCanaryGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_ApproveKeyCanaryGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).ApproveKeyCanaryGroup(ctx, req.(*ApproveKeyCanaryGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_RegisterCanaryGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterCanaryGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).RegisterCanaryGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_RegisterCanaryGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).RegisterCanaryGroup(ctx, req.(*RegisterCanaryGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_HeartbeatCanaryTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatCanaryTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).HeartbeatCanaryTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_HeartbeatCanaryTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).HeartbeatCanaryTarget(ctx, req.(*HeartbeatCanaryTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_DeregisterCanaryTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterCanaryTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).DeregisterCanaryTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_DeregisterCanaryTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).DeregisterCanaryTarget(ctx, req.(*DeregisterCanaryTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetKeyCanaryIP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyCanaryIPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetKeyCanaryIP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetKeyCanaryIP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetKeyCanaryIP(ctx, req.(*GetKeyCanaryIPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_SetCanaryGate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCanaryGateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).SetCanaryGate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_SetCanaryGate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).SetCanaryGate(ctx, req.(*SetCanaryGateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetCanaryDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCanaryDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetCanaryDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetCanaryDecisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetCanaryDecisions(ctx, req.(*GetCanaryDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_AddPrerequisite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPrerequisiteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).AddPrerequisite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_AddPrerequisite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).AddPrerequisite(ctx, req.(*AddPrerequisiteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_RemovePrerequisite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePrerequisiteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).RemovePrerequisite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_RemovePrerequisite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).RemovePrerequisite(ctx, req.(*RemovePrerequisiteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetPrerequisites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPrerequisitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetPrerequisites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetPrerequisites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetPrerequisites(ctx, req.(*GetPrerequisitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetStaleKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStaleKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetStaleKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetStaleKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetStaleKeys(ctx, req.(*GetStaleKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_CreateStaleDeleteRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStaleDeleteRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).CreateStaleDeleteRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_CreateStaleDeleteRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).CreateStaleDeleteRequests(ctx, req.(*CreateStaleDeleteRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetKeyReads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyReadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetKeyReads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetKeyReads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetKeyReads(ctx, req.(*GetKeyReadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_GetKeyDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetKeyDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_GetKeyDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetKeyDetail(ctx, req.(*GetKeyDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_RotateSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).RotateSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_RotateSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).RotateSecrets(ctx, req.(*RotateSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyService_WatchKeys_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchKeysRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KeyServiceServer).WatchKeys(m, &grpc.GenericServerStream[WatchKeysRequest, WatchKeysResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeyService_WatchKeysServer = grpc.ServerStreamingServer[WatchKeysResponse]
+
+// KeyService_ServiceDesc is the grpc.ServiceDesc for KeyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KeyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kvmiddleware.v1.KeyService",
+	HandlerType: (*KeyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetKey",
+			Handler:    _KeyService_GetKey_Handler,
+		},
+		{
+			MethodName: "GetKeys",
+			Handler:    _KeyService_GetKeys_Handler,
+		},
+		{
+			MethodName: "BrowseKeys",
+			Handler:    _KeyService_BrowseKeys_Handler,
+		},
+		{
+			MethodName: "GetHistoryKey",
+			Handler:    _KeyService_GetHistoryKey_Handler,
+		},
+		{
+			MethodName: "PendingApprovalKey",
+			Handler:    _KeyService_PendingApprovalKey_Handler,
+		},
+		{
+			MethodName: "DiffHistoryKey",
+			Handler:    _KeyService_DiffHistoryKey_Handler,
+		},
+		{
+			MethodName: "SearchKeys",
+			Handler:    _KeyService_SearchKeys_Handler,
+		},
+		{
+			MethodName: "ExportPrefix",
+			Handler:    _KeyService_ExportPrefix_Handler,
+		},
+		{
+			MethodName: "ImportPrefix",
+			Handler:    _KeyService_ImportPrefix_Handler,
+		},
+		{
+			MethodName: "PromoteKeys",
+			Handler:    _KeyService_PromoteKeys_Handler,
+		},
+		{
+			MethodName: "UpdateKey",
+			Handler:    _KeyService_UpdateKey_Handler,
+		},
+		{
+			MethodName: "CreateDeleteKey",
+			Handler:    _KeyService_CreateDeleteKey_Handler,
+		},
+		{
+			MethodName: "AmendPlacedKey",
+			Handler:    _KeyService_AmendPlacedKey_Handler,
+		},
+		{
+			MethodName: "WithdrawPlacedKey",
+			Handler:    _KeyService_WithdrawPlacedKey_Handler,
+		},
+		{
+			MethodName: "AddComment",
+			Handler:    _KeyService_AddComment_Handler,
+		},
+		{
+			MethodName: "GetComments",
+			Handler:    _KeyService_GetComments_Handler,
+		},
+		{
+			MethodName: "SetOwnership",
+			Handler:    _KeyService_SetOwnership_Handler,
+		},
+		{
+			MethodName: "GetOwnership",
+			Handler:    _KeyService_GetOwnership_Handler,
+		},
+		{
+			MethodName: "UpdateKeyMetadata",
+			Handler:    _KeyService_UpdateKeyMetadata_Handler,
+		},
+		{
+			MethodName: "GetKeyMetadata",
+			Handler:    _KeyService_GetKeyMetadata_Handler,
+		},
+		{
+			MethodName: "ApproveKey",
+			Handler:    _KeyService_ApproveKey_Handler,
+		},
+		{
+			MethodName: "ApproveDeleteKey",
+			Handler:    _KeyService_ApproveDeleteKey_Handler,
+		},
+		{
+			MethodName: "ApproveKeyCanary",
+			Handler:    _KeyService_ApproveKeyCanary_Handler,
+		},
+		{
+			MethodName: "DeleteKey",
+			Handler:    _KeyService_DeleteKey_Handler,
+		},
+		{
+			MethodName: "CreateService",
+			Handler:    _KeyService_CreateService_Handler,
+		},
+		{
+			MethodName: "ApproveKeyCanaryGroup",
+			Handler:    _KeyService_ApproveKeyCanaryGroup_Handler,
+		},
+		{
+			MethodName: "RegisterCanaryGroup",
+			Handler:    _KeyService_RegisterCanaryGroup_Handler,
+		},
+		{
+			MethodName: "HeartbeatCanaryTarget",
+			Handler:    _KeyService_HeartbeatCanaryTarget_Handler,
+		},
+		{
+			MethodName: "DeregisterCanaryTarget",
+			Handler:    _KeyService_DeregisterCanaryTarget_Handler,
+		},
+		{
+			MethodName: "GetKeyCanaryIP",
+			Handler:    _KeyService_GetKeyCanaryIP_Handler,
+		},
+		{
+			MethodName: "SetCanaryGate",
+			Handler:    _KeyService_SetCanaryGate_Handler,
+		},
+		{
+			MethodName: "GetCanaryDecisions",
+			Handler:    _KeyService_GetCanaryDecisions_Handler,
+		},
+		{
+			MethodName: "AddPrerequisite",
+			Handler:    _KeyService_AddPrerequisite_Handler,
+		},
+		{
+			MethodName: "RemovePrerequisite",
+			Handler:    _KeyService_RemovePrerequisite_Handler,
+		},
+		{
+			MethodName: "GetPrerequisites",
+			Handler:    _KeyService_GetPrerequisites_Handler,
+		},
+		{
+			MethodName: "GetStaleKeys",
+			Handler:    _KeyService_GetStaleKeys_Handler,
+		},
+		{
+			MethodName: "CreateStaleDeleteRequests",
+			Handler:    _KeyService_CreateStaleDeleteRequests_Handler,
+		},
+		{
+			MethodName: "GetKeyReads",
+			Handler:    _KeyService_GetKeyReads_Handler,
+		},
+		{
+			MethodName: "GetKeyDetail",
+			Handler:    _KeyService_GetKeyDetail_Handler,
+		},
+		{
+			MethodName: "RotateSecrets",
+			Handler:    _KeyService_RotateSecrets_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchKeys",
+			Handler:       _KeyService_WatchKeys_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "kvmiddleware/v1/key.proto",
+}
@@ -0,0 +1,1277 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: kvmiddleware/v1/user.proto
+
+package kvmiddlewarev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type Role struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Permission    string                 `protobuf:"bytes,3,opt,name=permission,proto3" json:"permission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Role) Reset() {
+	*x = Role{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Role) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Role) ProtoMessage() {}
+
+func (x *Role) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Role.ProtoReflect.Descriptor instead.
+func (*Role) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Role) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Role) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *Role) GetPermission() string {
+	if x != nil {
+		return x.Permission
+	}
+	return ""
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type CreateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserResponse) Reset() {
+	*x = CreateUserResponse{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserResponse) ProtoMessage() {}
+
+func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
+func (*CreateUserResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{3}
+}
+
+type GetUserDetailsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserDetailsRequest) Reset() {
+	*x = GetUserDetailsRequest{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserDetailsRequest) ProtoMessage() {}
+
+func (x *GetUserDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetUserDetailsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type GetUserDetailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Roles         []*Role                `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserDetailsResponse) Reset() {
+	*x = GetUserDetailsResponse{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserDetailsResponse) ProtoMessage() {}
+
+func (x *GetUserDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserDetailsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetUserDetailsResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GetUserDetailsResponse) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type CreateRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []*Role                `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoleRequest) Reset() {
+	*x = CreateRoleRequest{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoleRequest) ProtoMessage() {}
+
+func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoleRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoleRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateRoleRequest) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type CreateRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoleResponse) Reset() {
+	*x = CreateRoleResponse{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoleResponse) ProtoMessage() {}
+
+func (x *CreateRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoleResponse.ProtoReflect.Descriptor instead.
+func (*CreateRoleResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{7}
+}
+
+type MapUserAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Roles         []*Role                `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapUserAccessRequest) Reset() {
+	*x = MapUserAccessRequest{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapUserAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapUserAccessRequest) ProtoMessage() {}
+
+func (x *MapUserAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapUserAccessRequest.ProtoReflect.Descriptor instead.
+func (*MapUserAccessRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MapUserAccessRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MapUserAccessRequest) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type MapUserAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapUserAccessResponse) Reset() {
+	*x = MapUserAccessResponse{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapUserAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapUserAccessResponse) ProtoMessage() {}
+
+func (x *MapUserAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapUserAccessResponse.ProtoReflect.Descriptor instead.
+func (*MapUserAccessResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{9}
+}
+
+type GetAllRolesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllRolesRequest) Reset() {
+	*x = GetAllRolesRequest{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllRolesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllRolesRequest) ProtoMessage() {}
+
+func (x *GetAllRolesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllRolesRequest.ProtoReflect.Descriptor instead.
+func (*GetAllRolesRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{10}
+}
+
+type GetAllRolesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []*Role                `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllRolesResponse) Reset() {
+	*x = GetAllRolesResponse{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllRolesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllRolesResponse) ProtoMessage() {}
+
+func (x *GetAllRolesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllRolesResponse.ProtoReflect.Descriptor instead.
+func (*GetAllRolesResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetAllRolesResponse) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type GetRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Permission    string                 `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoleRequest) Reset() {
+	*x = GetRoleRequest{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleRequest) ProtoMessage() {}
+
+func (x *GetRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleRequest.ProtoReflect.Descriptor instead.
+func (*GetRoleRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetRoleRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *GetRoleRequest) GetPermission() string {
+	if x != nil {
+		return x.Permission
+	}
+	return ""
+}
+
+type GetRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          *Role                  `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoleResponse) Reset() {
+	*x = GetRoleResponse{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleResponse) ProtoMessage() {}
+
+func (x *GetRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleResponse.ProtoReflect.Descriptor instead.
+func (*GetRoleResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetRoleResponse) GetRole() *Role {
+	if x != nil {
+		return x.Role
+	}
+	return nil
+}
+
+type RevokeUserAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Roles         []*Role                `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeUserAccessRequest) Reset() {
+	*x = RevokeUserAccessRequest{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeUserAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeUserAccessRequest) ProtoMessage() {}
+
+func (x *RevokeUserAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeUserAccessRequest.ProtoReflect.Descriptor instead.
+func (*RevokeUserAccessRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RevokeUserAccessRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RevokeUserAccessRequest) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type RevokeUserAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeUserAccessResponse) Reset() {
+	*x = RevokeUserAccessResponse{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeUserAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeUserAccessResponse) ProtoMessage() {}
+
+func (x *RevokeUserAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeUserAccessResponse.ProtoReflect.Descriptor instead.
+func (*RevokeUserAccessResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{15}
+}
+
+type SearchRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRoleRequest) Reset() {
+	*x = SearchRoleRequest{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRoleRequest) ProtoMessage() {}
+
+func (x *SearchRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRoleRequest.ProtoReflect.Descriptor instead.
+func (*SearchRoleRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchRoleRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+type SearchRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []*Role                `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRoleResponse) Reset() {
+	*x = SearchRoleResponse{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRoleResponse) ProtoMessage() {}
+
+func (x *SearchRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRoleResponse.ProtoReflect.Descriptor instead.
+func (*SearchRoleResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SearchRoleResponse) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type Namespace struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Root  string                 `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+	// key_quota is the maximum number of keys, 0 means unlimited.
+	KeyQuota      int32 `protobuf:"varint,4,opt,name=key_quota,json=keyQuota,proto3" json:"key_quota,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Namespace) Reset() {
+	*x = Namespace{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Namespace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Namespace) ProtoMessage() {}
+
+func (x *Namespace) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Namespace.ProtoReflect.Descriptor instead.
+func (*Namespace) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Namespace) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Namespace) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Namespace) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *Namespace) GetKeyQuota() int32 {
+	if x != nil {
+		return x.KeyQuota
+	}
+	return 0
+}
+
+type CreateNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     *Namespace             `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	AdminUserId   int64                  `protobuf:"varint,2,opt,name=admin_user_id,json=adminUserId,proto3" json:"admin_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceRequest) Reset() {
+	*x = CreateNamespaceRequest{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceRequest) ProtoMessage() {}
+
+func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateNamespaceRequest) GetNamespace() *Namespace {
+	if x != nil {
+		return x.Namespace
+	}
+	return nil
+}
+
+func (x *CreateNamespaceRequest) GetAdminUserId() int64 {
+	if x != nil {
+		return x.AdminUserId
+	}
+	return 0
+}
+
+type CreateNamespaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceResponse) Reset() {
+	*x = CreateNamespaceResponse{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceResponse) ProtoMessage() {}
+
+func (x *CreateNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{20}
+}
+
+type GetNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNamespaceRequest) Reset() {
+	*x = GetNamespaceRequest{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNamespaceRequest) ProtoMessage() {}
+
+func (x *GetNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*GetNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetNamespaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     *Namespace             `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNamespaceResponse) Reset() {
+	*x = GetNamespaceResponse{}
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNamespaceResponse) ProtoMessage() {}
+
+func (x *GetNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvmiddleware_v1_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*GetNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_kvmiddleware_v1_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetNamespaceResponse) GetNamespace() *Namespace {
+	if x != nil {
+		return x.Namespace
+	}
+	return nil
+}
+
+var File_kvmiddleware_v1_user_proto protoreflect.FileDescriptor
+
+const file_kvmiddleware_v1_user_proto_rawDesc = "" +
+	"\n" +
+	"\x1akvmiddleware/v1/user.proto\x12\x0fkvmiddleware.v1\"H\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"N\n" +
+	"\x04Role\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x1e\n" +
+	"\n" +
+	"permission\x18\x03 \x01(\tR\n" +
+	"permission\"[\n" +
+	"\x11CreateUserRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"\x14\n" +
+	"\x12CreateUserResponse\"3\n" +
+	"\x15GetUserDetailsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"p\n" +
+	"\x16GetUserDetailsResponse\x12)\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.kvmiddleware.v1.UserR\x04user\x12+\n" +
+	"\x05roles\x18\x02 \x03(\v2\x15.kvmiddleware.v1.RoleR\x05roles\"F\n" +
+	"\x11CreateRoleRequest\x12+\n" +
+	"\x05roles\x18\x01 \x03(\v2\x15.kvmiddleware.v1.RoleR\x05rolesJ\x04\b\x02\x10\x03\"\x14\n" +
+	"\x12CreateRoleResponse\"b\n" +
+	"\x14MapUserAccessRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12+\n" +
+	"\x05roles\x18\x02 \x03(\v2\x15.kvmiddleware.v1.RoleR\x05rolesJ\x04\b\x03\x10\x04\"\x17\n" +
+	"\x15MapUserAccessResponse\"\x1a\n" +
+	"\x12GetAllRolesRequestJ\x04\b\x01\x10\x02\"B\n" +
+	"\x13GetAllRolesResponse\x12+\n" +
+	"\x05roles\x18\x01 \x03(\v2\x15.kvmiddleware.v1.RoleR\x05roles\"H\n" +
+	"\x0eGetRoleRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x1e\n" +
+	"\n" +
+	"permission\x18\x02 \x01(\tR\n" +
+	"permission\"<\n" +
+	"\x0fGetRoleResponse\x12)\n" +
+	"\x04role\x18\x01 \x01(\v2\x15.kvmiddleware.v1.RoleR\x04role\"e\n" +
+	"\x17RevokeUserAccessRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12+\n" +
+	"\x05roles\x18\x03 \x03(\v2\x15.kvmiddleware.v1.RoleR\x05rolesJ\x04\b\x02\x10\x03\"\x1a\n" +
+	"\x18RevokeUserAccessResponse\"1\n" +
+	"\x11SearchRoleRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefixJ\x04\b\x02\x10\x03\"A\n" +
+	"\x12SearchRoleResponse\x12+\n" +
+	"\x05roles\x18\x01 \x03(\v2\x15.kvmiddleware.v1.RoleR\x05roles\"`\n" +
+	"\tNamespace\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04root\x18\x03 \x01(\tR\x04root\x12\x1b\n" +
+	"\tkey_quota\x18\x04 \x01(\x05R\bkeyQuota\"|\n" +
+	"\x16CreateNamespaceRequest\x128\n" +
+	"\tnamespace\x18\x01 \x01(\v2\x1a.kvmiddleware.v1.NamespaceR\tnamespace\x12\"\n" +
+	"\radmin_user_id\x18\x02 \x01(\x03R\vadminUserIdJ\x04\b\x03\x10\x04\"\x19\n" +
+	"\x17CreateNamespaceResponse\")\n" +
+	"\x13GetNamespaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"P\n" +
+	"\x14GetNamespaceResponse\x128\n" +
+	"\tnamespace\x18\x01 \x01(\v2\x1a.kvmiddleware.v1.NamespaceR\tnamespace2\xa9\a\n" +
+	"\vUserService\x12U\n" +
+	"\n" +
+	"CreateUser\x12\".kvmiddleware.v1.CreateUserRequest\x1a#.kvmiddleware.v1.CreateUserResponse\x12a\n" +
+	"\x0eGetUserDetails\x12&.kvmiddleware.v1.GetUserDetailsRequest\x1a'.kvmiddleware.v1.GetUserDetailsResponse\x12U\n" +
+	"\n" +
+	"CreateRole\x12\".kvmiddleware.v1.CreateRoleRequest\x1a#.kvmiddleware.v1.CreateRoleResponse\x12^\n" +
+	"\rMapUserAccess\x12%.kvmiddleware.v1.MapUserAccessRequest\x1a&.kvmiddleware.v1.MapUserAccessResponse\x12X\n" +
+	"\vGetAllRoles\x12#.kvmiddleware.v1.GetAllRolesRequest\x1a$.kvmiddleware.v1.GetAllRolesResponse\x12L\n" +
+	"\aGetRole\x12\x1f.kvmiddleware.v1.GetRoleRequest\x1a .kvmiddleware.v1.GetRoleResponse\x12g\n" +
+	"\x10RevokeUserAccess\x12(.kvmiddleware.v1.RevokeUserAccessRequest\x1a).kvmiddleware.v1.RevokeUserAccessResponse\x12U\n" +
+	"\n" +
+	"SearchRole\x12\".kvmiddleware.v1.SearchRoleRequest\x1a#.kvmiddleware.v1.SearchRoleResponse\x12d\n" +
+	"\x0fCreateNamespace\x12'.kvmiddleware.v1.CreateNamespaceRequest\x1a(.kvmiddleware.v1.CreateNamespaceResponse\x12[\n" +
+	"\fGetNamespace\x12$.kvmiddleware.v1.GetNamespaceRequest\x1a%.kvmiddleware.v1.GetNamespaceResponseBIZGgithub.com/marde12345/key-flag/api/proto/kvmiddleware/v1;kvmiddlewarev1b\x06proto3"
+
+var (
+	file_kvmiddleware_v1_user_proto_rawDescOnce sync.Once
+	file_kvmiddleware_v1_user_proto_rawDescData []byte
+)
+
+func file_kvmiddleware_v1_user_proto_rawDescGZIP() []byte {
+	file_kvmiddleware_v1_user_proto_rawDescOnce.Do(func() {
+		file_kvmiddleware_v1_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kvmiddleware_v1_user_proto_rawDesc), len(file_kvmiddleware_v1_user_proto_rawDesc)))
+	})
+	return file_kvmiddleware_v1_user_proto_rawDescData
+}
+
+var file_kvmiddleware_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_kvmiddleware_v1_user_proto_goTypes = []any{
+	(*User)(nil),                     // 0: kvmiddleware.v1.User
+	(*Role)(nil),                     // 1: kvmiddleware.v1.Role
+	(*CreateUserRequest)(nil),        // 2: kvmiddleware.v1.CreateUserRequest
+	(*CreateUserResponse)(nil),       // 3: kvmiddleware.v1.CreateUserResponse
+	(*GetUserDetailsRequest)(nil),    // 4: kvmiddleware.v1.GetUserDetailsRequest
+	(*GetUserDetailsResponse)(nil),   // 5: kvmiddleware.v1.GetUserDetailsResponse
+	(*CreateRoleRequest)(nil),        // 6: kvmiddleware.v1.CreateRoleRequest
+	(*CreateRoleResponse)(nil),       // 7: kvmiddleware.v1.CreateRoleResponse
+	(*MapUserAccessRequest)(nil),     // 8: kvmiddleware.v1.MapUserAccessRequest
+	(*MapUserAccessResponse)(nil),    // 9: kvmiddleware.v1.MapUserAccessResponse
+	(*GetAllRolesRequest)(nil),       // 10: kvmiddleware.v1.GetAllRolesRequest
+	(*GetAllRolesResponse)(nil),      // 11: kvmiddleware.v1.GetAllRolesResponse
+	(*GetRoleRequest)(nil),           // 12: kvmiddleware.v1.GetRoleRequest
+	(*GetRoleResponse)(nil),          // 13: kvmiddleware.v1.GetRoleResponse
+	(*RevokeUserAccessRequest)(nil),  // 14: kvmiddleware.v1.RevokeUserAccessRequest
+	(*RevokeUserAccessResponse)(nil), // 15: kvmiddleware.v1.RevokeUserAccessResponse
+	(*SearchRoleRequest)(nil),        // 16: kvmiddleware.v1.SearchRoleRequest
+	(*SearchRoleResponse)(nil),       // 17: kvmiddleware.v1.SearchRoleResponse
+	(*Namespace)(nil),                // 18: kvmiddleware.v1.Namespace
+	(*CreateNamespaceRequest)(nil),   // 19: kvmiddleware.v1.CreateNamespaceRequest
+	(*CreateNamespaceResponse)(nil),  // 20: kvmiddleware.v1.CreateNamespaceResponse
+	(*GetNamespaceRequest)(nil),      // 21: kvmiddleware.v1.GetNamespaceRequest
+	(*GetNamespaceResponse)(nil),     // 22: kvmiddleware.v1.GetNamespaceResponse
+}
+var file_kvmiddleware_v1_user_proto_depIdxs = []int32{
+	0,  // 0: kvmiddleware.v1.GetUserDetailsResponse.user:type_name -> kvmiddleware.v1.User
+	1,  // 1: kvmiddleware.v1.GetUserDetailsResponse.roles:type_name -> kvmiddleware.v1.Role
+	1,  // 2: kvmiddleware.v1.CreateRoleRequest.roles:type_name -> kvmiddleware.v1.Role
+	1,  // 3: kvmiddleware.v1.MapUserAccessRequest.roles:type_name -> kvmiddleware.v1.Role
+	1,  // 4: kvmiddleware.v1.GetAllRolesResponse.roles:type_name -> kvmiddleware.v1.Role
+	1,  // 5: kvmiddleware.v1.GetRoleResponse.role:type_name -> kvmiddleware.v1.Role
+	1,  // 6: kvmiddleware.v1.RevokeUserAccessRequest.roles:type_name -> kvmiddleware.v1.Role
+	1,  // 7: kvmiddleware.v1.SearchRoleResponse.roles:type_name -> kvmiddleware.v1.Role
+	18, // 8: kvmiddleware.v1.CreateNamespaceRequest.namespace:type_name -> kvmiddleware.v1.Namespace
+	18, // 9: kvmiddleware.v1.GetNamespaceResponse.namespace:type_name -> kvmiddleware.v1.Namespace
+	2,  // 10: kvmiddleware.v1.UserService.CreateUser:input_type -> kvmiddleware.v1.CreateUserRequest
+	4,  // 11: kvmiddleware.v1.UserService.GetUserDetails:input_type -> kvmiddleware.v1.GetUserDetailsRequest
+	6,  // 12: kvmiddleware.v1.UserService.CreateRole:input_type -> kvmiddleware.v1.CreateRoleRequest
+	8,  // 13: kvmiddleware.v1.UserService.MapUserAccess:input_type -> kvmiddleware.v1.MapUserAccessRequest
+	10, // 14: kvmiddleware.v1.UserService.GetAllRoles:input_type -> kvmiddleware.v1.GetAllRolesRequest
+	12, // 15: kvmiddleware.v1.UserService.GetRole:input_type -> kvmiddleware.v1.GetRoleRequest
+	14, // 16: kvmiddleware.v1.UserService.RevokeUserAccess:input_type -> kvmiddleware.v1.RevokeUserAccessRequest
+	16, // 17: kvmiddleware.v1.UserService.SearchRole:input_type -> kvmiddleware.v1.SearchRoleRequest
+	19, // 18: kvmiddleware.v1.UserService.CreateNamespace:input_type -> kvmiddleware.v1.CreateNamespaceRequest
+	21, // 19: kvmiddleware.v1.UserService.GetNamespace:input_type -> kvmiddleware.v1.GetNamespaceRequest
+	3,  // 20: kvmiddleware.v1.UserService.CreateUser:output_type -> kvmiddleware.v1.CreateUserResponse
+	5,  // 21: kvmiddleware.v1.UserService.GetUserDetails:output_type -> kvmiddleware.v1.GetUserDetailsResponse
+	7,  // 22: kvmiddleware.v1.UserService.CreateRole:output_type -> kvmiddleware.v1.CreateRoleResponse
+	9,  // 23: kvmiddleware.v1.UserService.MapUserAccess:output_type -> kvmiddleware.v1.MapUserAccessResponse
+	11, // 24: kvmiddleware.v1.UserService.GetAllRoles:output_type -> kvmiddleware.v1.GetAllRolesResponse
+	13, // 25: kvmiddleware.v1.UserService.GetRole:output_type -> kvmiddleware.v1.GetRoleResponse
+	15, // 26: kvmiddleware.v1.UserService.RevokeUserAccess:output_type -> kvmiddleware.v1.RevokeUserAccessResponse
+	17, // 27: kvmiddleware.v1.UserService.SearchRole:output_type -> kvmiddleware.v1.SearchRoleResponse
+	20, // 28: kvmiddleware.v1.UserService.CreateNamespace:output_type -> kvmiddleware.v1.CreateNamespaceResponse
+	22, // 29: kvmiddleware.v1.UserService.GetNamespace:output_type -> kvmiddleware.v1.GetNamespaceResponse
+	20, // [20:30] is the sub-list for method output_type
+	10, // [10:20] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_kvmiddleware_v1_user_proto_init() }
+func file_kvmiddleware_v1_user_proto_init() {
+	if File_kvmiddleware_v1_user_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kvmiddleware_v1_user_proto_rawDesc), len(file_kvmiddleware_v1_user_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kvmiddleware_v1_user_proto_goTypes,
+		DependencyIndexes: file_kvmiddleware_v1_user_proto_depIdxs,
+		MessageInfos:      file_kvmiddleware_v1_user_proto_msgTypes,
+	}.Build()
+	File_kvmiddleware_v1_user_proto = out.File
+	file_kvmiddleware_v1_user_proto_goTypes = nil
+	file_kvmiddleware_v1_user_proto_depIdxs = nil
+}
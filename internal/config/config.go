@@ -1,18 +1,75 @@
 package config
 
+import "time"
+
 type Config struct {
 	Resources Resources `yaml:"resources"`
+	GRPC      GRPC      `yaml:"grpc"`
+	Telemetry Telemetry `yaml:"telemetry"`
+	Metrics   Metrics   `yaml:"metrics"`
+	Timeout   Timeout   `yaml:"timeout"`
+	Log       Log       `yaml:"log"`
+	Secret    Secret    `yaml:"secret"`
+}
+
+// Secret holds base64 encoded 32 bytes key encryption keys by id, new values are encrypted with CurrentKeyID.
+// EncryptCache keep secret values encrypted in redis and consul, otherwise they are stored decrypted there.
+type Secret struct {
+	CurrentKeyID string            `yaml:"currentKeyID"`
+	Keys         map[string]string `yaml:"keys"`
+	EncryptCache bool              `yaml:"encryptCache"`
+}
+
+// Log configure the logger, Format is json or text
+type Log struct {
+	Level         string   `yaml:"level"`
+	Format        string   `yaml:"format"`
+	SensitiveKeys []string `yaml:"sensitiveKeys"`
+}
+
+// Timeout is budget of one usecase call, zero keeps the default of the usecase
+type Timeout struct {
+	Read       time.Duration `yaml:"read"`
+	Write      time.Duration `yaml:"write"`
+	Background time.Duration `yaml:"background"`
+}
+
+// Metrics configure the /metrics endpoint and how often pending and canary gauges are refreshed
+type Metrics struct {
+	Address         string        `yaml:"address"`
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+}
+
+// Telemetry configure read tracking of keys, only SampleRate of the reads is recorded
+type Telemetry struct {
+	SampleRate    float64       `yaml:"sampleRate"`
+	FlushInterval time.Duration `yaml:"flushInterval"`
+}
+
+type GRPC struct {
+	Address       string        `yaml:"address"`
+	WatchInterval time.Duration `yaml:"watchInterval"`
 }
 
 type Resources struct {
-	Redis  Redis  `yaml:"redis"`
-	Consul Consul `yaml:"consul"`
-	DB     DB     `yaml:"db"`
-	Slack  Slack  `yaml:"slack"`
+	Redis      Redis      `yaml:"redis"`
+	Consul     Consul     `yaml:"consul"`
+	DB         DB         `yaml:"db"`
+	Slack      Slack      `yaml:"slack"`
+	Prometheus Prometheus `yaml:"prometheus"`
+}
+
+type Prometheus struct {
+	Address string        `yaml:"address"`
+	Timeout time.Duration `yaml:"timeout"`
 }
 
+// Slack configure the notifier, UserIDs and TeamIDs map owner names to slack member and user group ids
+// so owners are mentioned, owners without id are listed by name only
 type Slack struct {
-	Webhook string `yaml:"webhook"`
+	Webhook string            `yaml:"webhook"`
+	UserIDs map[string]string `yaml:"userIDs"`
+	TeamIDs map[string]string `yaml:"teamIDs"`
 }
 
 type Redis struct {
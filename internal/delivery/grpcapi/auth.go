@@ -0,0 +1,97 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	// internal dependency
+	kvmiddlewarev1 "github.com/marde12345/key-flag/api/proto/kvmiddleware/v1"
+)
+
+const (
+	usernameMetadata      = "x-username"
+	authorizationMetadata = "authorization"
+	bearerPrefix          = "Bearer "
+)
+
+// anonymousMethods can be called without credentials, flag readers of the services have no user
+// and get secret values masked
+var anonymousMethods = map[string]bool{
+	kvmiddlewarev1.KeyService_GetKey_FullMethodName:      true,
+	kvmiddlewarev1.KeyService_GetKeys_FullMethodName:     true,
+	kvmiddlewarev1.KeyService_WatchKeys_FullMethodName:   true,
+	kvmiddlewarev1.UserService_CreateUser_FullMethodName: true,
+}
+
+type userIDContextKey struct{}
+
+// UnaryAuthInterceptor authenticate the caller of every unary call and put its user id into the context
+func UnaryAuthInterceptor(auth authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, auth, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor authenticate the caller of every stream, e.g. WatchKeys
+func StreamAuthInterceptor(auth authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), auth, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate verify the x-username and authorization metadata,
+// a call without any of them is only allowed to anonymous methods
+func authenticate(ctx context.Context, auth authenticator, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	username := firstMetadata(md, usernameMetadata)
+	token := strings.TrimPrefix(firstMetadata(md, authorizationMetadata), bearerPrefix)
+
+	if username == "" && token == "" && anonymousMethods[fullMethod] {
+		return ctx, nil
+	}
+
+	user, err := auth.Authenticate(ctx, username, token)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return context.WithValue(ctx, userIDContextKey{}, user.ID), nil
+}
+
+// userIDFromContext returns the authenticated caller, zero for anonymous reader
+func userIDFromContext(ctx context.Context) int {
+	userID, _ := ctx.Value(userIDContextKey{}).(int)
+	return userID
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// authenticatedStream carry the context with the caller to the stream handler
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
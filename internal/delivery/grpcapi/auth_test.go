@@ -0,0 +1,225 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	kvmiddlewarev1 "github.com/marde12345/key-flag/api/proto/kvmiddleware/v1"
+	"github.com/marde12345/key-flag/internal/config"
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+const (
+	testUserID = 7
+	testToken  = "secret"
+)
+
+// stubKeyUsecase records the caller of the few methods the tests call, the others panic
+type stubKeyUsecase struct {
+	keyUsecase
+
+	calls  int
+	userID int
+}
+
+func (s *stubKeyUsecase) UpdateKey(ctx context.Context, kv keyentity.KV, baseID int, justification string) error {
+	s.calls++
+	s.userID = kv.CreatedBy
+	return nil
+}
+
+func (s *stubKeyUsecase) GetKey(ctx context.Context, key string, reader keyentity.Reader) (keyentity.KV, error) {
+	s.calls++
+	s.userID = reader.UserID
+	return keyentity.KV{Key: key}, nil
+}
+
+func (s *stubKeyUsecase) GetKeys(ctx context.Context, prefix string, reader keyentity.Reader) ([]keyentity.KV, error) {
+	s.calls++
+	s.userID = reader.UserID
+	return []keyentity.KV{{ID: 1, Key: prefix + "/flag"}}, nil
+}
+
+type stubUserUsecase struct {
+	userUsecase
+
+	calls int
+}
+
+func (s *stubUserUsecase) Authenticate(ctx context.Context, username, token string) (userentity.User, error) {
+	if username != "alice" || token != testToken {
+		return userentity.User{}, userentity.ErrUnauthenticated
+	}
+	return userentity.User{ID: testUserID, Username: username}, nil
+}
+
+func (s *stubUserUsecase) GetAllRoles(ctx context.Context, requestedBy int) ([]userentity.Role, error) {
+	s.calls++
+	return nil, nil
+}
+
+func (s *stubUserUsecase) CreateUser(ctx context.Context, user userentity.User) error {
+	s.calls++
+	return nil
+}
+
+func newTestServer(t *testing.T) (kvmiddlewarev1.KeyServiceClient, kvmiddlewarev1.UserServiceClient, *stubKeyUsecase, *stubUserUsecase) {
+	t.Helper()
+
+	key, user := &stubKeyUsecase{}, &stubUserUsecase{}
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryAuthInterceptor(user)),
+		grpc.StreamInterceptor(StreamAuthInterceptor(user)),
+	)
+	Register(s, config.GRPC{WatchInterval: time.Hour}, key, user)
+
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return kvmiddlewarev1.NewKeyServiceClient(conn), kvmiddlewarev1.NewUserServiceClient(conn), key, user
+}
+
+func withCredentials(username, token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), usernameMetadata, username, authorizationMetadata, bearerPrefix+token)
+}
+
+func TestUpdateKeyAuthentication(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{name: "no credentials", ctx: context.Background(), wantCode: codes.Unauthenticated},
+		{name: "wrong token", ctx: withCredentials("alice", "guess"), wantCode: codes.Unauthenticated},
+		{name: "unknown user", ctx: withCredentials("bob", testToken), wantCode: codes.Unauthenticated},
+		{name: "valid token", ctx: withCredentials("alice", testToken), wantCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyClient, _, key, _ := newTestServer(t)
+
+			_, err := keyClient.UpdateKey(tt.ctx, &kvmiddlewarev1.UpdateKeyRequest{Key: "service/risk/flag", Value: "true"})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("UpdateKey() code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+
+			if tt.wantCode != codes.OK {
+				if key.calls != 0 {
+					t.Fatalf("usecase called %d times, want none", key.calls)
+				}
+				return
+			}
+			if key.userID != testUserID {
+				t.Fatalf("CreatedBy = %d, want the authenticated user %d", key.userID, testUserID)
+			}
+		})
+	}
+}
+
+func TestGetAllRolesUnauthenticated(t *testing.T) {
+	_, userClient, _, user := newTestServer(t)
+
+	_, err := userClient.GetAllRoles(context.Background(), &kvmiddlewarev1.GetAllRolesRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("GetAllRoles() error = %v, want unauthenticated", err)
+	}
+	if user.calls != 0 {
+		t.Fatalf("usecase called %d times, want none", user.calls)
+	}
+}
+
+func TestCreateUserAnonymous(t *testing.T) {
+	_, userClient, _, user := newTestServer(t)
+
+	if _, err := userClient.CreateUser(context.Background(), &kvmiddlewarev1.CreateUserRequest{Username: "bob", Token: "t"}); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	if user.calls != 1 {
+		t.Fatalf("usecase called %d times, want once", user.calls)
+	}
+}
+
+func TestGetKeyAnonymousReader(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantCode   codes.Code
+		wantUserID int
+	}{
+		// anonymous reader gets secret values masked
+		{name: "no credentials", ctx: context.Background(), wantCode: codes.OK},
+		{name: "valid token", ctx: withCredentials("alice", testToken), wantCode: codes.OK, wantUserID: testUserID},
+		// a wrong token is rejected instead of falling back to anonymous
+		{name: "wrong token", ctx: withCredentials("alice", "guess"), wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyClient, _, key, _ := newTestServer(t)
+
+			_, err := keyClient.GetKey(tt.ctx, &kvmiddlewarev1.GetKeyRequest{Key: "service/risk/flag"})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("GetKey() code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if key.userID != tt.wantUserID {
+				t.Fatalf("reader user = %d, want %d", key.userID, tt.wantUserID)
+			}
+		})
+	}
+}
+
+func TestWatchKeysAuthentication(t *testing.T) {
+	t.Run("wrong token", func(t *testing.T) {
+		keyClient, _, key, _ := newTestServer(t)
+
+		stream, err := keyClient.WatchKeys(withCredentials("alice", "guess"), &kvmiddlewarev1.WatchKeysRequest{Prefix: "service/risk"})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("WatchKeys() error = %v, want unauthenticated", err)
+		}
+		if key.calls != 0 {
+			t.Fatalf("usecase called %d times, want none", key.calls)
+		}
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		keyClient, _, key, _ := newTestServer(t)
+
+		ctx, cancel := context.WithCancel(withCredentials("alice", testToken))
+		defer cancel()
+
+		stream, err := keyClient.WatchKeys(ctx, &kvmiddlewarev1.WatchKeysRequest{Prefix: "service/risk"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stream.Recv(); err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		if key.userID != testUserID {
+			t.Fatalf("reader user = %d, want the authenticated user %d", key.userID, testUserID)
+		}
+	})
+}
@@ -0,0 +1,781 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	// internal dependency
+	kvmiddlewarev1 "github.com/marde12345/key-flag/api/proto/kvmiddleware/v1"
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+type KeyServer struct {
+	kvmiddlewarev1.UnimplementedKeyServiceServer
+
+	keyUsecase    keyUsecase
+	watchInterval time.Duration
+}
+
+func (s *KeyServer) GetKey(ctx context.Context, req *kvmiddlewarev1.GetKeyRequest) (*kvmiddlewarev1.GetKeyResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	kv, err := s.keyUsecase.GetKey(ctx, req.GetKey(), keyentity.Reader{
+		Client: req.GetClient(),
+		IP:     req.GetIp(),
+		UserID: userIDFromContext(ctx),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetKeyResponse{Kv: toProtoKV(kv)}, nil
+}
+
+func (s *KeyServer) GetKeys(ctx context.Context, req *kvmiddlewarev1.GetKeysRequest) (*kvmiddlewarev1.GetKeysResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	getKeys := s.keyUsecase.GetKeys
+	if req.GetEvaluatePrerequisites() {
+		getKeys = s.keyUsecase.GetEffectiveKeys
+	}
+
+	kvs, err := getKeys(ctx, req.GetPrefix(), keyentity.Reader{
+		Client: req.GetClient(),
+		IP:     req.GetIp(),
+		Group:  req.GetGroup(),
+		UserID: userIDFromContext(ctx),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetKeysResponse{Kvs: toProtoKVs(kvs)}, nil
+}
+
+func (s *KeyServer) BrowseKeys(ctx context.Context, req *kvmiddlewarev1.BrowseKeysRequest) (*kvmiddlewarev1.BrowseKeysResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	page, err := s.keyUsecase.BrowseKeys(ctx, req.GetPrefix(), keyentity.BrowseOptions{
+		Separator: req.GetSeparator(),
+		Cursor:    req.GetCursor(),
+		Limit:     int(req.GetLimit()),
+	}, userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	nodes := make([]*kvmiddlewarev1.BrowseNode, 0, len(page.Nodes))
+	for _, node := range page.Nodes {
+		protoNode := &kvmiddlewarev1.BrowseNode{
+			Name:    node.Name,
+			IsDir:   node.IsDir,
+			Count:   int32(node.Count),
+			Expired: node.Expired,
+		}
+		if node.Metadata != nil {
+			protoNode.Metadata = toProtoKeyMetadata(*node.Metadata)
+		}
+		nodes = append(nodes, protoNode)
+	}
+
+	return &kvmiddlewarev1.BrowseKeysResponse{
+		Nodes:      nodes,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+func (s *KeyServer) GetHistoryKey(ctx context.Context, req *kvmiddlewarev1.GetHistoryKeyRequest) (*kvmiddlewarev1.GetHistoryKeyResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	filter := keyentity.HistoryFilter{
+		CreatedBy:  int(req.GetCreatedBy()),
+		ApprovedBy: int(req.GetApprovedBy()),
+		Cursor:     req.GetCursor(),
+		Limit:      int(req.GetLimit()),
+	}
+	for _, status := range req.GetStatuses() {
+		filter.Statuses = append(filter.Statuses, int(status))
+	}
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+
+	page, err := s.keyUsecase.GetHistoryKey(ctx, req.GetKey(), req.GetIsPrefix(), filter, userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetHistoryKeyResponse{
+		Kvs:        toProtoKVs(page.Items),
+		NextCursor: page.NextCursor,
+		Comments:   toProtoComments(page.Comments),
+	}, nil
+}
+
+func (s *KeyServer) PendingApprovalKey(ctx context.Context, req *kvmiddlewarev1.PendingApprovalKeyRequest) (*kvmiddlewarev1.PendingApprovalKeyResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	page, err := s.keyUsecase.PendingApprovalKey(ctx, req.GetPrefix(), keyentity.PendingFilter{
+		Kinds:    req.GetKinds(),
+		Author:   int(req.GetAuthor()),
+		Approver: req.GetApprover(),
+		MinAge:   time.Duration(req.GetMinAgeSeconds()) * time.Second,
+		MaxAge:   time.Duration(req.GetMaxAgeSeconds()) * time.Second,
+		Sort:     req.GetSort(),
+		Limit:    int(req.GetLimit()),
+		Offset:   int(req.GetOffset()),
+	}, userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	items := make([]*kvmiddlewarev1.PendingKV, 0, len(page.Items))
+	for _, pendingKey := range page.Items {
+		items = append(items, &kvmiddlewarev1.PendingKV{
+			Kind:      pendingKey.Kind,
+			Kv:        toProtoKV(pendingKey.KV),
+			Active:    toProtoKV(pendingKey.Active),
+			Diff:      toProtoDiff(pendingKey.Diff),
+			Comments:  toProtoComments(pendingKey.Comments),
+			Ownership: toProtoOwnership(pendingKey.Ownership),
+		})
+	}
+
+	return &kvmiddlewarev1.PendingApprovalKeyResponse{
+		Items: items,
+		Total: int32(page.Total),
+	}, nil
+}
+
+func (s *KeyServer) DiffHistoryKey(ctx context.Context, req *kvmiddlewarev1.DiffHistoryKeyRequest) (*kvmiddlewarev1.DiffHistoryKeyResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	diff, err := s.keyUsecase.DiffHistoryKey(ctx, int(req.GetFromId()), int(req.GetToId()), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.DiffHistoryKeyResponse{Diff: toProtoDiff(diff)}, nil
+}
+
+func (s *KeyServer) SearchKeys(ctx context.Context, req *kvmiddlewarev1.SearchKeysRequest) (*kvmiddlewarev1.SearchKeysResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	kvs, err := s.keyUsecase.SearchKeys(ctx, userIDFromContext(ctx), keyentity.SearchQuery{
+		Prefix:     req.GetPrefix(),
+		Text:       req.GetText(),
+		Mode:       req.GetMode(),
+		InKey:      req.GetInKey(),
+		InValue:    req.GetInValue(),
+		InMetadata: req.GetInMetadata(),
+		Tags:       req.GetTags(),
+		Expired:    req.GetExpired(),
+		Limit:      int(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.SearchKeysResponse{Kvs: toProtoKVs(kvs)}, nil
+}
+
+func (s *KeyServer) ExportPrefix(ctx context.Context, req *kvmiddlewarev1.ExportPrefixRequest) (*kvmiddlewarev1.ExportPrefixResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	data, err := s.keyUsecase.ExportPrefix(ctx, req.GetPrefix(), req.GetFormat(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.ExportPrefixResponse{Data: data}, nil
+}
+
+func (s *KeyServer) ImportPrefix(ctx context.Context, req *kvmiddlewarev1.ImportPrefixRequest) (*kvmiddlewarev1.ImportPrefixResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	result, err := s.keyUsecase.ImportPrefix(ctx, req.GetPrefix(), req.GetFormat(), req.GetData(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.ImportPrefixResponse{
+		ChangeSetId: int64(result.ChangeSetID),
+		Changed:     result.Changed,
+		Unchanged:   result.Unchanged,
+	}, nil
+}
+
+func (s *KeyServer) PromoteKeys(ctx context.Context, req *kvmiddlewarev1.PromoteKeysRequest) (*kvmiddlewarev1.PromoteKeysResponse, error) {
+	result, err := s.keyUsecase.PromoteKeys(ctx, keyentity.PromotionRequest{
+		SourceEnvironment: req.GetSourceEnvironment(),
+		TargetEnvironment: req.GetTargetEnvironment(),
+		Prefix:            req.GetPrefix(),
+		Keys:              req.GetKeys(),
+		DryRun:            req.GetDryRun(),
+	}, userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	items := make([]*kvmiddlewarev1.PromotionItem, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, &kvmiddlewarev1.PromotionItem{
+			Key:    item.Key,
+			Source: toProtoKV(item.Source),
+			Target: toProtoKV(item.Target),
+			Diff:   toProtoDiff(item.Diff),
+		})
+	}
+
+	return &kvmiddlewarev1.PromoteKeysResponse{
+		ChangeSetId: int64(result.ChangeSetID),
+		Items:       items,
+		Unchanged:   result.Unchanged,
+	}, nil
+}
+
+func (s *KeyServer) UpdateKey(ctx context.Context, req *kvmiddlewarev1.UpdateKeyRequest) (*kvmiddlewarev1.UpdateKeyResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	err := s.keyUsecase.UpdateKey(ctx, keyentity.KV{
+		Key:       req.GetKey(),
+		Value:     req.GetValue(),
+		Type:      req.GetType(),
+		CreatedBy: userIDFromContext(ctx),
+	}, int(req.GetBaseId()), req.GetJustification())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.UpdateKeyResponse{}, nil
+}
+
+func (s *KeyServer) CreateDeleteKey(ctx context.Context, req *kvmiddlewarev1.CreateDeleteKeyRequest) (*kvmiddlewarev1.CreateDeleteKeyResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	err := s.keyUsecase.CreateDeleteKey(ctx, keyentity.KV{
+		Key:       req.GetKey(),
+		CreatedBy: userIDFromContext(ctx),
+	}, req.GetJustification())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.CreateDeleteKeyResponse{}, nil
+}
+
+func (s *KeyServer) AmendPlacedKey(ctx context.Context, req *kvmiddlewarev1.AmendPlacedKeyRequest) (*kvmiddlewarev1.AmendPlacedKeyResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	err := s.keyUsecase.AmendPlacedKey(ctx, keyentity.KV{
+		Key:   req.GetKey(),
+		Value: req.GetValue(),
+		Type:  req.GetType(),
+	}, userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.AmendPlacedKeyResponse{}, nil
+}
+
+func (s *KeyServer) WithdrawPlacedKey(ctx context.Context, req *kvmiddlewarev1.WithdrawPlacedKeyRequest) (*kvmiddlewarev1.WithdrawPlacedKeyResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	if err := s.keyUsecase.WithdrawPlacedKey(ctx, req.GetKey(), userIDFromContext(ctx)); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.WithdrawPlacedKeyResponse{}, nil
+}
+
+func (s *KeyServer) AddComment(ctx context.Context, req *kvmiddlewarev1.AddCommentRequest) (*kvmiddlewarev1.AddCommentResponse, error) {
+	id, err := s.keyUsecase.AddComment(ctx, keyentity.Comment{
+		KeyID:    int(req.GetKeyId()),
+		ParentID: int(req.GetParentId()),
+		Body:     req.GetBody(),
+	}, userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.AddCommentResponse{Id: int64(id)}, nil
+}
+
+func (s *KeyServer) GetComments(ctx context.Context, req *kvmiddlewarev1.GetCommentsRequest) (*kvmiddlewarev1.GetCommentsResponse, error) {
+	comments, err := s.keyUsecase.GetComments(ctx, int(req.GetKeyId()), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetCommentsResponse{Comments: toProtoComments(comments)}, nil
+}
+
+func (s *KeyServer) SetOwnership(ctx context.Context, req *kvmiddlewarev1.SetOwnershipRequest) (*kvmiddlewarev1.SetOwnershipResponse, error) {
+	if err := s.keyUsecase.SetOwnership(ctx, fromProtoOwnership(req.GetOwnership()), userIDFromContext(ctx)); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.SetOwnershipResponse{}, nil
+}
+
+func (s *KeyServer) GetOwnership(ctx context.Context, req *kvmiddlewarev1.GetOwnershipRequest) (*kvmiddlewarev1.GetOwnershipResponse, error) {
+	ownership, err := s.keyUsecase.GetOwnership(ctx, req.GetKey(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetOwnershipResponse{Ownership: toProtoOwnership(ownership)}, nil
+}
+
+func (s *KeyServer) UpdateKeyMetadata(ctx context.Context, req *kvmiddlewarev1.UpdateKeyMetadataRequest) (*kvmiddlewarev1.UpdateKeyMetadataResponse, error) {
+	if err := s.keyUsecase.UpdateKeyMetadata(ctx, fromProtoKeyMetadata(req.GetMetadata()), userIDFromContext(ctx)); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.UpdateKeyMetadataResponse{}, nil
+}
+
+func (s *KeyServer) GetKeyMetadata(ctx context.Context, req *kvmiddlewarev1.GetKeyMetadataRequest) (*kvmiddlewarev1.GetKeyMetadataResponse, error) {
+	metadata, err := s.keyUsecase.GetKeyMetadata(ctx, req.GetKey(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetKeyMetadataResponse{Metadata: toProtoKeyMetadata(metadata)}, nil
+}
+
+func (s *KeyServer) ApproveKey(ctx context.Context, req *kvmiddlewarev1.ApproveKeyRequest) (*kvmiddlewarev1.ApproveKeyResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	err := s.keyUsecase.ApproveKey(ctx, req.GetKey(), userIDFromContext(ctx), int(req.GetStatus()), req.GetReason())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.ApproveKeyResponse{}, nil
+}
+
+func (s *KeyServer) ApproveDeleteKey(ctx context.Context, req *kvmiddlewarev1.ApproveDeleteKeyRequest) (*kvmiddlewarev1.ApproveDeleteKeyResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	err := s.keyUsecase.ApproveDeleteKey(ctx, req.GetKey(), userIDFromContext(ctx), int(req.GetStatus()), req.GetReason())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.ApproveDeleteKeyResponse{}, nil
+}
+
+func (s *KeyServer) ApproveKeyCanary(ctx context.Context, req *kvmiddlewarev1.ApproveKeyCanaryRequest) (*kvmiddlewarev1.ApproveKeyCanaryResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	err := s.keyUsecase.ApproveKeyCanary(ctx, req.GetKey(), userIDFromContext(ctx), int(req.GetStatus()), req.GetNodesIp())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.ApproveKeyCanaryResponse{}, nil
+}
+
+func (s *KeyServer) DeleteKey(ctx context.Context, req *kvmiddlewarev1.DeleteKeyRequest) (*kvmiddlewarev1.DeleteKeyResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	err := s.keyUsecase.DeleteKey(ctx, int(req.GetKeyId()), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.DeleteKeyResponse{}, nil
+}
+
+func (s *KeyServer) CreateService(ctx context.Context, req *kvmiddlewarev1.CreateServiceRequest) (*kvmiddlewarev1.CreateServiceResponse, error) {
+	err := s.keyUsecase.CreateService(ctx, req.GetUsername(), req.GetNamespace(), req.GetTribe(), req.GetService(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.CreateServiceResponse{}, nil
+}
+
+func (s *KeyServer) ApproveKeyCanaryGroup(ctx context.Context, req *kvmiddlewarev1.ApproveKeyCanaryGroupRequest) (*kvmiddlewarev1.ApproveKeyCanaryGroupResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	err := s.keyUsecase.ApproveKeyCanaryGroup(ctx, req.GetKey(), userIDFromContext(ctx), int(req.GetStatus()), req.GetGroup())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.ApproveKeyCanaryGroupResponse{}, nil
+}
+
+func (s *KeyServer) RegisterCanaryGroup(ctx context.Context, req *kvmiddlewarev1.RegisterCanaryGroupRequest) (*kvmiddlewarev1.RegisterCanaryGroupResponse, error) {
+	err := s.keyUsecase.RegisterCanaryGroup(ctx, req.GetServicePrefix(), req.GetGroup(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.RegisterCanaryGroupResponse{}, nil
+}
+
+func (s *KeyServer) HeartbeatCanaryTarget(ctx context.Context, req *kvmiddlewarev1.HeartbeatCanaryTargetRequest) (*kvmiddlewarev1.HeartbeatCanaryTargetResponse, error) {
+	ttl := time.Duration(req.GetTtlSeconds()) * time.Second
+
+	err := s.keyUsecase.HeartbeatCanaryTarget(ctx, req.GetServicePrefix(), fromProtoCanaryTarget(req.GetTarget()), ttl, userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.HeartbeatCanaryTargetResponse{}, nil
+}
+
+func (s *KeyServer) DeregisterCanaryTarget(ctx context.Context, req *kvmiddlewarev1.DeregisterCanaryTargetRequest) (*kvmiddlewarev1.DeregisterCanaryTargetResponse, error) {
+	err := s.keyUsecase.DeregisterCanaryTarget(ctx, req.GetServicePrefix(), fromProtoCanaryTarget(req.GetTarget()), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.DeregisterCanaryTargetResponse{}, nil
+}
+
+func (s *KeyServer) GetKeyCanaryIP(ctx context.Context, req *kvmiddlewarev1.GetKeyCanaryIPRequest) (*kvmiddlewarev1.GetKeyCanaryIPResponse, error) {
+	canaryIPs, recommendedTargets, err := s.keyUsecase.GetKeyCanaryIP(ctx, int(req.GetKeyId()), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	targets := make([]*kvmiddlewarev1.CanaryTarget, 0, len(recommendedTargets))
+	for _, target := range recommendedTargets {
+		targets = append(targets, &kvmiddlewarev1.CanaryTarget{
+			Group:     target.Group,
+			NodeId:    target.NodeID,
+			Ip:        target.IP,
+			ExpiresAt: timestamppb.New(target.ExpiresAt),
+		})
+	}
+
+	return &kvmiddlewarev1.GetKeyCanaryIPResponse{
+		CanaryIps:          canaryIPs,
+		RecommendedTargets: targets,
+	}, nil
+}
+
+func (s *KeyServer) SetCanaryGate(ctx context.Context, req *kvmiddlewarev1.SetCanaryGateRequest) (*kvmiddlewarev1.SetCanaryGateResponse, error) {
+	err := s.keyUsecase.SetCanaryGate(ctx, req.GetKey(), keyentity.CanaryGate{
+		MinBakeSeconds:   int(req.GetMinBakeSeconds()),
+		Checker:          req.GetChecker(),
+		Target:           req.GetTarget(),
+		Threshold:        req.GetThreshold(),
+		FailureThreshold: int(req.GetFailureThreshold()),
+	}, userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.SetCanaryGateResponse{}, nil
+}
+
+func (s *KeyServer) GetCanaryDecisions(ctx context.Context, req *kvmiddlewarev1.GetCanaryDecisionsRequest) (*kvmiddlewarev1.GetCanaryDecisionsResponse, error) {
+	decisions, err := s.keyUsecase.GetCanaryDecisions(ctx, int(req.GetKeyId()), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result := make([]*kvmiddlewarev1.CanaryDecision, 0, len(decisions))
+	for _, decision := range decisions {
+		result = append(result, &kvmiddlewarev1.CanaryDecision{
+			Id:         int64(decision.ID),
+			KeyId:      int64(decision.KeyID),
+			Decision:   decision.Decision,
+			Reason:     decision.Reason,
+			CreateTime: timestamppb.New(decision.CreateTime),
+		})
+	}
+
+	return &kvmiddlewarev1.GetCanaryDecisionsResponse{Decisions: result}, nil
+}
+
+func (s *KeyServer) AddPrerequisite(ctx context.Context, req *kvmiddlewarev1.AddPrerequisiteRequest) (*kvmiddlewarev1.AddPrerequisiteResponse, error) {
+	err := s.keyUsecase.AddPrerequisite(ctx, keyentity.Prerequisite{
+		Key:           req.GetPrerequisite().GetKey(),
+		RequiredKey:   req.GetPrerequisite().GetRequiredKey(),
+		RequiredValue: req.GetPrerequisite().GetRequiredValue(),
+		FallbackValue: req.GetPrerequisite().GetFallbackValue(),
+	}, userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.AddPrerequisiteResponse{}, nil
+}
+
+func (s *KeyServer) RemovePrerequisite(ctx context.Context, req *kvmiddlewarev1.RemovePrerequisiteRequest) (*kvmiddlewarev1.RemovePrerequisiteResponse, error) {
+	if err := s.keyUsecase.RemovePrerequisite(ctx, int(req.GetId()), userIDFromContext(ctx)); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.RemovePrerequisiteResponse{}, nil
+}
+
+func (s *KeyServer) GetPrerequisites(ctx context.Context, req *kvmiddlewarev1.GetPrerequisitesRequest) (*kvmiddlewarev1.GetPrerequisitesResponse, error) {
+	prerequisites, err := s.keyUsecase.GetPrerequisites(ctx, req.GetKey())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result := make([]*kvmiddlewarev1.Prerequisite, 0, len(prerequisites))
+	for _, p := range prerequisites {
+		result = append(result, &kvmiddlewarev1.Prerequisite{
+			Id:            int64(p.ID),
+			Key:           p.Key,
+			RequiredKey:   p.RequiredKey,
+			RequiredValue: p.RequiredValue,
+			FallbackValue: p.FallbackValue,
+			CreatedBy:     int64(p.CreatedBy),
+		})
+	}
+
+	return &kvmiddlewarev1.GetPrerequisitesResponse{Prerequisites: result}, nil
+}
+
+func (s *KeyServer) GetStaleKeys(ctx context.Context, req *kvmiddlewarev1.GetStaleKeysRequest) (*kvmiddlewarev1.GetStaleKeysResponse, error) {
+	staleKeys, err := s.keyUsecase.GetStaleKeys(ctx, req.GetPrefix(), time.Duration(req.GetMinAgeSeconds())*time.Second, userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result := make([]*kvmiddlewarev1.StaleKey, 0, len(staleKeys))
+	for _, staleKey := range staleKeys {
+		item := &kvmiddlewarev1.StaleKey{
+			Kv:         toProtoKV(staleKey.KV),
+			AgeSeconds: int64(staleKey.Age.Seconds()),
+			Changes:    int32(staleKey.Changes),
+			Expired:    staleKey.Expired,
+		}
+		// key never read has no last read
+		if staleKey.LastRead.Key != "" {
+			item.LastRead = toProtoKeyRead(staleKey.LastRead)
+		}
+
+		result = append(result, item)
+	}
+
+	return &kvmiddlewarev1.GetStaleKeysResponse{Keys: result}, nil
+}
+
+func (s *KeyServer) CreateStaleDeleteRequests(ctx context.Context, req *kvmiddlewarev1.CreateStaleDeleteRequestsRequest) (*kvmiddlewarev1.CreateStaleDeleteRequestsResponse, error) {
+	result, err := s.keyUsecase.CreateStaleDeleteRequests(ctx, req.GetPrefix(), time.Duration(req.GetMinAgeSeconds())*time.Second, req.GetKeys(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.CreateStaleDeleteRequestsResponse{
+		ChangeSetId: int64(result.ChangeSetID),
+		Keys:        result.Keys,
+	}, nil
+}
+
+func (s *KeyServer) GetKeyReads(ctx context.Context, req *kvmiddlewarev1.GetKeyReadsRequest) (*kvmiddlewarev1.GetKeyReadsResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	reads, err := s.keyUsecase.GetKeyReads(ctx, req.GetKey(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetKeyReadsResponse{Reads: toProtoKeyReads(reads)}, nil
+}
+
+func (s *KeyServer) GetKeyDetail(ctx context.Context, req *kvmiddlewarev1.GetKeyDetailRequest) (*kvmiddlewarev1.GetKeyDetailResponse, error) {
+	ctx = keyentity.WithEnvironment(ctx, req.GetEnvironment())
+
+	detail, err := s.keyUsecase.GetKeyDetail(ctx, req.GetKey(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetKeyDetailResponse{
+		Kv:       toProtoKV(detail.KV),
+		Reads:    toProtoKeyReads(detail.Reads),
+		Metadata: toProtoKeyMetadata(detail.Metadata),
+	}, nil
+}
+
+func (s *KeyServer) RotateSecrets(ctx context.Context, req *kvmiddlewarev1.RotateSecretsRequest) (*kvmiddlewarev1.RotateSecretsResponse, error) {
+	rotated, err := s.keyUsecase.RotateSecrets(ctx, req.GetPrefix(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.RotateSecretsResponse{Rotated: int64(rotated)}, nil
+}
+
+func toProtoKeyRead(read keyentity.KeyRead) *kvmiddlewarev1.KeyRead {
+	return &kvmiddlewarev1.KeyRead{
+		Key:          read.Key,
+		Client:       read.Client,
+		Ip:           read.IP,
+		Count:        read.Count,
+		LastReadTime: timestamppb.New(read.LastReadTime),
+		Environment:  read.Environment,
+	}
+}
+
+func toProtoKeyMetadata(metadata keyentity.KeyMetadata) *kvmiddlewarev1.KeyMetadata {
+	result := &kvmiddlewarev1.KeyMetadata{
+		Key:         metadata.Key,
+		Description: metadata.Description,
+		Tags:        metadata.Tags,
+		Owner:       metadata.Owner,
+		Ticket:      metadata.Ticket,
+		Lifetime:    metadata.Lifetime,
+		UpdateTime:  timestamppb.New(metadata.UpdateTime),
+		UpdatedBy:   int64(metadata.UpdatedBy),
+	}
+	if !metadata.ExpiryDate.IsZero() {
+		result.ExpiryDate = timestamppb.New(metadata.ExpiryDate)
+	}
+
+	return result
+}
+
+func fromProtoKeyMetadata(metadata *kvmiddlewarev1.KeyMetadata) keyentity.KeyMetadata {
+	result := keyentity.KeyMetadata{
+		Key:         metadata.GetKey(),
+		Description: metadata.GetDescription(),
+		Tags:        metadata.GetTags(),
+		Owner:       metadata.GetOwner(),
+		Ticket:      metadata.GetTicket(),
+		Lifetime:    metadata.GetLifetime(),
+	}
+	if metadata.GetExpiryDate() != nil {
+		result.ExpiryDate = metadata.GetExpiryDate().AsTime()
+	}
+
+	return result
+}
+
+func toProtoOwnership(ownership keyentity.Ownership) *kvmiddlewarev1.Ownership {
+	owners := make([]*kvmiddlewarev1.Owner, 0, len(ownership.Owners))
+	for _, owner := range ownership.Owners {
+		owners = append(owners, &kvmiddlewarev1.Owner{Kind: owner.Kind, Name: owner.Name})
+	}
+
+	return &kvmiddlewarev1.Ownership{
+		Id:          int64(ownership.ID),
+		Prefix:      ownership.Prefix,
+		Owners:      owners,
+		Description: ownership.Description,
+		OnCall:      ownership.OnCall,
+		Tags:        ownership.Tags,
+		UpdateTime:  timestamppb.New(ownership.UpdateTime),
+		UpdatedBy:   int64(ownership.UpdatedBy),
+	}
+}
+
+func fromProtoOwnership(ownership *kvmiddlewarev1.Ownership) keyentity.Ownership {
+	owners := make([]keyentity.Owner, 0, len(ownership.GetOwners()))
+	for _, owner := range ownership.GetOwners() {
+		owners = append(owners, keyentity.Owner{Kind: owner.GetKind(), Name: owner.GetName()})
+	}
+
+	return keyentity.Ownership{
+		Prefix:      ownership.GetPrefix(),
+		Owners:      owners,
+		Description: ownership.GetDescription(),
+		OnCall:      ownership.GetOnCall(),
+		Tags:        ownership.GetTags(),
+	}
+}
+
+func toProtoComments(comments []keyentity.Comment) []*kvmiddlewarev1.Comment {
+	result := make([]*kvmiddlewarev1.Comment, 0, len(comments))
+	for _, comment := range comments {
+		result = append(result, &kvmiddlewarev1.Comment{
+			Id:         int64(comment.ID),
+			KeyId:      int64(comment.KeyID),
+			ParentId:   int64(comment.ParentID),
+			Kind:       comment.Kind,
+			Body:       comment.Body,
+			CreatedBy:  int64(comment.CreatedBy),
+			CreateTime: timestamppb.New(comment.CreateTime),
+		})
+	}
+
+	return result
+}
+
+func toProtoKeyReads(reads []keyentity.KeyRead) []*kvmiddlewarev1.KeyRead {
+	result := make([]*kvmiddlewarev1.KeyRead, 0, len(reads))
+	for _, read := range reads {
+		result = append(result, toProtoKeyRead(read))
+	}
+
+	return result
+}
+
+func fromProtoCanaryTarget(target *kvmiddlewarev1.CanaryTarget) keyentity.CanaryTarget {
+	return keyentity.CanaryTarget{
+		Group:  target.GetGroup(),
+		NodeID: target.GetNodeId(),
+		IP:     target.GetIp(),
+	}
+}
+
+func toProtoKV(kv keyentity.KV) *kvmiddlewarev1.KV {
+	return &kvmiddlewarev1.KV{
+		Id:           int64(kv.ID),
+		Key:          kv.Key,
+		Value:        kv.Value,
+		Type:         kv.Type,
+		CreateTime:   timestamppb.New(kv.CreateTime),
+		UpdateTime:   timestamppb.New(kv.UpdateTime),
+		CreatedBy:    int64(kv.CreatedBy),
+		ApprovedBy:   int64(kv.ApprovedBy),
+		Status:       int32(kv.Status),
+		StatusString: kv.StatusString(),
+		CreatedByStr: kv.CreateByStr,
+		ChangeSetId:  int64(kv.ChangeSetID),
+		Environment:  kv.Environment,
+	}
+}
+
+func toProtoDiff(diff keyentity.Diff) *kvmiddlewarev1.Diff {
+	result := &kvmiddlewarev1.Diff{
+		Kind: diff.Kind,
+		Old:  diff.Old,
+		New:  diff.New,
+	}
+
+	for _, line := range diff.Lines {
+		result.Lines = append(result.Lines, &kvmiddlewarev1.DiffLine{
+			Op:   line.Op,
+			Text: line.Text,
+		})
+	}
+
+	for _, change := range diff.Changes {
+		result.Changes = append(result.Changes, &kvmiddlewarev1.DiffChange{
+			Path: change.Path,
+			Op:   change.Op,
+			Old:  change.Old,
+			New:  change.New,
+		})
+	}
+
+	return result
+}
+
+func toProtoKVs(kvs []keyentity.KV) []*kvmiddlewarev1.KV {
+	result := make([]*kvmiddlewarev1.KV, 0, len(kvs))
+	for _, kv := range kvs {
+		result = append(result, toProtoKV(kv))
+	}
+
+	return result
+}
@@ -0,0 +1,105 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	kvmiddlewarev1 "github.com/marde12345/key-flag/api/proto/kvmiddleware/v1"
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+// environmentKeyUsecase records the environment of the context each call runs in
+type environmentKeyUsecase struct {
+	keyUsecase
+
+	environment string
+}
+
+func (s *environmentKeyUsecase) record(ctx context.Context) {
+	s.environment = keyentity.EnvironmentFromContext(ctx)
+}
+
+func (s *environmentKeyUsecase) BrowseKeys(ctx context.Context, prefix string, opts keyentity.BrowseOptions, userID int) (keyentity.BrowsePage, error) {
+	s.record(ctx)
+	return keyentity.BrowsePage{}, nil
+}
+
+func (s *environmentKeyUsecase) GetHistoryKey(ctx context.Context, key string, isPrefix bool, filter keyentity.HistoryFilter, userID int) (keyentity.HistoryPage, error) {
+	s.record(ctx)
+	return keyentity.HistoryPage{}, nil
+}
+
+func (s *environmentKeyUsecase) SearchKeys(ctx context.Context, userID int, query keyentity.SearchQuery) ([]keyentity.KV, error) {
+	s.record(ctx)
+	return nil, nil
+}
+
+func (s *environmentKeyUsecase) ExportPrefix(ctx context.Context, prefix, format string, userID int) ([]byte, error) {
+	s.record(ctx)
+	return nil, nil
+}
+
+func (s *environmentKeyUsecase) ImportPrefix(ctx context.Context, prefix, format string, data []byte, userID int) (keyentity.ImportResult, error) {
+	s.record(ctx)
+	return keyentity.ImportResult{}, nil
+}
+
+func (s *environmentKeyUsecase) DiffHistoryKey(ctx context.Context, fromID, toID, userID int) (keyentity.Diff, error) {
+	s.record(ctx)
+	return keyentity.Diff{}, nil
+}
+
+func (s *environmentKeyUsecase) DeleteKey(ctx context.Context, keyID, userID int) error {
+	s.record(ctx)
+	return nil
+}
+
+func TestKeyServerEnvironment(t *testing.T) {
+	const staging = "staging"
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context, s *KeyServer) error
+	}{
+		{name: "BrowseKeys", call: func(ctx context.Context, s *KeyServer) error {
+			_, err := s.BrowseKeys(ctx, &kvmiddlewarev1.BrowseKeysRequest{Prefix: "service", Environment: staging})
+			return err
+		}},
+		{name: "GetHistoryKey", call: func(ctx context.Context, s *KeyServer) error {
+			_, err := s.GetHistoryKey(ctx, &kvmiddlewarev1.GetHistoryKeyRequest{Key: "service/flag", Environment: staging})
+			return err
+		}},
+		{name: "SearchKeys", call: func(ctx context.Context, s *KeyServer) error {
+			_, err := s.SearchKeys(ctx, &kvmiddlewarev1.SearchKeysRequest{Text: "flag", Environment: staging})
+			return err
+		}},
+		{name: "ExportPrefix", call: func(ctx context.Context, s *KeyServer) error {
+			_, err := s.ExportPrefix(ctx, &kvmiddlewarev1.ExportPrefixRequest{Prefix: "service", Environment: staging})
+			return err
+		}},
+		{name: "ImportPrefix", call: func(ctx context.Context, s *KeyServer) error {
+			_, err := s.ImportPrefix(ctx, &kvmiddlewarev1.ImportPrefixRequest{Prefix: "service", Environment: staging})
+			return err
+		}},
+		{name: "DiffHistoryKey", call: func(ctx context.Context, s *KeyServer) error {
+			_, err := s.DiffHistoryKey(ctx, &kvmiddlewarev1.DiffHistoryKeyRequest{FromId: 1, ToId: 2, Environment: staging})
+			return err
+		}},
+		{name: "DeleteKey", call: func(ctx context.Context, s *KeyServer) error {
+			_, err := s.DeleteKey(ctx, &kvmiddlewarev1.DeleteKeyRequest{KeyId: 1, Environment: staging})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := &environmentKeyUsecase{}
+			if err := tt.call(context.Background(), &KeyServer{keyUsecase: key}); err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			if key.environment != staging {
+				t.Fatalf("%s() ran in environment %q, want %q", tt.name, key.environment, staging)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package grpcapi
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	// internal dependency
+	kvmiddlewarev1 "github.com/marde12345/key-flag/api/proto/kvmiddleware/v1"
+	"github.com/marde12345/key-flag/internal/config"
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+const defaultWatchInterval = 5 * time.Second
+
+// Register attaches the key and user services to s,
+// s must be created with UnaryAuthInterceptor and StreamAuthInterceptor of the same user usecase.
+func Register(s *grpc.Server, cfg config.GRPC, key keyUsecase, user userUsecase) {
+	watchInterval := cfg.WatchInterval
+	if watchInterval <= 0 {
+		watchInterval = defaultWatchInterval
+	}
+
+	kvmiddlewarev1.RegisterKeyServiceServer(s, &KeyServer{
+		keyUsecase:    key,
+		watchInterval: watchInterval,
+	})
+	kvmiddlewarev1.RegisterUserServiceServer(s, &UserServer{
+		userUsecase: user,
+	})
+}
+
+// toStatusError converts usecase error to grpc status error.
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	if errors.Is(err, userentity.ErrUnauthenticated) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if errors.Is(err, userentity.ErrForbidden) || errors.Is(err, keyentity.ErrNotApprover) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if errors.Is(err, keyentity.ErrStaleBase) || errors.Is(err, keyentity.ErrPendingChange) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+
+	return status.Error(codes.Unknown, err.Error())
+}
@@ -0,0 +1,72 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	// entity dependency
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+type keyUsecase interface {
+	UpdateKey(ctx context.Context, kv keyentity.KV, baseID int, justification string) error
+	CreateDeleteKey(ctx context.Context, kv keyentity.KV, justification string) error
+	AmendPlacedKey(ctx context.Context, kv keyentity.KV, userID int) error
+	WithdrawPlacedKey(ctx context.Context, key string, userID int) error
+	AddComment(ctx context.Context, comment keyentity.Comment, userID int) (int, error)
+	GetComments(ctx context.Context, keyID, userID int) ([]keyentity.Comment, error)
+	SetOwnership(ctx context.Context, ownership keyentity.Ownership, userID int) error
+	GetOwnership(ctx context.Context, key string, userID int) (keyentity.Ownership, error)
+	UpdateKeyMetadata(ctx context.Context, metadata keyentity.KeyMetadata, userID int) error
+	GetKeyMetadata(ctx context.Context, key string, userID int) (keyentity.KeyMetadata, error)
+	ApproveKey(ctx context.Context, key string, userID, status int, reason string) error
+	ApproveDeleteKey(ctx context.Context, key string, userID, status int, reason string) error
+	ApproveKeyCanary(ctx context.Context, key string, userID, status int, nodesIP []string) error
+	DeleteKey(ctx context.Context, keyID, userID int) error
+	GetHistoryKey(ctx context.Context, key string, isPrefix bool, filter keyentity.HistoryFilter, userID int) (keyentity.HistoryPage, error)
+	GetKey(ctx context.Context, key string, reader keyentity.Reader) (keyentity.KV, error)
+	GetKeys(ctx context.Context, prefix string, reader keyentity.Reader) ([]keyentity.KV, error)
+	BrowseKeys(ctx context.Context, prefix string, opts keyentity.BrowseOptions, userID int) (keyentity.BrowsePage, error)
+	PendingApprovalKey(ctx context.Context, prefix string, filter keyentity.PendingFilter, userID int) (keyentity.PendingPage, error)
+	DiffHistoryKey(ctx context.Context, fromID, toID, userID int) (keyentity.Diff, error)
+	SearchKeys(ctx context.Context, userID int, query keyentity.SearchQuery) ([]keyentity.KV, error)
+	ExportPrefix(ctx context.Context, prefix, format string, userID int) ([]byte, error)
+	ImportPrefix(ctx context.Context, prefix, format string, data []byte, userID int) (keyentity.ImportResult, error)
+	PromoteKeys(ctx context.Context, req keyentity.PromotionRequest, userID int) (keyentity.PromotionResult, error)
+	CreateService(ctx context.Context, username, namespace, tribe, service string, requestedBy int) error
+	ApproveKeyCanaryGroup(ctx context.Context, key string, userID, status int, group string) error
+	RegisterCanaryGroup(ctx context.Context, servicePrefix, group string, userID int) error
+	HeartbeatCanaryTarget(ctx context.Context, servicePrefix string, target keyentity.CanaryTarget, ttl time.Duration, userID int) error
+	DeregisterCanaryTarget(ctx context.Context, servicePrefix string, target keyentity.CanaryTarget, userID int) error
+	GetKeyCanaryIP(ctx context.Context, id, userID int) ([]string, []keyentity.CanaryTarget, error)
+	SetCanaryGate(ctx context.Context, key string, gate keyentity.CanaryGate, userID int) error
+	GetCanaryDecisions(ctx context.Context, keyID, userID int) ([]keyentity.CanaryDecision, error)
+	AddPrerequisite(ctx context.Context, prerequisite keyentity.Prerequisite, userID int) error
+	RemovePrerequisite(ctx context.Context, id, userID int) error
+	GetPrerequisites(ctx context.Context, key string) ([]keyentity.Prerequisite, error)
+	GetEffectiveKeys(ctx context.Context, prefix string, reader keyentity.Reader) ([]keyentity.KV, error)
+	GetStaleKeys(ctx context.Context, prefix string, minAge time.Duration, userID int) ([]keyentity.StaleKey, error)
+	CreateStaleDeleteRequests(ctx context.Context, prefix string, minAge time.Duration, keys []string, userID int) (keyentity.StaleCleanupResult, error)
+	GetKeyReads(ctx context.Context, key string, userID int) ([]keyentity.KeyRead, error)
+	GetKeyDetail(ctx context.Context, key string, userID int) (keyentity.KeyDetail, error)
+	RotateSecrets(ctx context.Context, prefix string, userID int) (int, error)
+}
+
+type authenticator interface {
+	Authenticate(ctx context.Context, username, token string) (userentity.User, error)
+}
+
+type userUsecase interface {
+	authenticator
+	CreateUser(ctx context.Context, user userentity.User) error
+	GetUserDetails(ctx context.Context, username string) (userentity.UserDetails, error)
+	CreateRole(ctx context.Context, roles []userentity.Role, userID int) error
+	MapUserAccess(ctx context.Context, userID, requestedBy int, roles []userentity.Role) error
+	GetAllRoles(ctx context.Context, requestedBy int) ([]userentity.Role, error)
+	GetRole(ctx context.Context, prefix, permission string) (userentity.Role, error)
+	RevokeUserAccess(ctx context.Context, userID, requestedBy int, roles []userentity.Role) error
+	SearchRole(ctx context.Context, prefix string, requestedBy int) ([]userentity.Role, error)
+	CreateNamespace(ctx context.Context, namespace userentity.Namespace, adminUserID, requestedBy int) error
+	GetNamespace(ctx context.Context, name string) (userentity.Namespace, error)
+}
@@ -0,0 +1,157 @@
+package grpcapi
+
+import (
+	"context"
+
+	// internal dependency
+	kvmiddlewarev1 "github.com/marde12345/key-flag/api/proto/kvmiddleware/v1"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+type UserServer struct {
+	kvmiddlewarev1.UnimplementedUserServiceServer
+
+	userUsecase userUsecase
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *kvmiddlewarev1.CreateUserRequest) (*kvmiddlewarev1.CreateUserResponse, error) {
+	err := s.userUsecase.CreateUser(ctx, userentity.User{
+		Username: req.GetUsername(),
+		Email:    req.GetEmail(),
+		Token:    req.GetToken(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.CreateUserResponse{}, nil
+}
+
+func (s *UserServer) GetUserDetails(ctx context.Context, req *kvmiddlewarev1.GetUserDetailsRequest) (*kvmiddlewarev1.GetUserDetailsResponse, error) {
+	details, err := s.userUsecase.GetUserDetails(ctx, req.GetUsername())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetUserDetailsResponse{
+		User: &kvmiddlewarev1.User{
+			Id:       int64(details.User.ID),
+			Username: details.User.Username,
+			Email:    details.User.Email,
+		},
+		Roles: toProtoRoles(details.Roles),
+	}, nil
+}
+
+func (s *UserServer) CreateRole(ctx context.Context, req *kvmiddlewarev1.CreateRoleRequest) (*kvmiddlewarev1.CreateRoleResponse, error) {
+	err := s.userUsecase.CreateRole(ctx, fromProtoRoles(req.GetRoles()), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.CreateRoleResponse{}, nil
+}
+
+func (s *UserServer) MapUserAccess(ctx context.Context, req *kvmiddlewarev1.MapUserAccessRequest) (*kvmiddlewarev1.MapUserAccessResponse, error) {
+	err := s.userUsecase.MapUserAccess(ctx, int(req.GetUserId()), userIDFromContext(ctx), fromProtoRoles(req.GetRoles()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.MapUserAccessResponse{}, nil
+}
+
+func (s *UserServer) GetAllRoles(ctx context.Context, req *kvmiddlewarev1.GetAllRolesRequest) (*kvmiddlewarev1.GetAllRolesResponse, error) {
+	roles, err := s.userUsecase.GetAllRoles(ctx, userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetAllRolesResponse{Roles: toProtoRoles(roles)}, nil
+}
+
+func (s *UserServer) GetRole(ctx context.Context, req *kvmiddlewarev1.GetRoleRequest) (*kvmiddlewarev1.GetRoleResponse, error) {
+	role, err := s.userUsecase.GetRole(ctx, req.GetPrefix(), req.GetPermission())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetRoleResponse{Role: toProtoRole(role)}, nil
+}
+
+func (s *UserServer) RevokeUserAccess(ctx context.Context, req *kvmiddlewarev1.RevokeUserAccessRequest) (*kvmiddlewarev1.RevokeUserAccessResponse, error) {
+	err := s.userUsecase.RevokeUserAccess(ctx, int(req.GetUserId()), userIDFromContext(ctx), fromProtoRoles(req.GetRoles()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.RevokeUserAccessResponse{}, nil
+}
+
+func (s *UserServer) SearchRole(ctx context.Context, req *kvmiddlewarev1.SearchRoleRequest) (*kvmiddlewarev1.SearchRoleResponse, error) {
+	roles, err := s.userUsecase.SearchRole(ctx, req.GetPrefix(), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.SearchRoleResponse{Roles: toProtoRoles(roles)}, nil
+}
+
+func (s *UserServer) CreateNamespace(ctx context.Context, req *kvmiddlewarev1.CreateNamespaceRequest) (*kvmiddlewarev1.CreateNamespaceResponse, error) {
+	err := s.userUsecase.CreateNamespace(ctx, userentity.Namespace{
+		Name:     req.GetNamespace().GetName(),
+		Root:     req.GetNamespace().GetRoot(),
+		KeyQuota: int(req.GetNamespace().GetKeyQuota()),
+	}, int(req.GetAdminUserId()), userIDFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.CreateNamespaceResponse{}, nil
+}
+
+func (s *UserServer) GetNamespace(ctx context.Context, req *kvmiddlewarev1.GetNamespaceRequest) (*kvmiddlewarev1.GetNamespaceResponse, error) {
+	namespace, err := s.userUsecase.GetNamespace(ctx, req.GetName())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &kvmiddlewarev1.GetNamespaceResponse{
+		Namespace: &kvmiddlewarev1.Namespace{
+			Id:       int64(namespace.ID),
+			Name:     namespace.Name,
+			Root:     namespace.Root,
+			KeyQuota: int32(namespace.KeyQuota),
+		},
+	}, nil
+}
+
+func toProtoRole(role userentity.Role) *kvmiddlewarev1.Role {
+	return &kvmiddlewarev1.Role{
+		Id:         int64(role.ID),
+		Prefix:     role.Prefix,
+		Permission: role.Permission,
+	}
+}
+
+func toProtoRoles(roles []userentity.Role) []*kvmiddlewarev1.Role {
+	result := make([]*kvmiddlewarev1.Role, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, toProtoRole(role))
+	}
+
+	return result
+}
+
+func fromProtoRoles(roles []*kvmiddlewarev1.Role) []userentity.Role {
+	result := make([]userentity.Role, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, userentity.Role{
+			ID:         int(role.GetId()),
+			Prefix:     role.GetPrefix(),
+			Permission: role.GetPermission(),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,70 @@
+package grpcapi
+
+import (
+	"time"
+
+	// internal dependency
+	kvmiddlewarev1 "github.com/marde12345/key-flag/api/proto/kvmiddleware/v1"
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+// WatchKeys polls the keys under the requested prefix and streams every change.
+// The first poll is sent as a full snapshot of PUT events.
+func (s *KeyServer) WatchKeys(req *kvmiddlewarev1.WatchKeysRequest, stream kvmiddlewarev1.KeyService_WatchKeysServer) error {
+	ctx := keyentity.WithEnvironment(stream.Context(), req.GetEnvironment())
+
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	reader := keyentity.Reader{
+		Client: req.GetClient(),
+		IP:     req.GetIp(),
+		Group:  req.GetGroup(),
+		UserID: userIDFromContext(ctx),
+	}
+
+	known := make(map[string]keyentity.KV)
+	for {
+		kvs, err := s.keyUsecase.GetKeys(ctx, req.GetPrefix(), reader)
+		if err != nil {
+			return toStatusError(err)
+		}
+
+		current := make(map[string]keyentity.KV, len(kvs))
+		for _, kv := range kvs {
+			current[kv.Key] = kv
+
+			old, ok := known[kv.Key]
+			if ok && old.ID == kv.ID && old.Value == kv.Value && old.Status == kv.Status {
+				continue
+			}
+
+			if err := stream.Send(&kvmiddlewarev1.WatchKeysResponse{
+				Type: kvmiddlewarev1.WatchKeysResponse_EVENT_TYPE_PUT,
+				Kv:   toProtoKV(kv),
+			}); err != nil {
+				return err
+			}
+		}
+
+		for key, kv := range known {
+			if _, ok := current[key]; ok {
+				continue
+			}
+
+			if err := stream.Send(&kvmiddlewarev1.WatchKeysResponse{
+				Type: kvmiddlewarev1.WatchKeysResponse_EVENT_TYPE_DELETE,
+				Kv:   toProtoKV(kv),
+			}); err != nil {
+				return err
+			}
+		}
+		known = current
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
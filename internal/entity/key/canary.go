@@ -0,0 +1,81 @@
+package key
+
+import "time"
+
+// Service is created by CreateService and own every key under its prefix
+type Service struct {
+	ID        int    `db:"id" json:"id"`
+	Namespace string `db:"namespace" json:"namespace"`
+	Tribe     string `db:"tribe" json:"tribe"`
+	Name      string `db:"name" json:"name"`
+	Prefix    string `db:"prefix" json:"prefix"`
+	CreatedBy int    `db:"created_by" json:"created_by"`
+}
+
+// CanaryGroup is a named set of nodes of a service that receive canary values together
+type CanaryGroup struct {
+	ID        int    `db:"id" json:"id"`
+	ServiceID int    `db:"service_id" json:"service_id"`
+	Name      string `db:"name" json:"name"`
+	CreatedBy int    `db:"created_by" json:"created_by"`
+}
+
+// CanaryTarget is a node registered by its own heartbeat, it is gone once ExpiresAt passed
+type CanaryTarget struct {
+	ServiceID int       `json:"service_id"`
+	Group     string    `json:"group"`
+	NodeID    string    `json:"node_id"`
+	IP        string    `json:"ip"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+const (
+	DefaultCanaryTargetTTL = 30 * time.Second
+	MaxCanaryTargetTTL     = 5 * time.Minute
+)
+
+// CanaryGate promote the canary once it baked for MinBakeSeconds and the checker report healthy,
+// or revert it when the checker report unhealthy FailureThreshold times in a row.
+// Target is url for http checker and query for prometheus checker, prometheus is healthy when the value <= Threshold.
+type CanaryGate struct {
+	KeyID            int       `db:"key_id" json:"key_id"`
+	MinBakeSeconds   int       `db:"min_bake_seconds" json:"min_bake_seconds"`
+	Checker          string    `db:"checker" json:"checker"`
+	Target           string    `db:"target" json:"target"`
+	Threshold        float64   `db:"threshold" json:"threshold"`
+	FailureThreshold int       `db:"failure_threshold" json:"failure_threshold"`
+	Failures         int       `db:"failures" json:"failures"`
+	StartTime        time.Time `db:"start_time" json:"start_time"`
+	CreatedBy        int       `db:"created_by" json:"created_by"`
+	Status           int       `db:"status" json:"status"`
+}
+
+// DefaultCanaryFailureThreshold tolerate two flaky checks in a row, the third unhealthy check reverts
+const DefaultCanaryFailureThreshold = 3
+
+func (g CanaryGate) MinBakeTime() time.Duration {
+	return time.Duration(g.MinBakeSeconds) * time.Second
+}
+
+type HealthResult struct {
+	Healthy bool   `json:"healthy"`
+	Reason  string `json:"reason"`
+}
+
+type CanaryDecision struct {
+	ID         int       `db:"id" json:"id"`
+	KeyID      int       `db:"key_id" json:"key_id"`
+	Decision   string    `db:"decision" json:"decision"`
+	Reason     string    `db:"reason" json:"reason"`
+	CreateTime time.Time `db:"create_time" json:"create_time"`
+}
+
+const (
+	HealthCheckerHTTP       = "http"
+	HealthCheckerPrometheus = "prometheus"
+)
+
+const (
+	CanaryDecisionPromoted = "promoted"
+	CanaryDecisionReverted = "reverted"
+)
@@ -0,0 +1,22 @@
+package key
+
+import "time"
+
+// Comment on a key row, replies point to the comment they answer with ParentID
+type Comment struct {
+	ID         int       `db:"id" json:"id"`
+	KeyID      int       `db:"key_id" json:"key_id"`
+	ParentID   int       `db:"parent_id" json:"parent_id"`
+	Kind       string    `db:"kind" json:"kind"`
+	Body       string    `db:"body" json:"body"`
+	CreatedBy  int       `db:"created_by" json:"created_by"`
+	CreateTime time.Time `db:"create_time" json:"create_time"`
+}
+
+const (
+	CommentKindComment = "comment"
+	// CommentKindJustification is written by the author when the change is placed
+	CommentKindJustification = "justification"
+	// CommentKindDisapproval is the reason written by the approver who disapproved the change
+	CommentKindDisapproval = "disapproval"
+)
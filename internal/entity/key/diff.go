@@ -0,0 +1,46 @@
+package key
+
+type Diff struct {
+	Kind    string       `json:"kind"`
+	Old     string       `json:"old"`
+	New     string       `json:"new"`
+	Lines   []DiffLine   `json:"lines,omitempty"`
+	Changes []DiffChange `json:"changes,omitempty"`
+}
+
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// DiffChange is a single structural change of a json value, Old and New are json encoded.
+type DiffChange struct {
+	Path string `json:"path"`
+	Op   string `json:"op"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+const (
+	DiffKindLine   = "line"
+	DiffKindJSON   = "json"
+	DiffKindScalar = "scalar"
+	// DiffKindSecret only tells the value changed, both values are masked
+	DiffKindSecret = "secret"
+)
+
+const (
+	DiffOpEqual   = "equal"
+	DiffOpAdded   = "added"
+	DiffOpRemoved = "removed"
+	DiffOpChanged = "changed"
+)
+
+const (
+	TypeString = "string"
+	TypeJSON   = "json"
+	TypeSecret = "secret"
+)
+
+// SecretMask replace secret value everywhere except GetKey of authorized reader
+const SecretMask = "******"
@@ -0,0 +1,26 @@
+package key
+
+import "context"
+
+const DefaultEnvironment = "production"
+
+type environmentKey struct{}
+
+// WithEnvironment scope usecase and repository calls made with the ctx to the environment,
+// empty environment keeps DefaultEnvironment.
+func WithEnvironment(ctx context.Context, environment string) context.Context {
+	if environment == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, environmentKey{}, environment)
+}
+
+// EnvironmentFromContext returns environment of the ctx, DefaultEnvironment when it is not set
+func EnvironmentFromContext(ctx context.Context) string {
+	if environment, ok := ctx.Value(environmentKey{}).(string); ok {
+		return environment
+	}
+
+	return DefaultEnvironment
+}
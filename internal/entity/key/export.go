@@ -0,0 +1,37 @@
+package key
+
+import "time"
+
+type ExportDocument struct {
+	Prefix     string        `json:"prefix" yaml:"prefix"`
+	ExportedAt time.Time     `json:"exported_at" yaml:"exported_at"`
+	Keys       []ExportedKey `json:"keys" yaml:"keys"`
+}
+
+type ExportedKey struct {
+	Key        string    `json:"key" yaml:"key"`
+	Value      string    `json:"value" yaml:"value"`
+	Type       string    `json:"type" yaml:"type"`
+	CreatedBy  int       `json:"created_by" yaml:"created_by"`
+	ApprovedBy int       `json:"approved_by" yaml:"approved_by"`
+	UpdateTime time.Time `json:"update_time" yaml:"update_time"`
+}
+
+// ChangeSet group placed keys so they can be reviewed together
+type ChangeSet struct {
+	ID          int       `db:"id" json:"id"`
+	Description string    `db:"description" json:"description"`
+	CreateTime  time.Time `db:"create_time" json:"create_time"`
+	CreatedBy   int       `db:"created_by" json:"created_by"`
+}
+
+type ImportResult struct {
+	ChangeSetID int      `json:"change_set_id"`
+	Changed     []string `json:"changed"`
+	Unchanged   []string `json:"unchanged"`
+}
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
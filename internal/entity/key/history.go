@@ -0,0 +1,43 @@
+package key
+
+import "time"
+
+// HistoryFilter narrow down key history, zero value means no filter.
+type HistoryFilter struct {
+	Statuses   []int     `json:"statuses"`
+	CreatedBy  int       `json:"created_by"`
+	ApprovedBy int       `json:"approved_by"`
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	Cursor     string    `json:"cursor"`
+	Limit      int       `json:"limit"`
+}
+
+type HistoryPage struct {
+	Items      []KV   `json:"items"`
+	NextCursor string `json:"next_cursor"`
+	// Comments of the items ordered by create time
+	Comments []Comment `json:"comments"`
+}
+
+// BrowseOptions with separator return only immediate children of the prefix, like consul ?keys&separator=/
+type BrowseOptions struct {
+	Separator string `json:"separator"`
+	Cursor    string `json:"cursor"`
+	Limit     int    `json:"limit"`
+}
+
+type BrowseNode struct {
+	Name  string `json:"name"`
+	IsDir bool   `json:"is_dir"`
+	Count int    `json:"count,omitempty"`
+	// Metadata of the key, nil for directory and key without metadata
+	Metadata *KeyMetadata `json:"metadata,omitempty"`
+	// Expired is set for temporary key past its expiry date
+	Expired bool `json:"expired,omitempty"`
+}
+
+type BrowsePage struct {
+	Nodes      []BrowseNode `json:"nodes"`
+	NextCursor string       `json:"next_cursor"`
+}
@@ -1,6 +1,9 @@
 package key
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 type KV struct {
 	ID          int       `db:"id" json:"id"`
@@ -13,11 +16,15 @@ type KV struct {
 	ApprovedBy  int       `db:"approved_by" json:"approved_by"`
 	Status      int       `db:"status" json:"status"`
 	CreateByStr string    `db:"created_by_str" json:"created_by_str"`
+	ChangeSetID int       `db:"change_set_id" json:"change_set_id"`
+	Environment string    `db:"environment" json:"environment"`
 }
 
+// CanaryKV is one canary target of the key, either IP or Group is set
 type CanaryKV struct {
 	KeyID  int    `db:"key_id" json:"key_id"`
 	IP     string `db:"ip" json:"ip"`
+	Group  string `db:"canary_group" json:"group"`
 	Status int    `db:"status" json:"status"`
 }
 
@@ -30,10 +37,10 @@ const (
 	CanaryKey
 	PlacedDeleteKey
 	DeletedKey
-)
-
-const (
-	RedisKeyCanaryDeployment = "deployment:canary"
+	// AmendedKey is placed row replaced by newer value of its author
+	AmendedKey
+	// WithdrawnKey is placed or placed delete row taken back before approval
+	WithdrawnKey
 )
 
 const (
@@ -41,6 +48,13 @@ const (
 	StatusActive   = 1
 )
 
+// ErrStaleBase is returned when the change is based on active version that is no longer active
+var ErrStaleBase = errors.New("Key was changed since your base version, reload and try again.")
+
+// ErrPendingChange is returned when the key already has placed or placed delete row, or when a pending row of the key
+// is inserted concurrently. Key in canary can not be amended or withdrawn, so changing it returns its own error.
+var ErrPendingChange = errors.New("Key already has pending change, amend or withdraw it first.")
+
 func (kv KV) StatusString() string {
 	switch kv.Status {
 	case ApprovedAndExpiredKey:
@@ -53,10 +67,16 @@ func (kv KV) StatusString() string {
 		return "disapproved"
 	case ApprovedAndActive:
 		return "active"
+	case CanaryKey:
+		return "canary"
 	case PlacedDeleteKey:
 		return "placed delete"
 	case DeletedKey:
 		return "inactive"
+	case AmendedKey:
+		return "amended"
+	case WithdrawnKey:
+		return "withdrawn"
 	}
 
 	return ""
@@ -0,0 +1,27 @@
+package key
+
+import "time"
+
+// KeyMetadata describe a key, it belongs to the key name so it is kept across value changes and edited without approval
+type KeyMetadata struct {
+	Key         string   `db:"key" json:"key"`
+	Description string   `db:"description" json:"description"`
+	Tags        []string `db:"tags" json:"tags"`
+	Owner       string   `db:"owner" json:"owner"`
+	Ticket      string   `db:"ticket" json:"ticket"`
+	Lifetime    string   `db:"lifetime" json:"lifetime"`
+	// ExpiryDate is when temporary flag is expected to be removed, zero for permanent key
+	ExpiryDate time.Time `db:"expiry_date" json:"expiry_date"`
+	UpdateTime time.Time `db:"update_time" json:"update_time"`
+	UpdatedBy  int       `db:"updated_by" json:"updated_by"`
+}
+
+const (
+	LifetimePermanent = "permanent"
+	LifetimeTemporary = "temporary"
+)
+
+// Expired tells if temporary flag passed its expiry date
+func (m KeyMetadata) Expired(now time.Time) bool {
+	return m.Lifetime == LifetimeTemporary && !m.ExpiryDate.IsZero() && now.After(m.ExpiryDate)
+}
@@ -0,0 +1,70 @@
+package key
+
+import (
+	"errors"
+	"time"
+
+	"github.com/marde12345/key-flag/internal/util"
+)
+
+// Ownership describe who owns keys under the prefix, like CODEOWNERS the most specific prefix covering a key wins
+type Ownership struct {
+	ID          int       `db:"id" json:"id"`
+	Prefix      string    `db:"prefix" json:"prefix"`
+	Owners      []Owner   `db:"owners" json:"owners"`
+	Description string    `db:"description" json:"description"`
+	OnCall      string    `db:"on_call" json:"on_call"`
+	Tags        []string  `db:"tags" json:"tags"`
+	UpdateTime  time.Time `db:"update_time" json:"update_time"`
+	UpdatedBy   int       `db:"updated_by" json:"updated_by"`
+}
+
+// Owner is a team handle or a username, user owners are the only approvers of the change
+type Owner struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+const (
+	OwnerKindTeam = "team"
+	OwnerKindUser = "user"
+)
+
+// ErrNotApprover is returned when the key has user owners and the approver is none of them nor lead of the key
+var ErrNotApprover = errors.New("Only user owners or leads of the key can approve its changes.")
+
+// Usernames returns name of user owners
+func (o Ownership) Usernames() []string {
+	usernames := make([]string, 0, len(o.Owners))
+	for _, owner := range o.Owners {
+		if owner.Kind == OwnerKindUser {
+			usernames = append(usernames, owner.Name)
+		}
+	}
+
+	return usernames
+}
+
+// FindOwnership returns ownership of the most specific prefix containing the key
+func FindOwnership(ownerships []Ownership, key string) (Ownership, bool) {
+	var found Ownership
+	for _, ownership := range ownerships {
+		if !util.IsUnderPrefix(key, ownership.Prefix) {
+			continue
+		}
+
+		if len(ownership.Prefix) > len(found.Prefix) {
+			found = ownership
+		}
+	}
+
+	return found, found.ID > 0
+}
+
+// Notification of a key transition sent to owners of the key
+type Notification struct {
+	KV        KV        `json:"kv"`
+	Actor     int       `json:"actor"`
+	Reason    string    `json:"reason"`
+	Ownership Ownership `json:"ownership"`
+}
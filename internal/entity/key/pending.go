@@ -0,0 +1,50 @@
+package key
+
+import "time"
+
+type PendingKV struct {
+	Kind   string `json:"kind"`
+	KV     KV     `json:"kv"`
+	Active KV     `json:"active"`
+	Diff   Diff   `json:"diff"`
+	// Comments of the pending row ordered by create time, replies are linked by ParentID
+	Comments []Comment `json:"comments"`
+	// Ownership of the most specific prefix covering the key, zero when nobody owns it
+	Ownership Ownership `json:"ownership"`
+}
+
+// PendingFilter narrow down keys waiting for approval, zero value means no filter.
+type PendingFilter struct {
+	Kinds  []string `json:"kinds"`
+	Author int      `json:"author"`
+	// Approver is username the changes are routed to, see Ownership
+	Approver string        `json:"approver"`
+	MinAge   time.Duration `json:"min_age"`
+	MaxAge   time.Duration `json:"max_age"`
+	Sort     string        `json:"sort"`
+	Limit    int           `json:"limit"`
+	Offset   int           `json:"offset"`
+}
+
+type PendingPage struct {
+	Items []PendingKV `json:"items"`
+	Total int         `json:"total"`
+}
+
+const (
+	PendingKindUpdate = "update"
+	PendingKindDelete = "delete"
+	PendingKindCanary = "canary"
+)
+
+const (
+	SortOldest = "oldest"
+	SortNewest = "newest"
+)
+
+// PendingKindStatus map pending kind to key status
+var PendingKindStatus = map[string]int{
+	PendingKindUpdate: PlacedKey,
+	PendingKindDelete: PlacedDeleteKey,
+	PendingKindCanary: CanaryKey,
+}
@@ -0,0 +1,40 @@
+package key
+
+import (
+	"strings"
+	"time"
+)
+
+// Prerequisite make keys covered by Key only effective when RequiredKey has RequiredValue.
+// Key ending with /* covers every key under the prefix, e.g. checkout/new_flow/*.
+type Prerequisite struct {
+	ID            int    `db:"id" json:"id"`
+	Key           string `db:"key" json:"key"`
+	RequiredKey   string `db:"required_key" json:"required_key"`
+	RequiredValue string `db:"required_value" json:"required_value"`
+	// FallbackValue is the effective value of covered keys while prerequisite is not met
+	FallbackValue string    `db:"fallback_value" json:"fallback_value"`
+	CreateTime    time.Time `db:"create_time" json:"create_time"`
+	CreatedBy     int       `db:"created_by" json:"created_by"`
+	Status        int       `db:"status" json:"status"`
+}
+
+const prerequisiteWildcard = "/*"
+
+// Covers check if the key depends on the prerequisite, required key never depends on itself
+func (p Prerequisite) Covers(key string) bool {
+	if key == p.RequiredKey {
+		return false
+	}
+
+	if strings.HasSuffix(p.Key, prerequisiteWildcard) {
+		return strings.HasPrefix(key, p.Prefix())
+	}
+
+	return key == p.Key
+}
+
+// Prefix returns the prefix covered by the prerequisite, for exact key it is the key itself
+func (p Prerequisite) Prefix() string {
+	return strings.TrimSuffix(p.Key, "*")
+}
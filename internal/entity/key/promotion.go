@@ -0,0 +1,24 @@
+package key
+
+// PromotionRequest copy active keys from source environment as placed keys in target environment.
+// Empty Keys means every key under the prefix.
+type PromotionRequest struct {
+	SourceEnvironment string   `json:"source_environment"`
+	TargetEnvironment string   `json:"target_environment"`
+	Prefix            string   `json:"prefix"`
+	Keys              []string `json:"keys"`
+	DryRun            bool     `json:"dry_run"`
+}
+
+type PromotionItem struct {
+	Key    string `json:"key"`
+	Source KV     `json:"source"`
+	Target KV     `json:"target"`
+	Diff   Diff   `json:"diff"`
+}
+
+type PromotionResult struct {
+	ChangeSetID int             `json:"change_set_id"`
+	Items       []PromotionItem `json:"items"`
+	Unchanged   []string        `json:"unchanged"`
+}
@@ -0,0 +1,23 @@
+package key
+
+// SearchQuery look up active keys by name, value and metadata.
+// Text is a substring, a regex or a json path depending on Mode.
+// InMetadata match description, owner and ticket of the key, Tags keep only keys having every tag.
+// Expired keep only temporary keys past their expiry date.
+type SearchQuery struct {
+	Prefix     string   `json:"prefix"`
+	Text       string   `json:"text"`
+	Mode       string   `json:"mode"`
+	InKey      bool     `json:"in_key"`
+	InValue    bool     `json:"in_value"`
+	InMetadata bool     `json:"in_metadata"`
+	Tags       []string `json:"tags"`
+	Expired    bool     `json:"expired"`
+	Limit      int      `json:"limit"`
+}
+
+const (
+	SearchModeSubstring = "substring"
+	SearchModeRegex     = "regex"
+	SearchModeJSONPath  = "jsonpath"
+)
@@ -0,0 +1,22 @@
+package key
+
+import "time"
+
+// DefaultStaleAge is how long active key must stay unchanged before it is listed as stale
+const DefaultStaleAge = 90 * 24 * time.Hour
+
+type StaleKey struct {
+	KV  KV            `json:"kv"`
+	Age time.Duration `json:"age"`
+	// Changes is number of approved versions of the key, key that was never changed is likely a leftover
+	Changes int `json:"changes"`
+	// LastRead is the most recent read recorded by telemetry, empty when the key was never read
+	LastRead KeyRead `json:"last_read"`
+	// Expired temporary key is stale whatever its age
+	Expired bool `json:"expired"`
+}
+
+type StaleCleanupResult struct {
+	ChangeSetID int      `json:"change_set_id"`
+	Keys        []string `json:"keys"`
+}
@@ -0,0 +1,35 @@
+package key
+
+import "time"
+
+// Reader identify the client reading keys, IP and Group, the canary group of the node, are also used to resolve canary values.
+// UserID is only needed to read secret values.
+type Reader struct {
+	Client string `json:"client"`
+	IP     string `json:"ip"`
+	Group  string `json:"group"`
+	UserID int    `json:"user_id"`
+}
+
+// KeyRead is aggregated reads of a key by one client, Count is estimated from sampled reads
+type KeyRead struct {
+	Environment  string    `db:"environment" json:"environment"`
+	Key          string    `db:"key" json:"key"`
+	Client       string    `db:"client" json:"client"`
+	IP           string    `db:"ip" json:"ip"`
+	Count        int64     `db:"count" json:"count"`
+	LastReadTime time.Time `db:"last_read_time" json:"last_read_time"`
+}
+
+type KeyDetail struct {
+	KV       KV          `json:"kv"`
+	Metadata KeyMetadata `json:"metadata"`
+	Reads    []KeyRead   `json:"reads"`
+}
+
+const (
+	DefaultReadSampleRate    = 0.01
+	DefaultReadFlushInterval = time.Minute
+	// DefaultMaxPendingReads bound reads kept in memory between flushes, reads of new key, client and ip are dropped above it
+	DefaultMaxPendingReads = 100000
+)
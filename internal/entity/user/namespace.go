@@ -0,0 +1,62 @@
+package user
+
+import (
+	"errors"
+
+	"github.com/marde12345/key-flag/internal/util"
+)
+
+// Namespace is an isolated tree of keys with its own roles, zero KeyQuota means unlimited
+type Namespace struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Root      string `json:"root"`
+	KeyQuota  int    `json:"key_quota"`
+	CreatedBy int    `json:"created_by"`
+}
+
+const DefaultNamespace = "service"
+
+var ErrForbidden = errors.New("You don't have access to this prefix.")
+
+// ErrUnauthenticated is returned when the caller can not be verified
+var ErrUnauthenticated = errors.New("Invalid username or token.")
+
+// RoleRank is the role hierarchy, higher role has every permission of the lower one
+var RoleRank = map[string]int{
+	RoleUser:      1,
+	RoleSuperUser: 2,
+	RoleLead:      3,
+	RoleAdmin:     4,
+}
+
+// HasPermission check if one of the roles give at least permission on the prefix
+func HasPermission(roles []Role, prefix, permission string) bool {
+	for _, role := range roles {
+		if role.Prefix == "" || !util.IsUnderPrefix(prefix, role.Prefix) {
+			continue
+		}
+
+		if RoleRank[role.Permission] >= RoleRank[permission] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FindNamespace returns namespace which root contains the key
+func FindNamespace(namespaces []Namespace, key string) (Namespace, bool) {
+	var found Namespace
+	for _, namespace := range namespaces {
+		if !util.IsUnderPrefix(key, namespace.Root) {
+			continue
+		}
+
+		if len(namespace.Root) > len(found.Root) {
+			found = namespace
+		}
+	}
+
+	return found, found.ID > 0
+}
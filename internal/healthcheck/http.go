@@ -0,0 +1,104 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+// maxRedirects is the same limit as the default http client
+const maxRedirects = 10
+
+// HTTPChecker is healthy when gate target returns 2xx.
+// Target is set by superusers, so only http and https urls on allowed hosts are requested, redirects included.
+type HTTPChecker struct {
+	client       *http.Client
+	allowedHosts []string
+}
+
+// NewHTTPChecker allow targets on allowedHosts, host starting with *. also allows its subdomains
+func NewHTTPChecker(timeout time.Duration, allowedHosts []string) *HTTPChecker {
+	c := &HTTPChecker{allowedHosts: allowedHosts}
+	c.client = &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			return c.validateURL(req.URL)
+		},
+	}
+
+	return c
+}
+
+// ValidateTarget reject target with scheme other than http and https or outside of allowed hosts
+func (c *HTTPChecker) ValidateTarget(target string) error {
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return err
+	}
+
+	return c.validateURL(targetURL)
+}
+
+func (c *HTTPChecker) validateURL(target *url.URL) error {
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return fmt.Errorf("scheme %q is not allowed", target.Scheme)
+	}
+
+	if target.User != nil {
+		return errors.New("credentials in url are not allowed")
+	}
+
+	host := strings.ToLower(target.Hostname())
+	for _, allowed := range c.allowedHosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed {
+			return nil
+		}
+
+		if strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:]) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("host %q is not allowed", host)
+}
+
+func (c *HTTPChecker) Check(ctx context.Context, gate keyentity.CanaryGate) (keyentity.HealthResult, error) {
+	// gate may be stored before the allowed hosts changed
+	if err := c.ValidateTarget(gate.Target); err != nil {
+		return keyentity.HealthResult{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gate.Target, nil)
+	if err != nil {
+		return keyentity.HealthResult{}, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return keyentity.HealthResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return keyentity.HealthResult{
+			Healthy: false,
+			Reason:  fmt.Sprintf("%s returns %d", gate.Target, resp.StatusCode),
+		}, nil
+	}
+
+	return keyentity.HealthResult{
+		Healthy: true,
+		Reason:  fmt.Sprintf("%s returns %d", gate.Target, resp.StatusCode),
+	}, nil
+}
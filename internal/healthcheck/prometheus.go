@@ -0,0 +1,110 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+// PrometheusChecker run gate target as instant query, it is healthy when every sample <= gate threshold
+type PrometheusChecker struct {
+	address string
+	client  *http.Client
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func NewPrometheusChecker(address string, timeout time.Duration) *PrometheusChecker {
+	return &PrometheusChecker{
+		address: address,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// ValidateTarget accept any query, it only runs on the configured prometheus
+func (c *PrometheusChecker) ValidateTarget(target string) error {
+	return nil
+}
+
+func (c *PrometheusChecker) Check(ctx context.Context, gate keyentity.CanaryGate) (keyentity.HealthResult, error) {
+	queryURL := fmt.Sprintf("%s/api/v1/query?query=%s", c.address, url.QueryEscape(gate.Target))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	if err != nil {
+		return keyentity.HealthResult{}, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return keyentity.HealthResult{}, err
+	}
+	defer resp.Body.Close()
+
+	var body prometheusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return keyentity.HealthResult{}, err
+	}
+
+	if body.Status != "success" {
+		return keyentity.HealthResult{}, errors.New(body.Error)
+	}
+
+	if body.Data.ResultType != "vector" {
+		return keyentity.HealthResult{}, fmt.Errorf("unsupported result type %s", body.Data.ResultType)
+	}
+
+	// no sample means nothing to judge yet
+	if len(body.Data.Result) == 0 {
+		return keyentity.HealthResult{}, errors.New("query returns no sample")
+	}
+
+	for _, sample := range body.Data.Result {
+		if len(sample.Value) != 2 {
+			return keyentity.HealthResult{}, errors.New("invalid sample")
+		}
+
+		raw, ok := sample.Value[1].(string)
+		if !ok {
+			return keyentity.HealthResult{}, errors.New("invalid sample")
+		}
+
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return keyentity.HealthResult{}, err
+		}
+
+		// NaN compares false with every threshold, e.g. error rate without traffic, so it is no signal either
+		if math.IsNaN(value) {
+			return keyentity.HealthResult{}, errors.New("query returns NaN")
+		}
+
+		if value > gate.Threshold {
+			return keyentity.HealthResult{
+				Healthy: false,
+				Reason:  fmt.Sprintf("%s = %g is above threshold %g", gate.Target, value, gate.Threshold),
+			}, nil
+		}
+	}
+
+	return keyentity.HealthResult{
+		Healthy: true,
+		Reason:  fmt.Sprintf("%s is within threshold %g", gate.Target, gate.Threshold),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+func prometheusServer(t *testing.T, samples ...string) *httptest.Server {
+	t.Helper()
+
+	result := ""
+	for i, sample := range samples {
+		if i > 0 {
+			result += ","
+		}
+		result += fmt.Sprintf(`{"metric":{},"value":[1700000000,%q]}`, sample)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[%s]}}`, result)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestPrometheusChecker(t *testing.T) {
+	gate := keyentity.CanaryGate{Target: "error_rate", Threshold: 0.05}
+
+	tests := []struct {
+		name    string
+		samples []string
+		healthy bool
+		wantErr bool
+	}{
+		{name: "within threshold", samples: []string{"0.01", "0.05"}, healthy: true},
+		{name: "above threshold", samples: []string{"0.01", "0.2"}, healthy: false},
+		{name: "no sample", samples: nil, wantErr: true},
+		{name: "nan", samples: []string{"NaN"}, wantErr: true},
+		{name: "nan next to healthy sample", samples: []string{"0.01", "NaN"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := prometheusServer(t, tt.samples...)
+			checker := NewPrometheusChecker(server.URL, time.Second)
+
+			result, err := checker.Check(context.Background(), gate)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Check() = %+v, want error", result)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if result.Healthy != tt.healthy {
+				t.Fatalf("Check() healthy = %v, want %v", result.Healthy, tt.healthy)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/marde12345/key-flag/internal/config"
+	"github.com/marde12345/key-flag/internal/tracing"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+const redacted = "[REDACTED]"
+
+// Logger is slog logger that knows which key values must not be logged
+type Logger struct {
+	*slog.Logger
+	sensitiveKeys []string
+}
+
+// New create logger writing json, or text when format is text e.g. in development.
+// Level is one of debug, info, warn and error, --log_level flag overrides the one from config.
+func New(cfg config.Log, w io.Writer) *Logger {
+	options := &slog.HandlerOptions{Level: ParseLevel(cfg.Level)}
+
+	var handler slog.Handler = slog.NewJSONHandler(w, options)
+	if cfg.Format == FormatText {
+		handler = slog.NewTextHandler(w, options)
+	}
+
+	return &Logger{
+		Logger:        slog.New(traceHandler{handler}),
+		sensitiveKeys: cfg.SensitiveKeys,
+	}
+}
+
+// Flags are the logger command line flags
+type Flags struct {
+	Level string
+}
+
+// RegisterFlags register --log_level on fs
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{}
+	fs.StringVar(&f.Level, "log_level", "", "one of debug, info, warn and error, overrides log.level from config")
+	return f
+}
+
+// Apply returns cfg with Level replaced by --log_level when the flag is set
+func (f *Flags) Apply(cfg config.Log) config.Log {
+	if f.Level != "" {
+		cfg.Level = f.Level
+	}
+	return cfg
+}
+
+// Nop returns logger discarding everything, used until a logger is injected
+func Nop() *Logger {
+	return &Logger{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+}
+
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	}
+
+	return slog.LevelInfo
+}
+
+// Value returns value attribute of the key, redacted when the key is sensitive
+func (l *Logger) Value(key, value string) slog.Attr {
+	if l.IsSensitive(key) {
+		return slog.String("value", redacted)
+	}
+
+	return slog.String("value", value)
+}
+
+// IsSensitive check if the key contains one of sensitive words from config, e.g. password or token
+func (l *Logger) IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range l.sensitiveKeys {
+		if strings.Contains(key, strings.ToLower(sensitive)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// traceHandler add trace id of the span in ctx to every record
+type traceHandler struct {
+	slog.Handler
+}
+
+func (h traceHandler) Handle(ctx context.Context, record slog.Record) error {
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		record.AddAttrs(slog.String("trace_id", traceID))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"log/slog"
+	"testing"
+
+	"github.com/marde12345/key-flag/internal/config"
+)
+
+func TestFlagsApply(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		cfg       config.Log
+		wantLevel slog.Level
+	}{
+		{name: "flag overrides config", args: []string{"--log_level=debug"}, cfg: config.Log{Level: "error"}, wantLevel: slog.LevelDebug},
+		{name: "config without flag", cfg: config.Log{Level: "warn"}, wantLevel: slog.LevelWarn},
+		{name: "empty flag keeps config", args: []string{"--log_level="}, cfg: config.Log{Level: "error"}, wantLevel: slog.LevelError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags := RegisterFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			log := New(flags.Apply(tt.cfg), &buf)
+			for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+				if got, want := log.Enabled(context.Background(), level), level >= tt.wantLevel; got != want {
+					t.Fatalf("Enabled(%v) = %v, want %v", level, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor record count and latency of every unary call, labeled by usecase method
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observe(info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor record count and duration of every stream, e.g. WatchKeys
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observe(info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// observe label by method name only, rpc names match the usecase methods
+func observe(fullMethod string, start time.Time, err error) {
+	method := path.Base(fullMethod)
+
+	Requests.WithLabelValues(method, status.Code(err).String()).Inc()
+	RequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "kv_middleware"
+
+// Cache tiers, l1 is ristretto, l2 is redis and db is fallback when both missed
+const (
+	TierL1 = "l1"
+	TierL2 = "l2"
+	TierDB = "db"
+)
+
+const (
+	ResultHit  = "hit"
+	ResultMiss = "miss"
+)
+
+var (
+	// Requests count every usecase call served by the api by its outcome
+	Requests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "Usecase calls by method and grpc code.",
+	}, []string{"method", "code"})
+
+	RequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of usecase calls by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	// CacheRequests count lookup of every cache tier, l1 and l2 are recorded by the cache repository
+	// and db fallback by the key usecase
+	CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Cache lookups by operation, tier and result.",
+	}, []string{"operation", "tier", "result"})
+
+	PendingKeys = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pending_keys",
+		Help:      "Keys waiting for approval by service prefix and kind.",
+	}, []string{"prefix", "kind"})
+
+	CanaryKeys = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "canary_keys",
+		Help:      "Keys in canary by service prefix.",
+	}, []string{"prefix"})
+
+	CanaryTargets = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "canary_targets",
+		Help:      "Live canary nodes by service prefix and group.",
+	}, []string{"prefix", "group"})
+
+	// ConsulPublishFailures is increased by the consul publisher on every failed write
+	ConsulPublishFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "consul_publish_failures_total",
+		Help:      "Failed writes of keys to consul by operation.",
+	}, []string{"operation"})
+)
+
+// ObserveCache record cache lookup of the operation on the tier
+func ObserveCache(operation, tier string, hit bool) {
+	result := ResultMiss
+	if hit {
+		result = ResultHit
+	}
+
+	CacheRequests.WithLabelValues(operation, tier, result).Inc()
+}
+
+// Handler serve metrics for the /metrics endpoint
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Serve listen on the address and serve Handler on /metrics until ctx is done
+func Serve(ctx context.Context, address string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.WithoutCancel(ctx))
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/marde12345/key-flag/internal/config"
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// Slack post notification to incoming webhook, owners are mentioned by their slack id
+type Slack struct {
+	webhook string
+	userIDs map[string]string
+	teamIDs map[string]string
+	client  *http.Client
+}
+
+func NewSlack(cfg config.Slack) *Slack {
+	return &Slack{
+		webhook: cfg.Webhook,
+		userIDs: cfg.UserIDs,
+		teamIDs: cfg.TeamIDs,
+		client:  &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+func (s *Slack) Notify(ctx context.Context, notification keyentity.Notification) error {
+	body, err := json.Marshal(map[string]string{"text": s.text(notification)})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Slack webhook returns %d.", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *Slack) text(notification keyentity.Notification) string {
+	kv := notification.KV
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "`%s` is %s by user %d", escape(kv.Key), kv.StatusString(), notification.Actor)
+	if kv.Environment != "" {
+		fmt.Fprintf(&text, " in %s", escape(kv.Environment))
+	}
+
+	if notification.Reason != "" {
+		fmt.Fprintf(&text, "\nReason: %s", escape(notification.Reason))
+	}
+
+	ownership := notification.Ownership
+	if len(ownership.Owners) > 0 {
+		mentions := make([]string, 0, len(ownership.Owners))
+		for _, owner := range ownership.Owners {
+			mentions = append(mentions, s.mention(owner))
+		}
+		fmt.Fprintf(&text, "\nOwners: %s", strings.Join(mentions, " "))
+	}
+
+	if ownership.OnCall != "" {
+		fmt.Fprintf(&text, "\nOn-call: %s", escape(ownership.OnCall))
+	}
+
+	return text.String()
+}
+
+// mention format the owner as slack mention, plain "@name" text doesn't notify anybody
+func (s *Slack) mention(owner keyentity.Owner) string {
+	switch owner.Kind {
+	case keyentity.OwnerKindUser:
+		if id, ok := s.userIDs[owner.Name]; ok {
+			return "<@" + id + ">"
+		}
+	case keyentity.OwnerKindTeam:
+		if id, ok := s.teamIDs[owner.Name]; ok {
+			return "<!subteam^" + id + ">"
+		}
+	}
+
+	return escape(owner.Name)
+}
+
+// escape user input so it can not be read as mention or link, e.g. <!channel>
+func escape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/marde12345/key-flag/internal/config"
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+func TestSlackNotify(t *testing.T) {
+	var text string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		text = body["text"]
+	}))
+	defer server.Close()
+
+	slack := NewSlack(config.Slack{
+		Webhook: server.URL,
+		UserIDs: map[string]string{"alice": "U024BE7LH"},
+		TeamIDs: map[string]string{"risk": "SAZ94GDB8"},
+	})
+
+	err := slack.Notify(context.Background(), keyentity.Notification{
+		KV:     keyentity.KV{Key: "service/risk/flag", Status: keyentity.PlacedKey},
+		Actor:  1,
+		Reason: "ping <!channel>",
+		Ownership: keyentity.Ownership{
+			Owners: []keyentity.Owner{
+				{Kind: keyentity.OwnerKindUser, Name: "alice"},
+				{Kind: keyentity.OwnerKindTeam, Name: "risk"},
+				{Kind: keyentity.OwnerKindUser, Name: "bob"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	for _, want := range []string{"Owners: <@U024BE7LH> <!subteam^SAZ94GDB8> bob", "Reason: ping &lt;!channel&gt;"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("text = %q, want it to contain %q", text, want)
+		}
+	}
+}
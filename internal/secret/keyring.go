@@ -0,0 +1,211 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/marde12345/key-flag/internal/config"
+)
+
+// prefix of envelope encrypted value, enc:v2:<key id>:<wrapped data key>:<ciphertext>.
+// Ciphertext is bound to the key name.
+const envelopePrefix = "enc:v2:"
+
+const keySize = 32
+
+// Keyring encrypt values with a random data key per value, the data key is wrapped by the current key encryption key.
+// Old key encryption keys stay in the keyring to decrypt values until they are rewrapped.
+type Keyring struct {
+	currentID    string
+	keys         map[string][]byte
+	encryptCache bool
+}
+
+func NewKeyring(cfg config.Secret) (*Keyring, error) {
+	if cfg.CurrentKeyID == "" {
+		return nil, errors.New("Current secret key id is required.")
+	}
+
+	keys := make(map[string][]byte, len(cfg.Keys))
+	for id, encoded := range cfg.Keys {
+		if strings.Contains(id, ":") {
+			return nil, fmt.Errorf("Secret key id %s must not contain colon.", id)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("Secret key %s is not base64.", id)
+		}
+
+		if len(key) != keySize {
+			return nil, fmt.Errorf("Secret key %s must be %d bytes.", id, keySize)
+		}
+
+		keys[id] = key
+	}
+
+	if _, ok := keys[cfg.CurrentKeyID]; !ok {
+		return nil, fmt.Errorf("Secret key %s is not configured.", cfg.CurrentKeyID)
+	}
+
+	return &Keyring{
+		currentID:    cfg.CurrentKeyID,
+		keys:         keys,
+		encryptCache: cfg.EncryptCache,
+	}, nil
+}
+
+// EncryptCache tells if secret values are kept encrypted in redis and consul
+func (k *Keyring) EncryptCache() bool {
+	return k.encryptCache
+}
+
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, envelopePrefix)
+}
+
+// Encrypt value of the key, the ciphertext only decrypts under the same key name
+// so it can not be copied to another key
+func (k *Keyring) Encrypt(key, plaintext string) (string, error) {
+	dataKey := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(dataKey, []byte(plaintext), []byte(key))
+	if err != nil {
+		return "", err
+	}
+
+	wrappedKey, err := seal(k.keys[k.currentID], dataKey, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return envelopePrefix + k.currentID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt value of the key, it fails when the value was encrypted for another key
+func (k *Keyring) Decrypt(key, value string) (string, error) {
+	keyID, wrappedKey, ciphertext, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := k.unwrap(keyID, wrappedKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(dataKey, ciphertext, []byte(key))
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// Rewrap wrap data key of the value with the current key encryption key, the ciphertext is kept as is.
+// It returns false when the value is already wrapped by the current key.
+func (k *Keyring) Rewrap(key, value string) (string, bool, error) {
+	keyID, wrappedKey, ciphertext, err := parse(value)
+	if err != nil {
+		return "", false, err
+	}
+
+	if keyID == k.currentID {
+		return value, false, nil
+	}
+
+	dataKey, err := k.unwrap(keyID, wrappedKey)
+	if err != nil {
+		return "", false, err
+	}
+
+	rewrapped, err := seal(k.keys[k.currentID], dataKey, nil)
+	if err != nil {
+		return "", false, err
+	}
+
+	return envelopePrefix + k.currentID + ":" +
+		base64.RawStdEncoding.EncodeToString(rewrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), true, nil
+}
+
+func (k *Keyring) unwrap(keyID string, wrappedKey []byte) ([]byte, error) {
+	kek, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("Secret key %s is not configured.", keyID)
+	}
+
+	return open(kek, wrappedKey, nil)
+}
+
+func parse(value string) (string, []byte, []byte, error) {
+	if !IsEncrypted(value) {
+		return "", nil, nil, errors.New("Value is not encrypted.")
+	}
+
+	parts := strings.Split(value[len(envelopePrefix):], ":")
+	if len(parts) != 3 {
+		return "", nil, nil, errors.New("Malformed encrypted value.")
+	}
+
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, errors.New("Malformed encrypted value.")
+	}
+
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, errors.New("Malformed encrypted value.")
+	}
+
+	return parts[0], wrappedKey, ciphertext, nil
+}
+
+// seal encrypt with aes-gcm authenticating additionalData too, nonce is prepended to the ciphertext
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(key, sealed, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("Malformed encrypted value.")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,82 @@
+package secret
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/marde12345/key-flag/internal/config"
+)
+
+func newTestKeyring(t *testing.T, currentID string) *Keyring {
+	t.Helper()
+
+	keyring, err := NewKeyring(config.Secret{
+		CurrentKeyID: currentID,
+		Keys: map[string]string{
+			"k1": base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", keySize))),
+			"k2": base64.StdEncoding.EncodeToString([]byte(strings.Repeat("b", keySize))),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keyring
+}
+
+func TestKeyringBindValueToKey(t *testing.T) {
+	keyring := newTestKeyring(t, "k1")
+
+	value, err := keyring.Encrypt("service/payment/password", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plaintext, err := keyring.Decrypt("service/payment/password", value); err != nil || plaintext != "hunter2" {
+		t.Fatalf("Decrypt() = %q, %v, want hunter2", plaintext, err)
+	}
+	if _, err := keyring.Decrypt("service/risk/token", value); err == nil {
+		t.Fatal("Decrypt() of value copied to another key error = nil")
+	}
+}
+
+func TestKeyringRewrap(t *testing.T) {
+	old := newTestKeyring(t, "k1")
+	current := newTestKeyring(t, "k2")
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "v2 wrapped by old key", value: func() string {
+			value, err := old.Encrypt("service/payment/password", "hunter2")
+			if err != nil {
+				t.Fatal(err)
+			}
+			return value
+		}()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rewrapped, changed, err := current.Rewrap("service/payment/password", tt.value)
+			if err != nil || !changed {
+				t.Fatalf("Rewrap() changed = %v, error = %v", changed, err)
+			}
+			if !strings.HasPrefix(rewrapped, envelopePrefix+"k2:") {
+				t.Fatalf("Rewrap() = %q, want v2 wrapped by k2", rewrapped)
+			}
+			if plaintext, err := current.Decrypt("service/payment/password", rewrapped); err != nil || plaintext != "hunter2" {
+				t.Fatalf("Decrypt() = %q, %v, want hunter2", plaintext, err)
+			}
+			if _, err := current.Decrypt("service/risk/token", rewrapped); err == nil {
+				t.Fatal("Decrypt() of rewrapped value under another key error = nil")
+			}
+
+			if _, changed, _ := current.Rewrap("service/payment/password", rewrapped); changed {
+				t.Fatal("Rewrap() of current value changed it again")
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/marde12345/key-flag"
+
+// Setup install tracer provider exporting spans with the exporter, e.g. otlp in production
+// or tracetest.NewInMemoryExporter in tests. Shutdown the provider to flush remaining spans.
+func Setup(exporter sdktrace.SpanExporter) *sdktrace.TracerProvider {
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider
+}
+
+// Start span as child of the span in ctx
+func Start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name)
+}
+
+// RecordError mark span as failed, sql.ErrNoRows is expected by the usecases and not an error
+func RecordError(span trace.Span, err error) {
+	if err == nil || errors.Is(err, sql.ErrNoRows) {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// BeginTx begin transaction with begin inside a span lasting until the transaction is committed or rolled back.
+// The returned end rolls back the transaction when it was not committed and ends the span, only its first call counts.
+// Defer end right away and call it right after commit when more work follows.
+func BeginTx(ctx context.Context, name string, begin func(context.Context) (*sql.Tx, error)) (*sql.Tx, func(), error) {
+	ctx, span := Start(ctx, name)
+
+	tx, err := begin(ctx)
+	if err != nil {
+		RecordError(span, err)
+		span.End()
+		return nil, nil, err
+	}
+
+	var once sync.Once
+	return tx, func() {
+		once.Do(func() {
+			// ErrTxDone means the transaction was committed
+			err := tx.Rollback()
+			if err == nil {
+				span.SetAttributes(attribute.Bool("db.rollback", true))
+			} else if !errors.Is(err, sql.ErrTxDone) {
+				RecordError(span, err)
+			}
+			span.End()
+		})
+	}, nil
+}
+
+// TraceID returns trace id of the span in ctx to be included in logs, empty when there is no span
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.TraceID().IsValid() {
+		return ""
+	}
+
+	return spanContext.TraceID().String()
+}
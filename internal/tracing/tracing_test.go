@@ -0,0 +1,156 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// setupInMemory install provider exporting to memory, the returned func flush and returns recorded spans
+func setupInMemory(t *testing.T) func() tracetest.SpanStubs {
+	t.Helper()
+
+	previous := otel.GetTracerProvider()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := Setup(exporter)
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+		otel.SetTracerProvider(previous)
+	})
+
+	return func() tracetest.SpanStubs {
+		if err := provider.ForceFlush(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		return exporter.GetSpans()
+	}
+}
+
+func TestStartChildSpan(t *testing.T) {
+	spansOf := setupInMemory(t)
+
+	ctx, parent := Start(context.Background(), "parent")
+	if TraceID(ctx) == "" {
+		t.Fatal("TraceID() is empty inside a span")
+	}
+	_, child := Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	spans := spansOf()
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want 2", len(spans))
+	}
+	if spans[0].Name != "child" || spans[0].Parent.SpanID() != spans[1].SpanContext.SpanID() {
+		t.Fatalf("child span %q is not a child of %q", spans[0].Name, spans[1].Name)
+	}
+	if spans[0].SpanContext.TraceID().String() != TraceID(ctx) {
+		t.Fatal("child span is not in the trace of the parent")
+	}
+}
+
+func TestRecordError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status codes.Code
+	}{
+		{name: "nil", err: nil, status: codes.Unset},
+		{name: "no rows", err: sql.ErrNoRows, status: codes.Unset},
+		{name: "wrapped no rows", err: errors.Join(errors.New("get key"), sql.ErrNoRows), status: codes.Unset},
+		{name: "error", err: errors.New("connection refused"), status: codes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spansOf := setupInMemory(t)
+
+			_, span := Start(context.Background(), "repository")
+			RecordError(span, tt.err)
+			span.End()
+			spans := spansOf()
+			if len(spans) != 1 {
+				t.Fatalf("spans = %d, want 1", len(spans))
+			}
+			if spans[0].Status.Code != tt.status {
+				t.Fatalf("status = %v, want %v", spans[0].Status.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestBeginTx(t *testing.T) {
+	tests := []struct {
+		name     string
+		commit   bool
+		rollback bool
+	}{
+		{name: "committed", commit: true},
+		{name: "rolled back", rollback: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spansOf := setupInMemory(t)
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { db.Close() })
+
+			mock.ExpectBegin()
+			if tt.commit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			ctx, parent := Start(context.Background(), "usecase")
+			tx, end, err := BeginTx(ctx, "tx", func(ctx context.Context) (*sql.Tx, error) {
+				return db.BeginTx(ctx, nil)
+			})
+			if err != nil {
+				t.Fatalf("BeginTx() error = %v", err)
+			}
+			if tt.commit {
+				if err := tx.Commit(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			end()
+			// the deferred end after commit does nothing
+			end()
+			parent.End()
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+			spans := spansOf()
+			if len(spans) != 2 || spans[0].Name != "tx" || spans[0].Parent.SpanID() != spans[1].SpanContext.SpanID() {
+				t.Fatalf("spans = %+v, want tx span inside usecase span", spans)
+			}
+			rollback := false
+			for _, attr := range spans[0].Attributes {
+				if attr == attribute.Bool("db.rollback", true) {
+					rollback = true
+				}
+			}
+			if rollback != tt.rollback {
+				t.Fatalf("rollback attribute = %v, want %v", rollback, tt.rollback)
+			}
+		})
+	}
+}
+
+func TestTraceIDWithoutSpan(t *testing.T) {
+	if got := TraceID(context.Background()); got != "" {
+		t.Fatalf("TraceID() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,75 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/util"
+)
+
+// authorize check if user has at least permission on the key or prefix
+func (u *Usecase) authorize(ctx context.Context, userID int, prefix, permission string) error {
+	roles, err := u.userRepo.GetUserAccess(ctx, userID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if !userentity.HasPermission(roles, prefix, permission) {
+		return userentity.ErrForbidden
+	}
+
+	return nil
+}
+
+// underPrefix keep keys inside the prefix path, prefix lookup of the repository also returns
+// siblings sharing the name start, e.g. service/risk/sauron2 for service/risk/sauron
+func underPrefix(kvs []keyentity.KV, prefix string) []keyentity.KV {
+	result := make([]keyentity.KV, 0, len(kvs))
+	for _, kv := range kvs {
+		if util.IsUnderPrefix(kv.Key, prefix) {
+			result = append(result, kv)
+		}
+	}
+
+	return result
+}
+
+// checkQuota make sure new keys still fit in the quota of their namespace
+func (u *Usecase) checkQuota(ctx context.Context, keys []string) error {
+	namespaces, err := u.userRepo.GetNamespaces(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	newKeys := make(map[string]int)
+	quotas := make(map[string]userentity.Namespace)
+	for _, key := range keys {
+		namespace, ok := userentity.FindNamespace(namespaces, key)
+		if !ok {
+			return fmt.Errorf("Key %s is outside of any namespace.", key)
+		}
+
+		if namespace.KeyQuota <= 0 || u.keyRepo.IsKeyExist(ctx, key) {
+			continue
+		}
+
+		newKeys[namespace.Root]++
+		quotas[namespace.Root] = namespace
+	}
+
+	for root, count := range newKeys {
+		existing, err := u.keyRepo.CountKeyByPrefix(ctx, root)
+		if err != nil {
+			return err
+		}
+
+		if existing+count > quotas[root].KeyQuota {
+			return fmt.Errorf("Namespace %s exceed key quota of %d.", quotas[root].Name, quotas[root].KeyQuota)
+		}
+	}
+
+	return nil
+}
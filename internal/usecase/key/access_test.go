@@ -0,0 +1,81 @@
+package key
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+func TestCheckQuota(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []string
+		existing map[string]bool
+		count    int
+		wantErr  bool
+	}{
+		{
+			name:  "new keys fit",
+			keys:  []string{"payment/flag", "payment/limit"},
+			count: 1,
+		},
+		{
+			name:    "new keys exceed",
+			keys:    []string{"payment/flag", "payment/limit"},
+			count:   2,
+			wantErr: true,
+		},
+		{
+			name:     "existing key does not count",
+			keys:     []string{"payment/flag", "payment/limit"},
+			existing: map[string]bool{"payment/flag": true},
+			count:    2,
+		},
+		{
+			name: "unlimited namespace",
+			keys: []string{"service/risk/flag"},
+		},
+		{
+			name:    "outside of any namespace",
+			keys:    []string{"infra/flag"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			deps.namespaces = append(deps.namespaces, userentity.Namespace{ID: 2, Name: "payment", Root: "payment", KeyQuota: 3})
+			deps.keyRepo.EXPECT().IsKeyExist(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, key string) bool {
+				return tt.existing[key]
+			}).AnyTimes()
+			deps.keyRepo.EXPECT().CountKeyByPrefix(gomock.Any(), "payment").Return(tt.count, nil).AnyTimes()
+
+			err := u.checkQuota(context.Background(), tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkQuota() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthorizeNamespace(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	deps.namespaces = append(deps.namespaces, userentity.Namespace{ID: 2, Name: "payment", Root: "payment"})
+	deps.access[testLead] = []userentity.Role{{ID: 4, Prefix: "payment", Permission: userentity.RoleAdmin}}
+
+	// admin of a namespace has no access to the default one and the other way around
+	if err := u.authorize(context.Background(), testLead, "service/risk/flag", userentity.RoleUser); !errors.Is(err, userentity.ErrForbidden) {
+		t.Fatalf("authorize() of payment admin in service error = %v, want ErrForbidden", err)
+	}
+	if err := u.authorize(context.Background(), testAdmin, "payment/flag", userentity.RoleUser); !errors.Is(err, userentity.ErrForbidden) {
+		t.Fatalf("authorize() of service admin in payment error = %v, want ErrForbidden", err)
+	}
+	if err := u.authorize(context.Background(), testLead, "payment/flag", userentity.RoleLead); err != nil {
+		t.Fatalf("authorize() of payment admin in payment error = %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+// AmendPlacedKey replace value of the placed key in place, so its justification and comments stay on it.
+// Previous value is kept in history as amended row and the key waits for approval from the start.
+func (u *Usecase) AmendPlacedKey(ctx context.Context, kv keyentity.KV, userID int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.AmendPlacedKey", u.timeout.Write)
+	defer finish()
+
+	placedKey, err := u.placedKey(ctx, kv.Key)
+	if err != nil {
+		return err
+	}
+
+	if placedKey.Status == keyentity.PlacedDeleteKey {
+		return errors.New("Placed delete can only be withdrawn.")
+	}
+
+	if err := u.authorizeAuthor(ctx, userID, placedKey); err != nil {
+		return err
+	}
+
+	if kv.Type == "" {
+		kv.Type = placedKey.Type
+	}
+
+	if err := validateValue(kv); err != nil {
+		return err
+	}
+
+	if err := u.checkQuota(ctx, []string{placedKey.Key}); err != nil {
+		return err
+	}
+
+	if err := u.validatePrerequisites(ctx, kv, false); err != nil {
+		return err
+	}
+
+	amendedKey, err := u.encryptSecret(keyentity.KV{
+		Key:         placedKey.Key,
+		Value:       kv.Value,
+		Type:        kv.Type,
+		CreatedBy:   userID,
+		Status:      keyentity.PlacedKey,
+		ChangeSetID: placedKey.ChangeSetID,
+		Environment: placedKey.Environment,
+	})
+	if err != nil {
+		return err
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer endTx()
+
+	previousKey := placedKey
+	previousKey.Status = keyentity.AmendedKey
+	previousKey.UpdateTime = time.Now()
+	if err := u.keyRepo.CreateKeyEntry(ctx, tx, previousKey); err != nil {
+		return err
+	}
+
+	amendedKey.ID = placedKey.ID
+	amendedKey.UpdateTime = previousKey.UpdateTime
+	if err := u.keyRepo.ModifyKey(ctx, tx, placedKey.ID, amendedKey); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	u.logTransition(ctx, previousKey, userID)
+	u.logTransition(ctx, amendedKey, userID)
+	u.notify(ctx, amendedKey, userID, "")
+	return nil
+}
+
+// WithdrawPlacedKey take back placed or placed delete key before it is approved
+func (u *Usecase) WithdrawPlacedKey(ctx context.Context, key string, userID int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.WithdrawPlacedKey", u.timeout.Write)
+	defer finish()
+
+	placedKey, err := u.placedKey(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if err := u.authorizeAuthor(ctx, userID, placedKey); err != nil {
+		return err
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer endTx()
+
+	placedKey.Status = keyentity.WithdrawnKey
+	placedKey.UpdateTime = time.Now()
+	if err := u.keyRepo.ModifyKey(ctx, tx, placedKey.ID, placedKey); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	u.logTransition(ctx, placedKey, userID)
+	return nil
+}
+
+// placedKey returns placed or placed delete row of the key
+func (u *Usecase) placedKey(ctx context.Context, key string) (keyentity.KV, error) {
+	for _, status := range []int{keyentity.PlacedKey, keyentity.PlacedDeleteKey} {
+		keys, err := u.keyRepo.GetKey(ctx, key, status)
+		if err != nil && err != sql.ErrNoRows {
+			return keyentity.KV{}, err
+		}
+
+		if len(keys) > 0 {
+			return keys[0], nil
+		}
+	}
+
+	return keyentity.KV{}, errors.New("No placed key found.")
+}
+
+// authorizeAuthor allow the author of the row or lead of the key
+func (u *Usecase) authorizeAuthor(ctx context.Context, userID int, kv keyentity.KV) error {
+	if kv.CreatedBy == userID {
+		return u.authorize(ctx, userID, kv.Key, userentity.RoleUser)
+	}
+
+	return u.authorize(ctx, userID, kv.Key, userentity.RoleLead)
+}
@@ -0,0 +1,167 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+func TestAmendPlacedKeyCheckQuota(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	// placed while the quota was higher
+	deps.expectKeys("service/risk/b", map[int][]keyentity.KV{
+		keyentity.PlacedKey: {{ID: 2, Key: "service/risk/b", Value: "true", CreatedBy: testUser, Status: keyentity.PlacedKey}},
+	})
+	deps.namespaces[0].KeyQuota = 1
+	deps.keyRepo.EXPECT().IsKeyExist(gomock.Any(), "service/risk/b").Return(false)
+	deps.keyRepo.EXPECT().CountKeyByPrefix(gomock.Any(), "service").Return(1, nil)
+
+	err := u.AmendPlacedKey(context.Background(), keyentity.KV{Key: "service/risk/b", Value: "false"}, testUser)
+	if err == nil || !strings.Contains(err.Error(), "quota") {
+		t.Fatalf("AmendPlacedKey() error = %v, want quota error", err)
+	}
+}
+
+func TestCreateDeleteKeyPendingChange(t *testing.T) {
+	for _, status := range []int{keyentity.PlacedKey, keyentity.PlacedDeleteKey} {
+		u, deps := newTestUsecase(t)
+		deps.expectKeys("service/risk/a", map[int][]keyentity.KV{
+			status: {{ID: 1, Key: "service/risk/a", Value: "true", Status: status}},
+		})
+
+		err := u.CreateDeleteKey(context.Background(), keyentity.KV{Key: "service/risk/a", CreatedBy: testUser}, "")
+		if !errors.Is(err, keyentity.ErrPendingChange) {
+			t.Fatalf("CreateDeleteKey() with status %d error = %v, want ErrPendingChange", status, err)
+		}
+	}
+}
+
+func TestUpdateKeyConcurrentPendingChange(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	deps.expectNoPrerequisite()
+	deps.expectKeys("service/risk/a", nil)
+
+	// another request placed the key after the pending check, the unique index rejects this one
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().CreateKey(gomock.Any(), gomock.Any(), "service/risk/a", "true", gomock.Any(), testUser, keyentity.PlacedKey).
+		Return(0, keyentity.ErrPendingChange)
+	deps.db.ExpectRollback()
+
+	err := u.UpdateKey(context.Background(), keyentity.KV{Key: "service/risk/a", Value: "true", CreatedBy: testUser}, 0, "")
+	if !errors.Is(err, keyentity.ErrPendingChange) {
+		t.Fatalf("UpdateKey() error = %v, want ErrPendingChange", err)
+	}
+	if err := deps.db.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateKeyStaleBase(t *testing.T) {
+	active := map[int][]keyentity.KV{
+		keyentity.ApprovedAndActive: {{ID: 5, Key: "service/risk/a", Value: "false", Status: keyentity.ApprovedAndActive}},
+	}
+
+	tests := []struct {
+		name    string
+		rows    map[int][]keyentity.KV
+		baseID  int
+		wantErr error
+	}{
+		{name: "base is active", rows: active, baseID: 5},
+		{name: "base no longer active", rows: active, baseID: 4, wantErr: keyentity.ErrStaleBase},
+		{name: "new key", baseID: 0},
+		{name: "base of key without active value", baseID: 4, wantErr: keyentity.ErrStaleBase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			deps.expectNoPrerequisite()
+			deps.expectKeys("service/risk/a", tt.rows)
+			if tt.wantErr == nil {
+				deps.db.ExpectBegin()
+				deps.keyRepo.EXPECT().CreateKey(gomock.Any(), gomock.Any(), "service/risk/a", "true", gomock.Any(), testUser, keyentity.PlacedKey).Return(6, nil)
+				deps.db.ExpectCommit()
+			}
+
+			err := u.UpdateKey(context.Background(), keyentity.KV{Key: "service/risk/a", Value: "true", CreatedBy: testUser}, tt.baseID, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateKey() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAmendPlacedKeyKeepComments(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	deps.expectNoPrerequisite()
+	deps.expectKeys("service/risk/a", map[int][]keyentity.KV{
+		keyentity.PlacedKey: {{ID: 7, Key: "service/risk/a", Value: "true", CreatedBy: testUser, Status: keyentity.PlacedKey, ChangeSetID: 3}},
+	})
+
+	// placed row keeps its id with the new value, so the justification and comments on it are not left behind
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().CreateKeyEntry(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, tx *sql.Tx, kv keyentity.KV) error {
+		if kv.Status != keyentity.AmendedKey || kv.Value != "true" || kv.CreatedBy != testUser {
+			t.Fatalf("history row = %+v, want previous value as amended", kv)
+		}
+		return nil
+	})
+	deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), 7, gomock.Any()).DoAndReturn(func(ctx context.Context, tx *sql.Tx, keyID int, kv keyentity.KV) error {
+		if kv.Status != keyentity.PlacedKey || kv.Value != "false" || kv.ChangeSetID != 3 {
+			t.Fatalf("placed row = %+v, want new value still placed in change set 3", kv)
+		}
+		return nil
+	})
+	deps.db.ExpectCommit()
+
+	if err := u.AmendPlacedKey(context.Background(), keyentity.KV{Key: "service/risk/a", Value: "false"}, testUser); err != nil {
+		t.Fatalf("AmendPlacedKey() error = %v", err)
+	}
+}
+
+func TestWithdrawPlacedKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		author  int
+		userID  int
+		wantErr error
+	}{
+		{name: "author", author: testUser, userID: testUser},
+		{name: "lead", author: testUser, userID: testLead},
+		{name: "another user", author: testLead, userID: testUser, wantErr: userentity.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			deps.expectKeys("service/risk/a", map[int][]keyentity.KV{
+				keyentity.PlacedKey: {{ID: 7, Key: "service/risk/a", Value: "true", CreatedBy: tt.author, Status: keyentity.PlacedKey}},
+			})
+
+			// withdrawn row keeps its id, so the justification and comments stay on it
+			if tt.wantErr == nil {
+				deps.db.ExpectBegin()
+				deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), 7, gomock.Any()).DoAndReturn(func(ctx context.Context, tx *sql.Tx, keyID int, kv keyentity.KV) error {
+					if kv.Status != keyentity.WithdrawnKey || kv.ID != 7 || kv.Value != "true" || kv.CreatedBy != tt.author {
+						t.Fatalf("withdrawn row = %+v, want row 7 withdrawn as is", kv)
+					}
+					return nil
+				})
+				deps.db.ExpectCommit()
+			}
+
+			err := u.WithdrawPlacedKey(context.Background(), "service/risk/a", tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WithdrawPlacedKey() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
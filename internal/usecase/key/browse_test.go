@@ -0,0 +1,44 @@
+package key
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+func TestBrowseKeysSeparatorPage(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	opts := keyentity.BrowseOptions{Separator: "/", Limit: 2}
+
+	// one more node than the limit tells there is a next page
+	deps.keyRepo.EXPECT().GetKeyChildrenPage(gomock.Any(), "service/risk/", "/", "", 3).Return([]keyentity.BrowseNode{
+		{Name: "service/risk/flag"},
+		{Name: "service/risk/sauron/", IsDir: true, Count: 12},
+		{Name: "service/risk/shield/", IsDir: true, Count: 3},
+	}, nil)
+	deps.keyRepo.EXPECT().GetKeyChildrenPage(gomock.Any(), "service/risk/", "/", "service/risk/sauron/", 3).Return([]keyentity.BrowseNode{
+		{Name: "service/risk/shield/", IsDir: true, Count: 3},
+	}, nil)
+	// metadata is only looked up for keys
+	deps.keyRepo.EXPECT().GetKeyMetadata(gomock.Any(), []string{"service/risk/flag"}).Return(nil, nil)
+
+	page, err := u.BrowseKeys(context.Background(), "service/risk/", opts, testUser)
+	if err != nil {
+		t.Fatalf("BrowseKeys() error = %v", err)
+	}
+	if len(page.Nodes) != 2 || page.Nodes[1].Count != 12 || page.NextCursor == "" {
+		t.Fatalf("first page = %+v, want flag and sauron with next cursor", page)
+	}
+
+	opts.Cursor = page.NextCursor
+	page, err = u.BrowseKeys(context.Background(), "service/risk/", opts, testUser)
+	if err != nil {
+		t.Fatalf("BrowseKeys() error = %v", err)
+	}
+	if len(page.Nodes) != 1 || page.Nodes[0].Name != "service/risk/shield/" || page.NextCursor != "" {
+		t.Fatalf("last page = %+v, want only shield", page)
+	}
+}
@@ -0,0 +1,265 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/util"
+)
+
+// RegisterCanaryGroup create named canary group for the service, nodes can only join registered group
+func (u *Usecase) RegisterCanaryGroup(ctx context.Context, servicePrefix, group string, userID int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.RegisterCanaryGroup", u.timeout.Write)
+	defer finish()
+
+	if group == "" {
+		return errors.New("Canary group name is required.")
+	}
+
+	if err := u.authorize(ctx, userID, servicePrefix, userentity.RoleLead); err != nil {
+		return err
+	}
+
+	service, err := u.getService(ctx, servicePrefix)
+	if err != nil {
+		return err
+	}
+
+	if service.Prefix != servicePrefix {
+		return fmt.Errorf("Service %s is not found.", servicePrefix)
+	}
+
+	return u.keyRepo.CreateCanaryGroup(ctx, keyentity.CanaryGroup{
+		ServiceID: service.ID,
+		Name:      group,
+		CreatedBy: userID,
+	})
+}
+
+// HeartbeatCanaryTarget register the node into the canary group until the ttl passed,
+// nodes should call it periodically to stay registered. The user, usually service account of the node, must have access to the service.
+func (u *Usecase) HeartbeatCanaryTarget(ctx context.Context, servicePrefix string, target keyentity.CanaryTarget, ttl time.Duration, userID int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.HeartbeatCanaryTarget", u.timeout.Write)
+	defer finish()
+
+	if target.NodeID == "" {
+		return errors.New("Node id is required.")
+	}
+
+	ip := net.ParseIP(target.IP)
+	if ip == nil {
+		return fmt.Errorf("Invalid ip %s.", target.IP)
+	}
+	target.IP = ip.String()
+
+	if ttl <= 0 {
+		ttl = keyentity.DefaultCanaryTargetTTL
+	}
+	if ttl > keyentity.MaxCanaryTargetTTL {
+		ttl = keyentity.MaxCanaryTargetTTL
+	}
+
+	service, err := u.getService(ctx, servicePrefix)
+	if err != nil {
+		return err
+	}
+
+	if err := u.authorize(ctx, userID, service.Prefix, userentity.RoleUser); err != nil {
+		return err
+	}
+
+	if _, err := u.getCanaryGroup(ctx, service.ID, target.Group); err != nil {
+		return err
+	}
+
+	target.ServiceID = service.ID
+	target.ExpiresAt = time.Now().Add(ttl)
+
+	return u.keyRepo.SetCanaryTarget(ctx, target, ttl)
+}
+
+// DeregisterCanaryTarget remove the node from canary group before its ttl passed, e.g. on shutdown
+func (u *Usecase) DeregisterCanaryTarget(ctx context.Context, servicePrefix string, target keyentity.CanaryTarget, userID int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.DeregisterCanaryTarget", u.timeout.Write)
+	defer finish()
+
+	service, err := u.getService(ctx, servicePrefix)
+	if err != nil {
+		return err
+	}
+
+	if err := u.authorize(ctx, userID, service.Prefix, userentity.RoleUser); err != nil {
+		return err
+	}
+
+	target.ServiceID = service.ID
+	return u.keyRepo.DeleteCanaryTarget(ctx, target)
+}
+
+// GetKeyCanaryIP returns current canary ip of the key and live targets registered by the service owning the key
+func (u *Usecase) GetKeyCanaryIP(ctx context.Context, id, userID int) ([]string, []keyentity.CanaryTarget, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetKeyCanaryIP", u.timeout.Read)
+	defer finish()
+	var canaryIPs []string
+	var recommendedTargets []keyentity.CanaryTarget
+
+	requestedKey, err := u.keyRepo.GetKeyByID(ctx, id)
+	if err != nil && err != sql.ErrNoRows {
+		return canaryIPs, recommendedTargets, err
+	}
+
+	if requestedKey.Key == "" {
+		return canaryIPs, recommendedTargets, nil
+	}
+
+	if err := u.authorize(ctx, userID, requestedKey.Key, userentity.RoleUser); err != nil {
+		return canaryIPs, recommendedTargets, err
+	}
+
+	// Get current canary IP from db
+	canaryKVs, err := u.keyRepo.GetCanaryKVByID(ctx, id)
+	if err != nil && err != sql.ErrNoRows {
+		return canaryIPs, recommendedTargets, err
+	}
+	for _, ckv := range canaryKVs {
+		// group target has no ip
+		if ckv.IP != "" {
+			canaryIPs = append(canaryIPs, ckv.IP)
+		}
+	}
+
+	service, err := u.getService(ctx, requestedKey.Key)
+	if err != nil {
+		return canaryIPs, recommendedTargets, err
+	}
+
+	groups, err := u.keyRepo.GetCanaryGroups(ctx, service.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return canaryIPs, recommendedTargets, err
+	}
+
+	for _, group := range groups {
+		targets, err := u.keyRepo.GetCanaryTargets(ctx, service.ID, group.Name)
+		if err != nil && err != sql.ErrNoRows {
+			return canaryIPs, recommendedTargets, err
+		}
+
+		recommendedTargets = append(recommendedTargets, targets...)
+	}
+
+	return canaryIPs, recommendedTargets, nil
+}
+
+// ApproveKeyCanaryGroup put the key in canary for the group, nodes reading with the group get the canary value
+// even when they join later. Live nodes of the group are targeted by ip too, for nodes reading without their group.
+func (u *Usecase) ApproveKeyCanaryGroup(ctx context.Context, key string, userID, status int, group string) error {
+	ctx, finish := u.start(ctx, "key.Usecase.ApproveKeyCanaryGroup", u.timeout.Write)
+	defer finish()
+
+	service, err := u.getService(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.getCanaryGroup(ctx, service.ID, group); err != nil {
+		return err
+	}
+
+	targets, err := u.keyRepo.GetCanaryTargets(ctx, service.ID, group)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	nodesIP := make([]string, 0, len(targets))
+	for _, target := range targets {
+		nodesIP = append(nodesIP, target.IP)
+	}
+
+	if len(nodesIP) > 0 {
+		if nodesIP, err = normalizeCanaryTargets(nodesIP); err != nil {
+			return err
+		}
+	}
+
+	return u.approveKeyCanary(ctx, key, userID, nodesIP, group)
+}
+
+// normalizeCanaryTargets validate every target as ip or cidr range, ipv4 and ipv6 are both accepted.
+// Ranges are stored by their network address so 10.1.2.3/16 and 10.1.0.0/16 are the same target.
+func normalizeCanaryTargets(targets []string) ([]string, error) {
+	if len(targets) == 0 {
+		return nil, errors.New("Canary target is required.")
+	}
+
+	seen := make(map[string]bool, len(targets))
+	result := make([]string, 0, len(targets))
+	for _, target := range targets {
+		target = strings.TrimSpace(target)
+
+		var normalized string
+		if strings.Contains(target, "/") {
+			_, ipNet, err := net.ParseCIDR(target)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid canary target %s.", target)
+			}
+			normalized = ipNet.String()
+		} else {
+			ip := net.ParseIP(target)
+			if ip == nil {
+				return nil, fmt.Errorf("Invalid canary target %s.", target)
+			}
+			normalized = ip.String()
+		}
+
+		if seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		result = append(result, normalized)
+	}
+
+	return result, nil
+}
+
+// getService returns service owning the key, the one with longest prefix wins
+func (u *Usecase) getService(ctx context.Context, key string) (keyentity.Service, error) {
+	services, err := u.keyRepo.GetServices(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return keyentity.Service{}, err
+	}
+
+	var found keyentity.Service
+	for _, service := range services {
+		if util.IsUnderPrefix(key, service.Prefix) && len(service.Prefix) > len(found.Prefix) {
+			found = service
+		}
+	}
+
+	if found.ID == 0 {
+		return keyentity.Service{}, fmt.Errorf("No service owns %s.", key)
+	}
+
+	return found, nil
+}
+
+func (u *Usecase) getCanaryGroup(ctx context.Context, serviceID int, name string) (keyentity.CanaryGroup, error) {
+	groups, err := u.keyRepo.GetCanaryGroups(ctx, serviceID)
+	if err != nil && err != sql.ErrNoRows {
+		return keyentity.CanaryGroup{}, err
+	}
+
+	for _, group := range groups {
+		if group.Name == name {
+			return group, nil
+		}
+	}
+
+	return keyentity.CanaryGroup{}, fmt.Errorf("Canary group %s is not registered.", name)
+}
@@ -0,0 +1,140 @@
+package key
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+var canaryTestServices = []keyentity.Service{
+	{ID: 1, Tribe: "risk", Name: "risk", Prefix: "service/risk"},
+	{ID: 2, Tribe: "risk", Name: "sauron", Prefix: "service/risk/sauron"},
+}
+
+// expectCanaryRegistry register group blue for service/risk/sauron
+func expectCanaryRegistry(deps *testDeps) {
+	deps.keyRepo.EXPECT().GetServices(gomock.Any()).Return(canaryTestServices, nil).AnyTimes()
+	deps.keyRepo.EXPECT().GetCanaryGroups(gomock.Any(), 2).Return([]keyentity.CanaryGroup{{ID: 1, ServiceID: 2, Name: "blue"}}, nil).AnyTimes()
+}
+
+func TestRegisterCanaryGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		group   string
+		userID  int
+		wantErr bool
+	}{
+		{name: "lead of the service", prefix: "service/risk/sauron", group: "green", userID: testLead},
+		{name: "user of the service", prefix: "service/risk/sauron", group: "green", userID: testUser, wantErr: true},
+		{name: "prefix inside the service", prefix: "service/risk/sauron/api", group: "green", userID: testLead, wantErr: true},
+		{name: "empty group", prefix: "service/risk/sauron", userID: testLead, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			expectCanaryRegistry(deps)
+			if !tt.wantErr {
+				deps.keyRepo.EXPECT().CreateCanaryGroup(gomock.Any(), keyentity.CanaryGroup{ServiceID: 2, Name: tt.group, CreatedBy: tt.userID}).Return(nil)
+			}
+
+			err := u.RegisterCanaryGroup(context.Background(), tt.prefix, tt.group, tt.userID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RegisterCanaryGroup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHeartbeatCanaryTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  keyentity.CanaryTarget
+		ttl     time.Duration
+		wantIP  string
+		wantTTL time.Duration
+		wantErr bool
+	}{
+		{
+			name:    "default ttl",
+			target:  keyentity.CanaryTarget{NodeID: "node-1", Group: "blue", IP: "10.0.0.1"},
+			wantIP:  "10.0.0.1",
+			wantTTL: keyentity.DefaultCanaryTargetTTL,
+		},
+		{
+			name:    "ttl is capped",
+			target:  keyentity.CanaryTarget{NodeID: "node-1", Group: "blue", IP: "2001:db8:0:0::1"},
+			ttl:     time.Hour,
+			wantIP:  "2001:db8::1",
+			wantTTL: keyentity.MaxCanaryTargetTTL,
+		},
+		{
+			name:    "unregistered group",
+			target:  keyentity.CanaryTarget{NodeID: "node-1", Group: "green", IP: "10.0.0.1"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid ip",
+			target:  keyentity.CanaryTarget{NodeID: "node-1", Group: "blue", IP: "10.0.0"},
+			wantErr: true,
+		},
+		{
+			name:    "missing node id",
+			target:  keyentity.CanaryTarget{Group: "blue", IP: "10.0.0.1"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			expectCanaryRegistry(deps)
+			if !tt.wantErr {
+				deps.keyRepo.EXPECT().SetCanaryTarget(gomock.Any(), gomock.Any(), tt.wantTTL).DoAndReturn(func(ctx context.Context, target keyentity.CanaryTarget, ttl time.Duration) error {
+					if target.ServiceID != 2 || target.IP != tt.wantIP || target.ExpiresAt.IsZero() {
+						t.Errorf("SetCanaryTarget() target = %+v, want ip %s of service 2", target, tt.wantIP)
+					}
+					return nil
+				})
+			}
+
+			err := u.HeartbeatCanaryTarget(context.Background(), "service/risk/sauron", tt.target, tt.ttl, testUser)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HeartbeatCanaryTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApproveKeyCanaryGroupTargetsLiveNodes(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	deps.expectNoOwnership()
+	expectCanaryRegistry(deps)
+	deps.expectKeys(canaryTestKey, map[int][]keyentity.KV{keyentity.PlacedKey: {canaryTestPlaced}})
+	deps.keyRepo.EXPECT().GetCanaryTargets(gomock.Any(), 2, "blue").Return([]keyentity.CanaryTarget{
+		{ServiceID: 2, Group: "blue", NodeID: "node-1", IP: "10.0.0.1"},
+		{ServiceID: 2, Group: "blue", NodeID: "node-2", IP: "10.0.0.2"},
+	}, nil)
+
+	// group is targeted for nodes joining later, live nodes by their ip
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().CreateCanaryKey(gomock.Any(), gomock.Any(), canaryTestPlaced.ID, "10.0.0.1").Return(nil)
+	deps.keyRepo.EXPECT().CreateCanaryKey(gomock.Any(), gomock.Any(), canaryTestPlaced.ID, "10.0.0.2").Return(nil)
+	deps.keyRepo.EXPECT().CreateCanaryGroupKey(gomock.Any(), gomock.Any(), canaryTestPlaced.ID, "blue").Return(nil)
+	deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), canaryTestPlaced.ID, gomock.Any()).Return(nil)
+	deps.db.ExpectCommit()
+	deps.keyRepo.EXPECT().InvalidateCanaryCache(gomock.Any(), canaryTestKey).Return(nil)
+
+	if err := u.ApproveKeyCanaryGroup(context.Background(), canaryTestKey, testAdmin, keyentity.CanaryKey, "blue"); err != nil {
+		t.Fatalf("ApproveKeyCanaryGroup() error = %v", err)
+	}
+
+	if err := u.ApproveKeyCanaryGroup(context.Background(), canaryTestKey, testAdmin, keyentity.CanaryKey, "green"); err == nil {
+		t.Fatal("ApproveKeyCanaryGroup() of unregistered group error = nil")
+	}
+}
@@ -0,0 +1,37 @@
+package key
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeCanaryTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "single ipv4", targets: []string{" 10.0.0.1 "}, want: []string{"10.0.0.1"}},
+		{name: "ipv4 range by network address", targets: []string{"10.1.2.3/16"}, want: []string{"10.1.0.0/16"}},
+		{name: "ipv6 shortened", targets: []string{"2001:0db8:0000::0001"}, want: []string{"2001:db8::1"}},
+		{name: "ipv6 range", targets: []string{"2001:db8::1/64"}, want: []string{"2001:db8::/64"}},
+		{name: "same range written twice", targets: []string{"10.1.2.3/16", "10.1.0.0/16", "10.0.0.1"}, want: []string{"10.1.0.0/16", "10.0.0.1"}},
+		{name: "invalid ip", targets: []string{"10.0.0"}, wantErr: true},
+		{name: "invalid range", targets: []string{"10.0.0.0/33"}, wantErr: true},
+		{name: "host name", targets: []string{"node-1.local"}, wantErr: true},
+		{name: "empty", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeCanaryTargets(tt.targets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeCanaryTargets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("normalizeCanaryTargets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
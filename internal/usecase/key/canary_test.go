@@ -0,0 +1,252 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+const canaryTestKey = "service/risk/sauron/flag"
+
+var (
+	canaryTestActive = keyentity.KV{ID: 1, Key: canaryTestKey, Value: "false", Status: keyentity.ApprovedAndActive}
+	canaryTestPlaced = keyentity.KV{ID: 2, Key: canaryTestKey, Value: "true", Status: keyentity.PlacedKey}
+	canaryTestCanary = keyentity.KV{ID: 2, Key: canaryTestKey, Value: "true", Status: keyentity.CanaryKey}
+)
+
+// expectActiveCache serve canaryTestActive from cache, GetKeys replace canary values in place so every call gets its own copy
+func expectActiveCache(deps *testDeps) {
+	deps.keyRepo.EXPECT().GetCaches(gomock.Any(), "service/risk").DoAndReturn(func(ctx context.Context, prefix string) ([]keyentity.KV, error) {
+		return []keyentity.KV{canaryTestActive}, nil
+	}).AnyTimes()
+}
+
+func getValue(t *testing.T, u *Usecase, reader keyentity.Reader) string {
+	t.Helper()
+
+	kvs, err := u.GetKeys(context.Background(), "service/risk", reader)
+	if err != nil {
+		t.Fatalf("GetKeys() error = %v", err)
+	}
+	if len(kvs) != 1 {
+		t.Fatalf("GetKeys() = %+v, want one key", kvs)
+	}
+
+	return kvs[0].Value
+}
+
+// expectInvalidateAfterCommit fails when the canary cache is invalidated before the transaction is committed
+func expectInvalidateAfterCommit(t *testing.T, deps *testDeps) {
+	t.Helper()
+
+	deps.keyRepo.EXPECT().InvalidateCanaryCache(gomock.Any(), canaryTestKey).DoAndReturn(func(ctx context.Context, key string) error {
+		if err := deps.db.ExpectationsWereMet(); err != nil {
+			t.Errorf("canary cache invalidated before commit: %v", err)
+		}
+		return nil
+	})
+}
+
+func TestApproveKeyCanaryInvalidateCacheAfterCommit(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	deps.expectNoOwnership()
+	deps.expectKeys(canaryTestKey, map[int][]keyentity.KV{keyentity.PlacedKey: {canaryTestPlaced}})
+
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().CreateCanaryKey(gomock.Any(), gomock.Any(), canaryTestPlaced.ID, "10.0.0.0/24").Return(nil)
+	deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), canaryTestPlaced.ID, gomock.Any()).Return(nil)
+	deps.db.ExpectCommit()
+	expectInvalidateAfterCommit(t, deps)
+
+	// range is stored by its network address
+	if err := u.ApproveKeyCanary(context.Background(), canaryTestKey, testAdmin, keyentity.CanaryKey, []string{"10.0.0.7/24"}); err != nil {
+		t.Fatalf("ApproveKeyCanary() error = %v", err)
+	}
+}
+
+func TestApproveKeyInvalidateCanaryCacheAfterCommit(t *testing.T) {
+	for _, status := range []int{keyentity.ApprovedKey, keyentity.DissaprovedKey} {
+		t.Run(fmt.Sprint(status), func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			deps.expectNoOwnership()
+			deps.expectNoPrerequisite()
+			deps.expectKeys(canaryTestKey, map[int][]keyentity.KV{
+				keyentity.ApprovedAndActive: {canaryTestActive},
+				keyentity.CanaryKey:         {canaryTestCanary},
+			})
+
+			deps.db.ExpectBegin()
+			deps.keyRepo.EXPECT().ModifyCanaryKey(gomock.Any(), gomock.Any(), canaryTestCanary.ID, keyentity.StatusInactive).Return(nil)
+			deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), canaryTestCanary.ID, gomock.Any()).Return(nil)
+			if status == keyentity.DissaprovedKey {
+				deps.keyRepo.EXPECT().CreateComment(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
+			} else {
+				deps.keyRepo.EXPECT().ModifyOldActiveKey(gomock.Any(), gomock.Any(), canaryTestKey).Return(nil)
+				deps.keyRepo.EXPECT().CreateKeyEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				deps.keyRepo.EXPECT().SetCache(gomock.Any(), gomock.Any()).Return(nil)
+			}
+			deps.db.ExpectCommit()
+			expectInvalidateAfterCommit(t, deps)
+
+			if err := u.ApproveKey(context.Background(), canaryTestKey, testAdmin, status, "error rate is up"); err != nil {
+				t.Fatalf("ApproveKey() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGetKeysCanaryOverride(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	expectActiveCache(deps)
+
+	// index of the reader is built from db once, then served from cache
+	deps.keyRepo.EXPECT().GetCanaryCache(gomock.Any(), "service/risk", "10.0.0.1", "").Return(nil, errors.New("cache miss"))
+	deps.keyRepo.EXPECT().GetCanaryKVByPrefix(gomock.Any(), "service/risk", "10.0.0.1", "").Return([]keyentity.KV{canaryTestCanary}, nil)
+	deps.keyRepo.EXPECT().SetCanaryCache(gomock.Any(), "service/risk", "10.0.0.1", "", []keyentity.KV{canaryTestCanary}).Return(nil)
+	deps.keyRepo.EXPECT().GetCanaryCache(gomock.Any(), "service/risk", "10.0.0.1", "").Return([]keyentity.KV{canaryTestCanary}, nil)
+
+	// empty index is cached as well
+	deps.keyRepo.EXPECT().GetCanaryCache(gomock.Any(), "service/risk", "10.0.1.1", "").Return(nil, errors.New("cache miss"))
+	deps.keyRepo.EXPECT().GetCanaryKVByPrefix(gomock.Any(), "service/risk", "10.0.1.1", "").Return(nil, sql.ErrNoRows)
+	deps.keyRepo.EXPECT().SetCanaryCache(gomock.Any(), "service/risk", "10.0.1.1", "", nil).Return(nil)
+
+	for i := 0; i < 2; i++ {
+		if got := getValue(t, u, keyentity.Reader{IP: "10.0.0.1"}); got != "true" {
+			t.Fatalf("value of canary ip = %q, want true", got)
+		}
+	}
+	if got := getValue(t, u, keyentity.Reader{IP: "10.0.1.1"}); got != "false" {
+		t.Fatalf("value of other ip = %q, want false", got)
+	}
+	// reader without ip and group has no canary index
+	if got := getValue(t, u, keyentity.Reader{}); got != "false" {
+		t.Fatalf("value of anonymous reader = %q, want false", got)
+	}
+}
+
+func TestGetKeysCanaryGroup(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	deps.expectNoOwnership()
+	deps.expectKeys(canaryTestKey, map[int][]keyentity.KV{keyentity.PlacedKey: {canaryTestPlaced}})
+
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().CreateCanaryGroupKey(gomock.Any(), gomock.Any(), canaryTestPlaced.ID, "blue").Return(nil)
+	deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), canaryTestPlaced.ID, gomock.Any()).Return(nil)
+	deps.db.ExpectCommit()
+	deps.keyRepo.EXPECT().InvalidateCanaryCache(gomock.Any(), canaryTestKey).Return(nil)
+
+	if err := u.approveKeyCanary(context.Background(), canaryTestKey, testAdmin, nil, "blue"); err != nil {
+		t.Fatalf("approveKeyCanary() error = %v", err)
+	}
+
+	expectActiveCache(deps)
+	deps.keyRepo.EXPECT().GetCanaryCache(gomock.Any(), "service/risk", "10.0.0.1", "blue").Return([]keyentity.KV{canaryTestCanary}, nil)
+	deps.keyRepo.EXPECT().GetCanaryCache(gomock.Any(), "service/risk", "10.0.0.1", "green").Return(nil, nil)
+
+	// node without ip in the canary still reads the group value
+	if got := getValue(t, u, keyentity.Reader{IP: "10.0.0.1", Group: "blue"}); got != "true" {
+		t.Fatalf("value of group reader = %q, want true", got)
+	}
+	if got := getValue(t, u, keyentity.Reader{IP: "10.0.0.1", Group: "green"}); got != "false" {
+		t.Fatalf("value of other group = %q, want false", got)
+	}
+}
+
+// expectBenchmarkCache serve the number of active keys under service/risk, one key in ten is in canary for the reader
+func expectBenchmarkCache(deps *testDeps, keys int) {
+	approvedKeys := make([]keyentity.KV, 0, keys)
+	canaryKeys := make([]keyentity.KV, 0, keys/10)
+	for i := 0; i < keys; i++ {
+		kv := keyentity.KV{Key: fmt.Sprintf("service/risk/flag-%05d", i), Value: "false", Status: keyentity.ApprovedAndActive}
+		approvedKeys = append(approvedKeys, kv)
+
+		if i%10 == 0 {
+			kv.Value = "true"
+			kv.Status = keyentity.CanaryKey
+			canaryKeys = append(canaryKeys, kv)
+		}
+	}
+
+	// every call gets its own copy like from redis
+	deps.keyRepo.EXPECT().GetCaches(gomock.Any(), "service/risk").DoAndReturn(func(ctx context.Context, prefix string) ([]keyentity.KV, error) {
+		return append([]keyentity.KV(nil), approvedKeys...), nil
+	}).AnyTimes()
+	deps.keyRepo.EXPECT().GetCanaryCache(gomock.Any(), "service/risk", gomock.Any(), gomock.Any()).Return(canaryKeys, nil).AnyTimes()
+}
+
+func benchmarkGetKeys(b *testing.B, keys int, reader keyentity.Reader) {
+	u, deps := newTestUsecase(b)
+	expectBenchmarkCache(deps, keys)
+
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := u.GetKeys(ctx, "service/risk", reader); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetKeys(b *testing.B) {
+	for _, keys := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("keys=%d/no canary", keys), func(b *testing.B) {
+			benchmarkGetKeys(b, keys, keyentity.Reader{})
+		})
+		b.Run(fmt.Sprintf("keys=%d/ip", keys), func(b *testing.B) {
+			benchmarkGetKeys(b, keys, keyentity.Reader{IP: "10.0.0.1"})
+		})
+		b.Run(fmt.Sprintf("keys=%d/group", keys), func(b *testing.B) {
+			benchmarkGetKeys(b, keys, keyentity.Reader{Group: "blue"})
+		})
+	}
+}
+
+// getKeysNestedLoop is GetKeys before the canary index, every active key scanned every canary key of the ip
+func getKeysNestedLoop(ctx context.Context, u *Usecase, prefix, ip string) ([]keyentity.KV, error) {
+	approvedKeys, err := u.keyRepo.GetCaches(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	canaryKeys, err := u.keyRepo.GetCanaryCache(ctx, prefix, ip, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for i, aKey := range approvedKeys {
+		for _, cKey := range canaryKeys {
+			if aKey.Key == cKey.Key {
+				approvedKeys[i] = cKey
+				break
+			}
+		}
+	}
+
+	return approvedKeys, nil
+}
+
+// BenchmarkGetKeysNestedLoop is the baseline of BenchmarkGetKeys with ip reader on the same keys
+func BenchmarkGetKeysNestedLoop(b *testing.B) {
+	for _, keys := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("keys=%d/ip", keys), func(b *testing.B) {
+			u, deps := newTestUsecase(b)
+			expectBenchmarkCache(deps, keys)
+
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := getKeysNestedLoop(ctx, u, "service/risk", "10.0.0.1"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+// AddComment comment on a pending change, reply to another comment of the same change with ParentID
+func (u *Usecase) AddComment(ctx context.Context, comment keyentity.Comment, userID int) (int, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.AddComment", u.timeout.Write)
+	defer finish()
+
+	comment.Body = strings.TrimSpace(comment.Body)
+	if comment.Body == "" {
+		return 0, errors.New("Comment can not be empty.")
+	}
+
+	kv, err := u.keyRepo.GetKeyByID(ctx, comment.KeyID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := u.authorize(ctx, userID, kv.Key, userentity.RoleUser); err != nil {
+		return 0, err
+	}
+
+	// decided change is part of history, the discussion is closed
+	if pendingKind(kv.Status) == "" {
+		return 0, errors.New("Key is not pending approval.")
+	}
+
+	if comment.ParentID != 0 {
+		parent, err := u.keyRepo.GetCommentByID(ctx, comment.ParentID)
+		if err != nil {
+			return 0, err
+		}
+
+		if parent.KeyID != comment.KeyID {
+			return 0, errors.New("Parent comment belongs to another change.")
+		}
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer endTx()
+
+	comment.Kind = keyentity.CommentKindComment
+	comment.CreatedBy = userID
+	id, err := u.keyRepo.CreateComment(ctx, tx, comment)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetComments returns comments of a key row ordered by create time
+func (u *Usecase) GetComments(ctx context.Context, keyID, userID int) ([]keyentity.Comment, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetComments", u.timeout.Read)
+	defer finish()
+
+	kv, err := u.keyRepo.GetKeyByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.authorize(ctx, userID, kv.Key, userentity.RoleUser); err != nil {
+		return nil, err
+	}
+
+	return u.getComments(ctx, []keyentity.KV{kv})
+}
+
+// getComments returns comments of every key row in one query
+func (u *Usecase) getComments(ctx context.Context, kvs []keyentity.KV) ([]keyentity.Comment, error) {
+	if len(kvs) == 0 {
+		return []keyentity.Comment{}, nil
+	}
+
+	keyIDs := make([]int, 0, len(kvs))
+	for _, kv := range kvs {
+		keyIDs = append(keyIDs, kv.ID)
+	}
+
+	comments, err := u.keyRepo.GetComments(ctx, keyIDs)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if comments == nil {
+		comments = []keyentity.Comment{}
+	}
+
+	return comments, nil
+}
+
+// createComment store comment of the kind inside tx, empty body is skipped
+func (u *Usecase) createComment(ctx context.Context, tx *sql.Tx, keyID int, kind, body string, userID int) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil
+	}
+
+	_, err := u.keyRepo.CreateComment(ctx, tx, keyentity.Comment{
+		KeyID:     keyID,
+		Kind:      kind,
+		Body:      body,
+		CreatedBy: userID,
+	})
+	return err
+}
+
+func validateReason(status int, reason string) error {
+	if status == keyentity.DissaprovedKey && strings.TrimSpace(reason) == "" {
+		return errors.New("Reason is required to disapprove.")
+	}
+
+	return nil
+}
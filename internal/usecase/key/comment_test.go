@@ -0,0 +1,107 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+func TestAddComment(t *testing.T) {
+	placed := keyentity.KV{ID: 1, Key: "service/risk/flag", Value: "true", Status: keyentity.PlacedKey}
+	active := keyentity.KV{ID: 2, Key: "service/risk/flag", Value: "false", Status: keyentity.ApprovedAndActive}
+
+	tests := []struct {
+		name    string
+		comment keyentity.Comment
+		kv      keyentity.KV
+		parent  keyentity.Comment
+		wantErr bool
+	}{
+		{name: "comment on placed key", comment: keyentity.Comment{KeyID: 1, Body: " looks good "}, kv: placed},
+		{name: "reply", comment: keyentity.Comment{KeyID: 1, ParentID: 5, Body: "why true?"}, kv: placed, parent: keyentity.Comment{ID: 5, KeyID: 1}},
+		{name: "empty body", comment: keyentity.Comment{KeyID: 1, Body: "  "}, kv: placed, wantErr: true},
+		{name: "decided key", comment: keyentity.Comment{KeyID: 2, Body: "late"}, kv: active, wantErr: true},
+		{name: "reply to another change", comment: keyentity.Comment{KeyID: 1, ParentID: 5, Body: "why?"}, kv: placed, parent: keyentity.Comment{ID: 5, KeyID: 3}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			deps.keyRepo.EXPECT().GetKeyByID(gomock.Any(), tt.comment.KeyID).Return(tt.kv, nil).AnyTimes()
+			deps.keyRepo.EXPECT().GetCommentByID(gomock.Any(), tt.comment.ParentID).Return(tt.parent, nil).AnyTimes()
+
+			if !tt.wantErr {
+				deps.db.ExpectBegin()
+				deps.keyRepo.EXPECT().CreateComment(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, tx *sql.Tx, comment keyentity.Comment) (int, error) {
+					if comment.Kind != keyentity.CommentKindComment || comment.CreatedBy != testUser || comment.ParentID != tt.comment.ParentID {
+						t.Fatalf("stored comment = %+v, want comment of the user", comment)
+					}
+					if comment.Body == "" || comment.Body[0] == ' ' {
+						t.Fatalf("stored body = %q, want trimmed", comment.Body)
+					}
+					return 9, nil
+				})
+				deps.db.ExpectCommit()
+			}
+
+			id, err := u.AddComment(context.Background(), tt.comment, testUser)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddComment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && id != 9 {
+				t.Fatalf("AddComment() = %d, want 9", id)
+			}
+		})
+	}
+}
+
+func TestApproveKeyDisapprovalReason(t *testing.T) {
+	t.Run("reason is required", func(t *testing.T) {
+		u, deps := newTestUsecase(t)
+		deps.expectNoOwnership()
+
+		if err := u.ApproveKey(context.Background(), "service/risk/flag", testAdmin, keyentity.DissaprovedKey, " "); err == nil {
+			t.Fatal("ApproveKey() error = nil, want reason error")
+		}
+	})
+
+	t.Run("reason is stored with the disapproval", func(t *testing.T) {
+		u, deps := newTestUsecase(t)
+		expectApproveKey(deps).DoAndReturn(func(ctx context.Context, tx *sql.Tx, keyID int, kv keyentity.KV) error {
+			if kv.Status != keyentity.DissaprovedKey {
+				t.Fatalf("status = %d, want disapproved", kv.Status)
+			}
+			return nil
+		})
+		deps.keyRepo.EXPECT().CreateComment(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, tx *sql.Tx, comment keyentity.Comment) (int, error) {
+			if tx == nil || comment.KeyID != 1 || comment.Kind != keyentity.CommentKindDisapproval || comment.Body != "error rate is up" || comment.CreatedBy != testAdmin {
+				t.Fatalf("stored comment = %+v, want disapproval reason of the approver", comment)
+			}
+			return 1, nil
+		})
+		deps.db.ExpectCommit()
+
+		if err := u.ApproveKey(context.Background(), "service/risk/flag", testAdmin, keyentity.DissaprovedKey, "error rate is up"); err != nil {
+			t.Fatalf("ApproveKey() error = %v", err)
+		}
+	})
+}
+
+func TestGetCommentsEmpty(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	deps.keyRepo.EXPECT().GetKeyByID(gomock.Any(), 1).Return(keyentity.KV{ID: 1, Key: "service/risk/flag", Status: keyentity.PlacedKey}, nil)
+	deps.keyRepo.EXPECT().GetComments(gomock.Any(), []int{1}).Return(nil, sql.ErrNoRows)
+
+	// no comments is an empty list, not an error
+	comments, err := u.GetComments(context.Background(), 1, testUser)
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	if comments == nil || len(comments) != 0 {
+		t.Fatalf("GetComments() = %#v, want empty list", comments)
+	}
+}
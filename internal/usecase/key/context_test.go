@@ -0,0 +1,167 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/marde12345/key-flag/internal/config"
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	"github.com/marde12345/key-flag/internal/tracing"
+)
+
+func TestUsecaseSpans(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.Setup(exporter)
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+		otel.SetTracerProvider(previous)
+	})
+
+	u, deps := newTestUsecase(t)
+	deps.keyRepo.EXPECT().GetCache(gomock.Any(), "service/risk/flag").Return(keyentity.KV{}, errors.New("cache miss"))
+	deps.keyRepo.EXPECT().GetKey(gomock.Any(), "service/risk/flag", keyentity.ApprovedKey).
+		Return([]keyentity.KV{{Key: "service/risk/flag", Value: "true", Status: keyentity.ApprovedKey}}, nil)
+
+	// cache miss falls back to the db
+	if _, err := u.GetKey(context.Background(), "service/risk/flag", keyentity.Reader{}); err != nil {
+		t.Fatalf("GetKey() error = %v", err)
+	}
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+
+	root, ok := spans["key.Usecase.GetKey"]
+	if !ok {
+		t.Fatalf("spans = %v, want key.Usecase.GetKey", exporter.GetSpans())
+	}
+	for _, name := range []string{"keyRepository.GetCache", "keyRepository.GetKey"} {
+		span, ok := spans[name]
+		if !ok {
+			t.Fatalf("span %s is not recorded", name)
+		}
+		if span.Parent.SpanID() != root.SpanContext.SpanID() {
+			t.Fatalf("span %s is not a child of the usecase span", name)
+		}
+	}
+
+	// cache miss is an error of the repository span only
+	if got := spans["keyRepository.GetCache"].Status.Code; got != codes.Error {
+		t.Fatalf("GetCache status = %v, want error", got)
+	}
+	if got := root.Status.Code; got != codes.Unset {
+		t.Fatalf("usecase status = %v, want unset", got)
+	}
+}
+
+// expectApproveKey expect approval of placed service/risk/flag up to ModifyKey, the call ModifyKey makes is returned
+func expectApproveKey(deps *testDeps) *gomock.Call {
+	deps.expectNoOwnership()
+	deps.expectNoPrerequisite()
+	deps.expectKeys("service/risk/flag", map[int][]keyentity.KV{
+		keyentity.PlacedKey: {{ID: 1, Key: "service/risk/flag", Value: "true", Status: keyentity.PlacedKey}},
+	})
+	deps.db.ExpectBegin()
+
+	return deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), 1, gomock.Any())
+}
+
+// assertNoOpenTx wait for rollback of canceled ctx, database/sql runs it in the background
+func assertNoOpenTx(t *testing.T, deps *testDeps) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		err := deps.db.ExpectationsWereMet()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("transaction is left open: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// blockModifyKey block ModifyKey until ctx is done, like a query on a slow db
+func blockModifyKey(call *gomock.Call, started chan struct{}) {
+	call.DoAndReturn(func(ctx context.Context, tx *sql.Tx, keyID int, kv keyentity.KV) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+}
+
+func TestApproveKeyNoOpenTx(t *testing.T) {
+	t.Run("committed", func(t *testing.T) {
+		u, deps := newTestUsecase(t)
+		expectApproveKey(deps).Return(nil)
+		deps.keyRepo.EXPECT().ModifyOldActiveKey(gomock.Any(), gomock.Any(), "service/risk/flag").Return(nil)
+		deps.keyRepo.EXPECT().CreateKeyEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		deps.keyRepo.EXPECT().SetCache(gomock.Any(), gomock.Any()).Return(nil)
+		deps.db.ExpectCommit()
+
+		if err := u.ApproveKey(context.Background(), "service/risk/flag", testAdmin, keyentity.ApprovedKey, ""); err != nil {
+			t.Fatalf("ApproveKey() error = %v", err)
+		}
+		assertNoOpenTx(t, deps)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		u, deps := newTestUsecase(t)
+		expectApproveKey(deps).Return(nil)
+		deps.keyRepo.EXPECT().ModifyOldActiveKey(gomock.Any(), gomock.Any(), "service/risk/flag").Return(nil)
+		deps.keyRepo.EXPECT().CreateKeyEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+		deps.db.ExpectRollback()
+
+		if err := u.ApproveKey(context.Background(), "service/risk/flag", testAdmin, keyentity.ApprovedKey, ""); err == nil {
+			t.Fatal("ApproveKey() error = nil, want repository error")
+		}
+		assertNoOpenTx(t, deps)
+	})
+
+	t.Run("caller canceled", func(t *testing.T) {
+		u, deps := newTestUsecase(t)
+		started := make(chan struct{})
+		blockModifyKey(expectApproveKey(deps), started)
+		deps.db.ExpectRollback()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- u.ApproveKey(ctx, "service/risk/flag", testAdmin, keyentity.ApprovedKey, "")
+		}()
+
+		<-started
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Fatalf("ApproveKey() error = %v, want canceled", err)
+		}
+		assertNoOpenTx(t, deps)
+	})
+
+	t.Run("write timeout", func(t *testing.T) {
+		u, deps := newTestUsecase(t)
+		u.SetTimeout(config.Timeout{Write: 10 * time.Millisecond})
+		blockModifyKey(expectApproveKey(deps), make(chan struct{}))
+		deps.db.ExpectRollback()
+
+		if err := u.ApproveKey(context.Background(), "service/risk/flag", testAdmin, keyentity.ApprovedKey, ""); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("ApproveKey() error = %v, want deadline exceeded", err)
+		}
+		assertNoOpenTx(t, deps)
+	})
+}
@@ -0,0 +1,45 @@
+package key
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+)
+
+var errInvalidCursor = errors.New("Invalid cursor.")
+
+func encodeCursor(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", errInvalidCursor
+	}
+
+	return string(value), nil
+}
+
+func encodeIDCursor(id int) string {
+	return encodeCursor(strconv.Itoa(id))
+}
+
+// decodeIDCursor returns 0 for empty cursor which means first page
+func decodeIDCursor(cursor string) (int, error) {
+	value, err := decodeCursor(cursor)
+	if err != nil || value == "" {
+		return 0, err
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, errInvalidCursor
+	}
+
+	return id, nil
+}
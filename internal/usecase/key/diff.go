@@ -0,0 +1,254 @@
+package key
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+// diffValue compare old and new value based on value type.
+// string use line diff, json use structural diff, secret only tells it changed and everything else is old -> new.
+func diffValue(valType, oldValue, newValue string) keyentity.Diff {
+	if valType == keyentity.TypeSecret {
+		return keyentity.Diff{
+			Kind: keyentity.DiffKindSecret,
+			Old:  maskValue(oldValue),
+			New:  maskValue(newValue),
+		}
+	}
+
+	diff := keyentity.Diff{
+		Kind: keyentity.DiffKindScalar,
+		Old:  oldValue,
+		New:  newValue,
+	}
+
+	switch valType {
+	case keyentity.TypeString:
+		diff.Kind = keyentity.DiffKindLine
+		diff.Lines = diffLines(oldValue, newValue)
+	case keyentity.TypeJSON:
+		changes, err := diffJSON(oldValue, newValue)
+		if err != nil {
+			// invalid json still can be compared line by line
+			diff.Kind = keyentity.DiffKindLine
+			diff.Lines = diffLines(oldValue, newValue)
+			return diff
+		}
+
+		diff.Kind = keyentity.DiffKindJSON
+		diff.Changes = changes
+	}
+
+	return diff
+}
+
+// maxDiffCells bound the lcs table of diffLines, 1M cells is about 8MB.
+// Changed block bigger than that is shown as removed then added lines.
+const maxDiffCells = 1 << 20
+
+// diffLines build line diff using longest common subsequence of both values.
+// Common leading and trailing lines are matched first so only the changed block needs the lcs table.
+func diffLines(oldValue, newValue string) []keyentity.DiffLine {
+	oldLines := splitLines(oldValue)
+	newLines := splitLines(newValue)
+
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]keyentity.DiffLine, 0, len(oldLines)+len(newLines))
+	for _, line := range oldLines[:prefix] {
+		result = append(result, keyentity.DiffLine{Op: keyentity.DiffOpEqual, Text: line})
+	}
+
+	oldBlock := oldLines[prefix : len(oldLines)-suffix]
+	newBlock := newLines[prefix : len(newLines)-suffix]
+	if len(oldBlock)*len(newBlock) > maxDiffCells {
+		for _, line := range oldBlock {
+			result = append(result, keyentity.DiffLine{Op: keyentity.DiffOpRemoved, Text: line})
+		}
+		for _, line := range newBlock {
+			result = append(result, keyentity.DiffLine{Op: keyentity.DiffOpAdded, Text: line})
+		}
+	} else {
+		result = appendLCSLines(result, oldBlock, newBlock)
+	}
+
+	for _, line := range oldLines[len(oldLines)-suffix:] {
+		result = append(result, keyentity.DiffLine{Op: keyentity.DiffOpEqual, Text: line})
+	}
+
+	return result
+}
+
+// appendLCSLines append line diff of both blocks using their longest common subsequence
+func appendLCSLines(result []keyentity.DiffLine, oldLines, newLines []string) []keyentity.DiffLine {
+	// lcs[i][j] is lcs length of oldLines[i:] and newLines[j:]
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(oldLines) && j < len(newLines) {
+		switch {
+		case oldLines[i] == newLines[j]:
+			result = append(result, keyentity.DiffLine{Op: keyentity.DiffOpEqual, Text: oldLines[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, keyentity.DiffLine{Op: keyentity.DiffOpRemoved, Text: oldLines[i]})
+			i++
+		default:
+			result = append(result, keyentity.DiffLine{Op: keyentity.DiffOpAdded, Text: newLines[j]})
+			j++
+		}
+	}
+	for ; i < len(oldLines); i++ {
+		result = append(result, keyentity.DiffLine{Op: keyentity.DiffOpRemoved, Text: oldLines[i]})
+	}
+	for ; j < len(newLines); j++ {
+		result = append(result, keyentity.DiffLine{Op: keyentity.DiffOpAdded, Text: newLines[j]})
+	}
+
+	return result
+}
+
+func splitLines(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+
+	return strings.Split(value, "\n")
+}
+
+// diffJSON returns every changed path between two json documents.
+// Empty value is treated as a missing document so new and deleted keys still can be compared.
+func diffJSON(oldValue, newValue string) ([]keyentity.DiffChange, error) {
+	var oldDoc, newDoc interface{}
+
+	if oldValue != "" {
+		if err := json.Unmarshal([]byte(oldValue), &oldDoc); err != nil {
+			return nil, err
+		}
+	}
+	if newValue != "" {
+		if err := json.Unmarshal([]byte(newValue), &newDoc); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := make([]keyentity.DiffChange, 0)
+	switch {
+	case oldValue == "" && newValue == "":
+	case oldValue == "":
+		changes = append(changes, keyentity.DiffChange{Path: "$", Op: keyentity.DiffOpAdded, New: encodeJSON(newDoc)})
+	case newValue == "":
+		changes = append(changes, keyentity.DiffChange{Path: "$", Op: keyentity.DiffOpRemoved, Old: encodeJSON(oldDoc)})
+	default:
+		changes = appendJSONChanges(changes, "$", oldDoc, newDoc)
+	}
+
+	return changes, nil
+}
+
+func appendJSONChanges(changes []keyentity.DiffChange, path string, oldDoc, newDoc interface{}) []keyentity.DiffChange {
+	switch oldTyped := oldDoc.(type) {
+	case map[string]interface{}:
+		newTyped, ok := newDoc.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		fields := make([]string, 0, len(oldTyped)+len(newTyped))
+		for field := range oldTyped {
+			fields = append(fields, field)
+		}
+		for field := range newTyped {
+			if _, ok := oldTyped[field]; !ok {
+				fields = append(fields, field)
+			}
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			fieldPath := fmt.Sprintf("%s.%s", path, field)
+			oldField, inOld := oldTyped[field]
+			newField, inNew := newTyped[field]
+
+			switch {
+			case !inOld:
+				changes = append(changes, keyentity.DiffChange{Path: fieldPath, Op: keyentity.DiffOpAdded, New: encodeJSON(newField)})
+			case !inNew:
+				changes = append(changes, keyentity.DiffChange{Path: fieldPath, Op: keyentity.DiffOpRemoved, Old: encodeJSON(oldField)})
+			default:
+				changes = appendJSONChanges(changes, fieldPath, oldField, newField)
+			}
+		}
+
+		return changes
+	case []interface{}:
+		newTyped, ok := newDoc.([]interface{})
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(oldTyped) || i < len(newTyped); i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+			switch {
+			case i >= len(oldTyped):
+				changes = append(changes, keyentity.DiffChange{Path: itemPath, Op: keyentity.DiffOpAdded, New: encodeJSON(newTyped[i])})
+			case i >= len(newTyped):
+				changes = append(changes, keyentity.DiffChange{Path: itemPath, Op: keyentity.DiffOpRemoved, Old: encodeJSON(oldTyped[i])})
+			default:
+				changes = appendJSONChanges(changes, itemPath, oldTyped[i], newTyped[i])
+			}
+		}
+
+		return changes
+	}
+
+	if reflect.DeepEqual(oldDoc, newDoc) {
+		return changes
+	}
+
+	return append(changes, keyentity.DiffChange{
+		Path: path,
+		Op:   keyentity.DiffOpChanged,
+		Old:  encodeJSON(oldDoc),
+		New:  encodeJSON(newDoc),
+	})
+}
+
+func encodeJSON(doc interface{}) string {
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		return ""
+	}
+
+	return string(encoded)
+}
@@ -0,0 +1,186 @@
+package key
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+func line(op, text string) keyentity.DiffLine {
+	return keyentity.DiffLine{Op: op, Text: text}
+}
+
+func TestDiffValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		valType  string
+		oldValue string
+		newValue string
+		want     keyentity.Diff
+	}{
+		{
+			name:     "scalar",
+			valType:  "bool",
+			oldValue: "false",
+			newValue: "true",
+			want:     keyentity.Diff{Kind: keyentity.DiffKindScalar, Old: "false", New: "true"},
+		},
+		{
+			name:     "secret is masked",
+			valType:  keyentity.TypeSecret,
+			oldValue: "old password",
+			newValue: "new password",
+			want:     keyentity.Diff{Kind: keyentity.DiffKindSecret, Old: keyentity.SecretMask, New: keyentity.SecretMask},
+		},
+		{
+			name:     "deleted secret stays empty",
+			valType:  keyentity.TypeSecret,
+			oldValue: "old password",
+			want:     keyentity.Diff{Kind: keyentity.DiffKindSecret, Old: keyentity.SecretMask},
+		},
+		{
+			name:     "string by line",
+			valType:  keyentity.TypeString,
+			oldValue: "a\nb\nc",
+			newValue: "a\nB\nc",
+			want: keyentity.Diff{
+				Kind: keyentity.DiffKindLine,
+				Old:  "a\nb\nc",
+				New:  "a\nB\nc",
+				Lines: []keyentity.DiffLine{
+					line(keyentity.DiffOpEqual, "a"),
+					line(keyentity.DiffOpRemoved, "b"),
+					line(keyentity.DiffOpAdded, "B"),
+					line(keyentity.DiffOpEqual, "c"),
+				},
+			},
+		},
+		{
+			name:     "json by path",
+			valType:  keyentity.TypeJSON,
+			oldValue: `{"a":1}`,
+			newValue: `{"a":2}`,
+			want: keyentity.Diff{
+				Kind:    keyentity.DiffKindJSON,
+				Old:     `{"a":1}`,
+				New:     `{"a":2}`,
+				Changes: []keyentity.DiffChange{{Path: "$.a", Op: keyentity.DiffOpChanged, Old: "1", New: "2"}},
+			},
+		},
+		{
+			name:     "invalid json by line",
+			valType:  keyentity.TypeJSON,
+			oldValue: `{"a":1`,
+			newValue: `{"a":1}`,
+			want: keyentity.Diff{
+				Kind:  keyentity.DiffKindLine,
+				Old:   `{"a":1`,
+				New:   `{"a":1}`,
+				Lines: []keyentity.DiffLine{line(keyentity.DiffOpRemoved, `{"a":1`), line(keyentity.DiffOpAdded, `{"a":1}`)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffValue(tt.valType, tt.oldValue, tt.newValue); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("diffValue() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldValue string
+		newValue string
+		want     []keyentity.DiffChange
+		wantErr  bool
+	}{
+		{
+			name:     "same document",
+			oldValue: `{"a":[1,2],"b":{"c":true}}`,
+			newValue: `{"b":{"c":true},"a":[1,2]}`,
+			want:     []keyentity.DiffChange{},
+		},
+		{
+			name:     "nested fields sorted by path",
+			oldValue: `{"b":{"c":true,"d":1},"a":"x"}`,
+			newValue: `{"b":{"c":false,"e":2},"a":"x"}`,
+			want: []keyentity.DiffChange{
+				{Path: "$.b.c", Op: keyentity.DiffOpChanged, Old: "true", New: "false"},
+				{Path: "$.b.d", Op: keyentity.DiffOpRemoved, Old: "1"},
+				{Path: "$.b.e", Op: keyentity.DiffOpAdded, New: "2"},
+			},
+		},
+		{
+			name:     "array items by index",
+			oldValue: `[1,2]`,
+			newValue: `[1,3,4]`,
+			want: []keyentity.DiffChange{
+				{Path: "$[1]", Op: keyentity.DiffOpChanged, Old: "2", New: "3"},
+				{Path: "$[2]", Op: keyentity.DiffOpAdded, New: "4"},
+			},
+		},
+		{
+			name:     "type change",
+			oldValue: `{"a":{"b":1}}`,
+			newValue: `{"a":[1]}`,
+			want:     []keyentity.DiffChange{{Path: "$.a", Op: keyentity.DiffOpChanged, Old: `{"b":1}`, New: "[1]"}},
+		},
+		{
+			name:     "new key",
+			newValue: `{"a":1}`,
+			want:     []keyentity.DiffChange{{Path: "$", Op: keyentity.DiffOpAdded, New: `{"a":1}`}},
+		},
+		{
+			name:     "deleted key",
+			oldValue: `{"a":1}`,
+			want:     []keyentity.DiffChange{{Path: "$", Op: keyentity.DiffOpRemoved, Old: `{"a":1}`}},
+		},
+		{
+			name:     "invalid json",
+			oldValue: `{"a":1}`,
+			newValue: `{"a":`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diffJSON(tt.oldValue, tt.newValue)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("diffJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("diffJSON() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLinesLargeValue(t *testing.T) {
+	oldLines := make([]string, 0, 2000)
+	newLines := make([]string, 0, 2000)
+	for i := 0; i < 2000; i++ {
+		oldLines = append(oldLines, "old")
+		newLines = append(newLines, "new")
+	}
+	oldValue := "head\n" + strings.Join(oldLines, "\n") + "\ntail"
+	newValue := "head\n" + strings.Join(newLines, "\n") + "\ntail"
+
+	// changed block is over maxDiffCells, it is shown as removed then added without the lcs table
+	got := diffLines(oldValue, newValue)
+	if len(got) != 4002 {
+		t.Fatalf("len(diffLines()) = %d, want 4002", len(got))
+	}
+	if got[0] != line(keyentity.DiffOpEqual, "head") || got[len(got)-1] != line(keyentity.DiffOpEqual, "tail") {
+		t.Fatalf("common lines = %+v and %+v, want head and tail equal", got[0], got[len(got)-1])
+	}
+	if got[1] != line(keyentity.DiffOpRemoved, "old") || got[2001] != line(keyentity.DiffOpAdded, "new") {
+		t.Fatalf("changed block = %+v and %+v, want removed then added", got[1], got[2001])
+	}
+}
@@ -0,0 +1,246 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/util"
+)
+
+// ExportPrefix dump every active key under the prefix as yaml or json, secret values are masked
+func (u *Usecase) ExportPrefix(ctx context.Context, prefix, format string, userID int) ([]byte, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.ExportPrefix", u.timeout.Read)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, prefix, userentity.RoleUser); err != nil {
+		return nil, err
+	}
+
+	activeKeys, err := u.keyRepo.GetKeyByPrefix(ctx, prefix, keyentity.ApprovedAndActive)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	activeKeys = underPrefix(activeKeys, prefix)
+
+	sort.Slice(activeKeys, func(i, j int) bool {
+		return activeKeys[i].Key < activeKeys[j].Key
+	})
+
+	doc := keyentity.ExportDocument{
+		Prefix:     prefix,
+		ExportedAt: time.Now(),
+		Keys:       make([]keyentity.ExportedKey, 0, len(activeKeys)),
+	}
+	for _, kv := range activeKeys {
+		doc.Keys = append(doc.Keys, keyentity.ExportedKey{
+			Key:        kv.Key,
+			Value:      maskSecret(kv).Value,
+			Type:       kv.Type,
+			CreatedBy:  kv.CreatedBy,
+			ApprovedBy: kv.ApprovedBy,
+			UpdateTime: kv.UpdateTime,
+		})
+	}
+
+	switch format {
+	case keyentity.FormatJSON:
+		return json.MarshalIndent(doc, "", "  ")
+	case keyentity.FormatYAML:
+		return yaml.Marshal(doc)
+	}
+
+	return nil, fmt.Errorf("Unknown format %s.", format)
+}
+
+// ImportPrefix place every key in the document that differ from the active value under one change set.
+// Keys are moved from the document prefix into the requested prefix, so export of one prefix can be imported to another.
+func (u *Usecase) ImportPrefix(ctx context.Context, prefix, format string, data []byte, userID int) (keyentity.ImportResult, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.ImportPrefix", u.timeout.Write)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, prefix, userentity.RoleUser); err != nil {
+		return keyentity.ImportResult{}, err
+	}
+
+	var doc keyentity.ExportDocument
+	switch format {
+	case keyentity.FormatJSON:
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return keyentity.ImportResult{}, err
+		}
+	case keyentity.FormatYAML:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return keyentity.ImportResult{}, err
+		}
+	default:
+		return keyentity.ImportResult{}, fmt.Errorf("Unknown format %s.", format)
+	}
+
+	activeKeys, err := u.keyRepo.GetKeyByPrefix(ctx, prefix, keyentity.ApprovedAndActive)
+	if err != nil && err != sql.ErrNoRows {
+		return keyentity.ImportResult{}, err
+	}
+
+	activeByKey := make(map[string]keyentity.KV, len(activeKeys))
+	for _, kv := range activeKeys {
+		activeByKey[kv.Key] = kv
+	}
+
+	result := keyentity.ImportResult{
+		Changed:   make([]string, 0),
+		Unchanged: make([]string, 0),
+	}
+	changedKeys := make([]keyentity.KV, 0)
+	for _, exported := range doc.Keys {
+		if !util.IsUnderPrefix(exported.Key, doc.Prefix) {
+			return keyentity.ImportResult{}, fmt.Errorf("Key %s is outside of prefix %s.", exported.Key, doc.Prefix)
+		}
+
+		key := prefix + strings.TrimPrefix(exported.Key, doc.Prefix)
+		if !util.IsUnderPrefix(key, prefix) {
+			return keyentity.ImportResult{}, fmt.Errorf("Key %s is outside of prefix %s.", key, prefix)
+		}
+
+		// the written key must be covered by user roles, not only the requested prefix
+		if err := u.authorize(ctx, userID, key, userentity.RoleUser); err != nil {
+			return keyentity.ImportResult{}, err
+		}
+
+		activeKey, ok := activeByKey[key]
+		valType := exported.Type
+		if valType == "" {
+			valType = activeKey.Type
+		}
+		if valType == "" {
+			valType = keyentity.TypeString
+		}
+
+		// masked secret of an export keeps the active value
+		if ok && activeKey.Type == valType && valType == keyentity.TypeSecret && exported.Value == keyentity.SecretMask {
+			result.Unchanged = append(result.Unchanged, key)
+			continue
+		}
+
+		if valType == keyentity.TypeSecret && exported.Value == keyentity.SecretMask {
+			return keyentity.ImportResult{}, fmt.Errorf("Secret value of %s is masked.", key)
+		}
+
+		importedKey := keyentity.KV{Key: key, Value: exported.Value, Type: valType}
+		if err := validateValue(importedKey); err != nil {
+			return keyentity.ImportResult{}, err
+		}
+
+		if err := u.validatePrerequisites(ctx, importedKey, false); err != nil {
+			return keyentity.ImportResult{}, err
+		}
+
+		same, err := u.sameValue(key, valType, activeKey.Value, exported.Value)
+		if err != nil {
+			return keyentity.ImportResult{}, err
+		}
+
+		if ok && same && activeKey.Type == valType {
+			result.Unchanged = append(result.Unchanged, key)
+			continue
+		}
+
+		kv, err := u.encryptSecret(keyentity.KV{
+			Key:         key,
+			Value:       exported.Value,
+			Type:        valType,
+			CreatedBy:   userID,
+			Status:      keyentity.PlacedKey,
+			Environment: keyentity.EnvironmentFromContext(ctx),
+		})
+		if err != nil {
+			return keyentity.ImportResult{}, err
+		}
+
+		changedKeys = append(changedKeys, kv)
+		result.Changed = append(result.Changed, key)
+	}
+
+	if len(changedKeys) == 0 {
+		return result, nil
+	}
+
+	if err := u.checkQuota(ctx, result.Changed); err != nil {
+		return keyentity.ImportResult{}, err
+	}
+
+	// whole change set is rejected when one of the key already has pending change
+	for _, kv := range changedKeys {
+		pending, err := u.hasPendingChange(ctx, kv.Key)
+		if err != nil {
+			return keyentity.ImportResult{}, err
+		}
+		if pending {
+			return keyentity.ImportResult{}, fmt.Errorf("Key %s already has pending change.", kv.Key)
+		}
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return keyentity.ImportResult{}, err
+	}
+	defer endTx()
+
+	description := fmt.Sprintf("Import %s into %s", doc.Prefix, prefix)
+	changeSetID, err := u.keyRepo.CreateChangeSet(ctx, tx, keyentity.ChangeSet{
+		Description: description,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		return keyentity.ImportResult{}, err
+	}
+
+	for _, kv := range changedKeys {
+		kv.ChangeSetID = changeSetID
+		if err := u.keyRepo.CreateKeyEntry(ctx, tx, kv); err != nil {
+			return keyentity.ImportResult{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return keyentity.ImportResult{}, err
+	}
+	endTx()
+
+	for _, kv := range changedKeys {
+		kv.ChangeSetID = changeSetID
+		u.logTransition(ctx, kv, userID)
+		u.notify(ctx, kv, userID, description)
+	}
+
+	result.ChangeSetID = changeSetID
+	return result, nil
+}
+
+// hasPendingChange check if key is placed, placed for delete or in canary
+func (u *Usecase) hasPendingChange(ctx context.Context, key string) (bool, error) {
+	return u.hasPendingChangeInEnvironment(ctx, keyentity.EnvironmentFromContext(ctx), key)
+}
+
+func (u *Usecase) hasPendingChangeInEnvironment(ctx context.Context, environment, key string) (bool, error) {
+	for _, status := range []int{keyentity.PlacedKey, keyentity.PlacedDeleteKey, keyentity.CanaryKey} {
+		keys, err := u.keyRepo.GetKeyInEnvironment(ctx, environment, key, status)
+		if err != nil && err != sql.ErrNoRows {
+			return false, err
+		}
+
+		if len(keys) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,94 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+var (
+	exportTestRisk = []keyentity.KV{
+		{ID: 1, Key: "service/risk/flag", Value: "true", Type: keyentity.TypeString, Status: keyentity.ApprovedAndActive},
+		{ID: 2, Key: "service/risk/rules", Value: `{"limit":10}`, Type: keyentity.TypeJSON, Status: keyentity.ApprovedAndActive},
+		{ID: 3, Key: "service/risk/token", Value: "risk token", Type: keyentity.TypeSecret, Status: keyentity.ApprovedAndActive},
+		// sibling sharing the name start is not exported
+		{ID: 4, Key: "service/risky/flag", Value: "false", Type: keyentity.TypeString, Status: keyentity.ApprovedAndActive},
+	}
+	exportTestPayment = []keyentity.KV{
+		{ID: 5, Key: "service/payment/flag", Value: "true", Type: keyentity.TypeString, Status: keyentity.ApprovedAndActive},
+		{ID: 6, Key: "service/payment/token", Value: "payment token", Type: keyentity.TypeSecret, Status: keyentity.ApprovedAndActive},
+	}
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	for _, format := range []string{keyentity.FormatJSON, keyentity.FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			notifier := &blockingNotifier{release: make(chan struct{})}
+			close(notifier.release)
+			u.SetNotifier(notifier)
+			deps.expectNoOwnership()
+			deps.expectNoPrerequisite()
+			deps.keyRepo.EXPECT().GetKeyByPrefix(gomock.Any(), "service/risk", keyentity.ApprovedAndActive).Return(exportTestRisk, nil)
+			deps.keyRepo.EXPECT().GetKeyByPrefix(gomock.Any(), "service/payment", keyentity.ApprovedAndActive).Return(exportTestPayment, nil)
+			deps.keyRepo.EXPECT().GetKeyInEnvironment(gomock.Any(), gomock.Any(), "service/payment/rules", gomock.Any()).Return(nil, nil).Times(3)
+
+			deps.db.ExpectBegin()
+			deps.keyRepo.EXPECT().CreateChangeSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(7, nil)
+			deps.keyRepo.EXPECT().CreateKeyEntry(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, tx *sql.Tx, kv keyentity.KV) error {
+				if kv.Key != "service/payment/rules" || kv.Value != `{"limit":10}` || kv.Type != keyentity.TypeJSON || kv.Status != keyentity.PlacedKey || kv.ChangeSetID != 7 {
+					t.Errorf("CreateKeyEntry() kv = %+v, want placed rules in change set 7", kv)
+				}
+				return nil
+			})
+			deps.db.ExpectCommit()
+
+			data, err := u.ExportPrefix(context.Background(), "service/risk", format, testUser)
+			if err != nil {
+				t.Fatalf("ExportPrefix() error = %v", err)
+			}
+
+			// masked secret of the export keeps the active secret of the target
+			result, err := u.ImportPrefix(context.Background(), "service/payment", format, data, testUser)
+			if err != nil {
+				t.Fatalf("ImportPrefix() error = %v", err)
+			}
+
+			want := keyentity.ImportResult{
+				ChangeSetID: 7,
+				Changed:     []string{"service/payment/rules"},
+				Unchanged:   []string{"service/payment/flag", "service/payment/token"},
+			}
+			if !reflect.DeepEqual(result, want) {
+				t.Fatalf("ImportPrefix() = %+v, want %+v", result, want)
+			}
+
+			u.WaitNotifications()
+			if len(notifier.sent) != 1 || notifier.sent[0].KV.Key != "service/payment/rules" {
+				t.Fatalf("sent = %+v, want notification of the imported key", notifier.sent)
+			}
+		})
+	}
+}
+
+func TestImportPrefixPrerequisite(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	deps.keyRepo.EXPECT().GetKeyByPrefix(gomock.Any(), "service/payment", keyentity.ApprovedAndActive).Return(exportTestPayment, nil)
+	deps.keyRepo.EXPECT().GetPrerequisites(gomock.Any()).Return([]keyentity.Prerequisite{{
+		Key:           "service/payment/rules",
+		RequiredKey:   "service/payment/enabled",
+		RequiredValue: "true",
+		FallbackValue: "{}",
+	}}, nil)
+	deps.expectKeys("service/payment/enabled", nil)
+
+	data := []byte(`{"prefix":"service/risk","keys":[{"key":"service/risk/rules","value":"{\"limit\":10}","type":"json"}]}`)
+	if _, err := u.ImportPrefix(context.Background(), "service/payment", keyentity.FormatJSON, data, testUser); err == nil {
+		t.Fatal("ImportPrefix() error = nil, want unmet prerequisite")
+	}
+}
@@ -0,0 +1,250 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+// HealthChecker report health of a canary, see internal/healthcheck for implementations.
+// ValidateTarget reject target the checker must not request, it is called before the gate is stored.
+type HealthChecker interface {
+	ValidateTarget(target string) error
+	Check(ctx context.Context, gate keyentity.CanaryGate) (keyentity.HealthResult, error)
+}
+
+// SetHealthChecker register checker to be used by canary gates with the same checker name
+func (u *Usecase) SetHealthChecker(name string, checker HealthChecker) {
+	u.healthCheckers[name] = checker
+}
+
+// SetCanaryGate attach success criteria to a key in canary.
+// The gate promotes or reverts on behalf of its creator, so only approvers of the key can set it.
+func (u *Usecase) SetCanaryGate(ctx context.Context, key string, gate keyentity.CanaryGate, userID int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.SetCanaryGate", u.timeout.Write)
+	defer finish()
+
+	if err := u.authorizeApprover(ctx, userID, key); err != nil {
+		return err
+	}
+
+	checker, ok := u.healthCheckers[gate.Checker]
+	if !ok {
+		return fmt.Errorf("Unknown health checker %s.", gate.Checker)
+	}
+
+	if gate.Target == "" {
+		return errors.New("Health check target is required.")
+	}
+
+	if err := checker.ValidateTarget(gate.Target); err != nil {
+		return fmt.Errorf("Invalid health check target, %v.", err)
+	}
+
+	if gate.MinBakeSeconds < 0 || gate.FailureThreshold < 0 {
+		return errors.New("Bake time and failure threshold can not be negative.")
+	}
+
+	if gate.FailureThreshold == 0 {
+		gate.FailureThreshold = keyentity.DefaultCanaryFailureThreshold
+	}
+
+	keyCanary, err := u.keyRepo.GetKey(ctx, key, keyentity.CanaryKey)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if len(keyCanary) == 0 {
+		return errors.New("Key is not in canary.")
+	}
+
+	gate.KeyID = keyCanary[0].ID
+	gate.Failures = 0
+	gate.StartTime = time.Now()
+	gate.CreatedBy = userID
+	gate.Status = keyentity.StatusActive
+
+	return u.keyRepo.CreateCanaryGate(ctx, gate)
+}
+
+// GetCanaryDecisions returns promote and revert decisions of the gates of the canary key
+func (u *Usecase) GetCanaryDecisions(ctx context.Context, keyID, userID int) ([]keyentity.CanaryDecision, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetCanaryDecisions", u.timeout.Read)
+	defer finish()
+
+	kv, err := u.keyRepo.GetKeyByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.authorize(ctx, userID, kv.Key, userentity.RoleUser); err != nil {
+		return nil, err
+	}
+
+	return u.keyRepo.GetCanaryDecisions(ctx, keyID)
+}
+
+// RunCanaryGates evaluate canary gates every interval until ctx is done
+func (u *Usecase) RunCanaryGates(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// error of one round should not stop the next round
+			_ = u.EvaluateCanaryGates(ctx)
+		}
+	}
+}
+
+// EvaluateCanaryGates promote healthy canaries that baked long enough and revert unhealthy ones.
+// Decision is taken on behalf of the user who set the gate.
+func (u *Usecase) EvaluateCanaryGates(ctx context.Context) error {
+	ctx, finish := u.start(ctx, "key.Usecase.EvaluateCanaryGates", u.timeout.Background)
+	defer finish()
+
+	gates, err := u.keyRepo.GetActiveCanaryGates(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	var lastErr error
+	for _, gate := range gates {
+		if err := u.evaluateCanaryGate(ctx, gate); err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+func (u *Usecase) evaluateCanaryGate(ctx context.Context, gate keyentity.CanaryGate) error {
+	canaryKey, err := u.keyRepo.GetKeyByID(ctx, gate.KeyID)
+	if err != nil {
+		return err
+	}
+
+	// canary already approved or disapproved manually
+	if canaryKey.Status != keyentity.CanaryKey {
+		return u.closeCanaryGate(ctx, gate.KeyID)
+	}
+
+	// promote or revert in environment the canary runs in
+	ctx = keyentity.WithEnvironment(ctx, canaryKey.Environment)
+
+	checker, ok := u.healthCheckers[gate.Checker]
+	if !ok {
+		return fmt.Errorf("Unknown health checker %s.", gate.Checker)
+	}
+
+	// checker error is not a health signal, retry on the next round
+	result, err := checker.Check(ctx, gate)
+	if err != nil {
+		return err
+	}
+
+	decision := keyentity.CanaryDecision{
+		KeyID:  gate.KeyID,
+		Reason: result.Reason,
+	}
+
+	// single unhealthy check may be a blip, revert only after enough of them in a row
+	failures := 0
+	if !result.Healthy {
+		failures = gate.Failures + 1
+	}
+
+	status := keyentity.ApprovedKey
+	reason := ""
+	switch {
+	case !result.Healthy && failures < gate.FailureThreshold:
+		return u.keyRepo.ModifyCanaryGateFailures(ctx, gate.KeyID, failures)
+	case !result.Healthy:
+		decision.Decision = keyentity.CanaryDecisionReverted
+		status = keyentity.DissaprovedKey
+		reason = fmt.Sprintf("Reverted by %s canary gate.", gate.Checker)
+		if result.Reason != "" {
+			reason += " " + result.Reason
+		}
+	case time.Since(gate.StartTime) >= gate.MinBakeTime():
+		decision.Decision = keyentity.CanaryDecisionPromoted
+	case gate.Failures > 0:
+		// healthy but still baking, earlier failures are no longer in a row
+		return u.keyRepo.ModifyCanaryGateFailures(ctx, gate.KeyID, 0)
+	default:
+		// healthy but still baking
+		return nil
+	}
+
+	if err := u.authorizeApprover(ctx, gate.CreatedBy, canaryKey.Key); err != nil {
+		return err
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer endTx()
+
+	// the key, gate status and decision are committed together, so a decided canary never keeps an active gate
+	if err := u.ApproveKeyWithTx(ctx, tx, canaryKey.Key, gate.CreatedBy, status, reason); err != nil {
+		return err
+	}
+
+	if err := u.keyRepo.ModifyCanaryGateStatus(ctx, tx, gate.KeyID, keyentity.StatusInactive); err != nil {
+		return err
+	}
+
+	if err := u.keyRepo.CreateCanaryDecision(ctx, tx, decision); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	u.invalidateCanaryCache(ctx, canaryKey.Key)
+
+	decidedKey := canaryKey
+	decidedKey.ApprovedBy = gate.CreatedBy
+	decidedKey.Status = keyentity.ApprovedAndActive
+	if status == keyentity.DissaprovedKey {
+		decidedKey.Status = keyentity.DissaprovedKey
+	}
+	u.logTransition(ctx, decidedKey, gate.CreatedBy)
+	u.notify(ctx, decidedKey, gate.CreatedBy, reason)
+
+	u.log.InfoContext(ctx, "canary gate decided",
+		slog.String("key", canaryKey.Key),
+		slog.Int("actor", gate.CreatedBy),
+		slog.String("decision", decision.Decision),
+		slog.String("reason", decision.Reason),
+	)
+
+	return nil
+}
+
+// closeCanaryGate deactivate gate of a canary that is no longer in canary
+func (u *Usecase) closeCanaryGate(ctx context.Context, keyID int) error {
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer endTx()
+
+	if err := u.keyRepo.ModifyCanaryGateStatus(ctx, tx, keyID, keyentity.StatusInactive); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,311 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+// stubChecker returns the results in order, the last one is repeated
+type stubChecker struct {
+	results []keyentity.HealthResult
+	err     error
+	calls   int
+}
+
+func (c *stubChecker) ValidateTarget(target string) error {
+	return nil
+}
+
+func (c *stubChecker) Check(ctx context.Context, gate keyentity.CanaryGate) (keyentity.HealthResult, error) {
+	if c.err != nil {
+		return keyentity.HealthResult{}, c.err
+	}
+
+	result := c.results[len(c.results)-1]
+	if c.calls < len(c.results) {
+		result = c.results[c.calls]
+	}
+	c.calls++
+
+	return result, nil
+}
+
+var (
+	healthy   = keyentity.HealthResult{Healthy: true}
+	unhealthy = keyentity.HealthResult{Healthy: false, Reason: "error rate is above threshold"}
+)
+
+const gateTestKey = "service/risk/sauron/flag"
+
+// setupGate put gateTestKey in canary with the gate, the returned gate is what the repository stores
+func setupGate(t *testing.T, checker *stubChecker, gate keyentity.CanaryGate) (*Usecase, *testDeps, *keyentity.CanaryGate) {
+	t.Helper()
+
+	u, deps := newTestUsecase(t)
+	u.SetHealthChecker("stub", checker)
+	deps.expectNoOwnership()
+
+	canaryKey := keyentity.KV{ID: 2, Key: gateTestKey, Value: "true", Status: keyentity.CanaryKey}
+	deps.expectKeys(gateTestKey, map[int][]keyentity.KV{
+		keyentity.ApprovedAndActive: {{ID: 1, Key: gateTestKey, Value: "false", Status: keyentity.ApprovedAndActive}},
+		keyentity.CanaryKey:         {canaryKey},
+	})
+	deps.keyRepo.EXPECT().GetKeyByID(gomock.Any(), canaryKey.ID).Return(canaryKey, nil).AnyTimes()
+
+	stored := &keyentity.CanaryGate{}
+	deps.keyRepo.EXPECT().CreateCanaryGate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, gate keyentity.CanaryGate) error {
+		*stored = gate
+		return nil
+	})
+	deps.keyRepo.EXPECT().GetActiveCanaryGates(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]keyentity.CanaryGate, error) {
+		if stored.Status != keyentity.StatusActive {
+			return nil, sql.ErrNoRows
+		}
+		return []keyentity.CanaryGate{*stored}, nil
+	}).AnyTimes()
+	deps.keyRepo.EXPECT().ModifyCanaryGateFailures(gomock.Any(), canaryKey.ID, gomock.Any()).DoAndReturn(func(ctx context.Context, keyID, failures int) error {
+		stored.Failures = failures
+		return nil
+	}).AnyTimes()
+
+	gate.Checker = "stub"
+	gate.Target = "error_rate"
+	if err := u.SetCanaryGate(context.Background(), gateTestKey, gate, testAdmin); err != nil {
+		t.Fatalf("SetCanaryGate() error = %v", err)
+	}
+
+	return u, deps, stored
+}
+
+// expectDecision expect the gate to be closed with the decision before the approval is committed,
+// the recorded decision is returned
+func expectDecision(t *testing.T, deps *testDeps, stored *keyentity.CanaryGate) *keyentity.CanaryDecision {
+	t.Helper()
+
+	inTx := func(tx *sql.Tx) {
+		if tx == nil || deps.db.ExpectationsWereMet() == nil {
+			t.Errorf("canary gate written outside of the approval transaction")
+		}
+	}
+
+	deps.expectNoOwnership()
+	deps.keyRepo.EXPECT().ModifyCanaryKey(gomock.Any(), gomock.Any(), stored.KeyID, keyentity.StatusInactive).Return(nil)
+	deps.keyRepo.EXPECT().InvalidateCanaryCache(gomock.Any(), gateTestKey).Return(nil)
+	deps.keyRepo.EXPECT().ModifyCanaryGateStatus(gomock.Any(), gomock.Any(), stored.KeyID, keyentity.StatusInactive).DoAndReturn(func(ctx context.Context, tx *sql.Tx, keyID, status int) error {
+		inTx(tx)
+		stored.Status = status
+		return nil
+	})
+
+	decision := &keyentity.CanaryDecision{}
+	deps.keyRepo.EXPECT().CreateCanaryDecision(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, tx *sql.Tx, d keyentity.CanaryDecision) error {
+		inTx(tx)
+		*decision = d
+		return nil
+	})
+
+	return decision
+}
+
+func evaluate(t *testing.T, u *Usecase, rounds int) {
+	t.Helper()
+
+	for i := 0; i < rounds; i++ {
+		if err := u.EvaluateCanaryGates(context.Background()); err != nil {
+			t.Fatalf("EvaluateCanaryGates() round %d error = %v", i+1, err)
+		}
+	}
+}
+
+func TestEvaluateCanaryGatesRevertAfterConsecutiveFailures(t *testing.T) {
+	checker := &stubChecker{results: []keyentity.HealthResult{unhealthy}}
+	u, deps, stored := setupGate(t, checker, keyentity.CanaryGate{MinBakeSeconds: 3600, FailureThreshold: 3})
+
+	// the key is not touched before the threshold
+	evaluate(t, u, 2)
+	if stored.Failures != 2 {
+		t.Fatalf("failures = %d, want 2", stored.Failures)
+	}
+
+	decision := expectDecision(t, deps, stored)
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), stored.KeyID, gomock.Any()).DoAndReturn(func(ctx context.Context, tx *sql.Tx, keyID int, kv keyentity.KV) error {
+		if kv.Status != keyentity.DissaprovedKey {
+			t.Fatalf("status after 3 failures = %d, want disapproved", kv.Status)
+		}
+		return nil
+	})
+	deps.keyRepo.EXPECT().CreateComment(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
+	deps.db.ExpectCommit()
+
+	evaluate(t, u, 1)
+	if stored.Status != keyentity.StatusInactive {
+		t.Fatalf("gate status = %d, want inactive", stored.Status)
+	}
+	if decision.Decision != keyentity.CanaryDecisionReverted {
+		t.Fatalf("decision = %+v, want reverted", decision)
+	}
+}
+
+func TestEvaluateCanaryGatesHealthyCheckResetFailures(t *testing.T) {
+	checker := &stubChecker{results: []keyentity.HealthResult{unhealthy, unhealthy, healthy, unhealthy, unhealthy}}
+	u, _, stored := setupGate(t, checker, keyentity.CanaryGate{MinBakeSeconds: 3600, FailureThreshold: 3})
+
+	// failures were not in a row, so the key is not reverted
+	evaluate(t, u, 5)
+	if stored.Failures != 2 {
+		t.Fatalf("failures = %d, want 2", stored.Failures)
+	}
+}
+
+func TestEvaluateCanaryGatesPromoteAfterBakeTime(t *testing.T) {
+	checker := &stubChecker{results: []keyentity.HealthResult{healthy}}
+	u, deps, stored := setupGate(t, checker, keyentity.CanaryGate{MinBakeSeconds: 60})
+
+	// still baking, the key is not touched
+	evaluate(t, u, 1)
+
+	stored.StartTime = time.Now().Add(-time.Minute)
+	decision := expectDecision(t, deps, stored)
+	deps.expectNoPrerequisite()
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), stored.KeyID, gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().ModifyOldActiveKey(gomock.Any(), gomock.Any(), gateTestKey).Return(nil)
+	deps.keyRepo.EXPECT().CreateKeyEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().SetCache(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, kv keyentity.KV) error {
+		if kv.Value != "true" || kv.Status != keyentity.ApprovedAndActive {
+			t.Fatalf("cached = %+v, want active canary value", kv)
+		}
+		return nil
+	})
+	deps.db.ExpectCommit()
+
+	evaluate(t, u, 1)
+	if decision.Decision != keyentity.CanaryDecisionPromoted {
+		t.Fatalf("decision = %+v, want promoted", decision)
+	}
+}
+
+func TestEvaluateCanaryGatesCheckerErrorIsNoSignal(t *testing.T) {
+	checker := &stubChecker{err: errors.New("query returns NaN")}
+	u, _, stored := setupGate(t, checker, keyentity.CanaryGate{FailureThreshold: 1})
+
+	if err := u.EvaluateCanaryGates(context.Background()); err == nil {
+		t.Fatal("EvaluateCanaryGates() error = nil, want checker error")
+	}
+	if stored.Failures != 0 {
+		t.Fatalf("failures = %d, want 0", stored.Failures)
+	}
+}
+
+func TestSetCanaryGateDefaultFailureThreshold(t *testing.T) {
+	checker := &stubChecker{results: []keyentity.HealthResult{healthy}}
+	_, _, stored := setupGate(t, checker, keyentity.CanaryGate{MinBakeSeconds: 90})
+
+	if stored.FailureThreshold != keyentity.DefaultCanaryFailureThreshold {
+		t.Fatalf("failure threshold = %d, want %d", stored.FailureThreshold, keyentity.DefaultCanaryFailureThreshold)
+	}
+	if stored.MinBakeTime() != 90*time.Second {
+		t.Fatalf("MinBakeTime() = %v, want 1m30s", stored.MinBakeTime())
+	}
+}
+
+func TestEvaluateCanaryGatesRollbackOnDecisionError(t *testing.T) {
+	checker := &stubChecker{results: []keyentity.HealthResult{unhealthy}}
+	u, deps, stored := setupGate(t, checker, keyentity.CanaryGate{FailureThreshold: 1})
+
+	deps.expectNoOwnership()
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().ModifyCanaryKey(gomock.Any(), gomock.Any(), stored.KeyID, keyentity.StatusInactive).Return(nil)
+	deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), stored.KeyID, gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().CreateComment(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
+	deps.keyRepo.EXPECT().ModifyCanaryGateStatus(gomock.Any(), gomock.Any(), stored.KeyID, keyentity.StatusInactive).Return(nil)
+	deps.keyRepo.EXPECT().CreateCanaryDecision(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+	deps.db.ExpectRollback()
+
+	// revert is rolled back with the decision, the gate decides again on the next round
+	if err := u.EvaluateCanaryGates(context.Background()); err == nil {
+		t.Fatal("EvaluateCanaryGates() error = nil, want decision error")
+	}
+	if stored.Status != keyentity.StatusActive {
+		t.Fatalf("gate status = %d, want active", stored.Status)
+	}
+}
+
+func TestSetCanaryGateOnlyByApprover(t *testing.T) {
+	const testOther = 4
+
+	tests := []struct {
+		name    string
+		userID  int
+		wantErr error
+	}{
+		{name: "user of the key", userID: testUser, wantErr: userentity.ErrForbidden},
+		{name: "superuser not owning the key", userID: testOther, wantErr: keyentity.ErrNotApprover},
+		{name: "lead of the key", userID: testLead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			u.SetHealthChecker("stub", &stubChecker{results: []keyentity.HealthResult{healthy}})
+			deps.access[testOther] = []userentity.Role{{ID: 4, Prefix: "service", Permission: userentity.RoleSuperUser}}
+			deps.keyRepo.EXPECT().GetOwnerships(gomock.Any()).Return([]keyentity.Ownership{{
+				ID:     1,
+				Prefix: "service/risk",
+				Owners: []keyentity.Owner{{Kind: keyentity.OwnerKindUser, Name: "user"}},
+			}}, nil).AnyTimes()
+			if tt.wantErr == nil {
+				deps.expectKeys(gateTestKey, map[int][]keyentity.KV{
+					keyentity.CanaryKey: {{ID: 2, Key: gateTestKey, Value: "true", Status: keyentity.CanaryKey}},
+				})
+				deps.keyRepo.EXPECT().CreateCanaryGate(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			err := u.SetCanaryGate(context.Background(), gateTestKey, keyentity.CanaryGate{Checker: "stub", Target: "error_rate"}, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetCanaryGate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetCanaryDecisionsAuthorize(t *testing.T) {
+	const testOutsider = 4
+
+	tests := []struct {
+		name    string
+		userID  int
+		wantErr error
+	}{
+		{name: "user of the key", userID: testUser},
+		{name: "user of another prefix", userID: testOutsider, wantErr: userentity.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			deps.access[testOutsider] = []userentity.Role{{ID: 4, Prefix: "payment", Permission: userentity.RoleAdmin}}
+			deps.keyRepo.EXPECT().GetKeyByID(gomock.Any(), 2).Return(keyentity.KV{ID: 2, Key: gateTestKey, Status: keyentity.ApprovedKey}, nil)
+			if tt.wantErr == nil {
+				deps.keyRepo.EXPECT().GetCanaryDecisions(gomock.Any(), 2).Return([]keyentity.CanaryDecision{{KeyID: 2}}, nil)
+			}
+
+			decisions, err := u.GetCanaryDecisions(context.Background(), 2, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetCanaryDecisions() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && len(decisions) != 1 {
+				t.Fatalf("GetCanaryDecisions() = %v, want one decision", decisions)
+			}
+		})
+	}
+}
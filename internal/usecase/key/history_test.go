@@ -0,0 +1,218 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+// historyRows is the history of service/risk with rows of the sibling service/risk2 in between, newest first
+func historyRows(start time.Time) []keyentity.KV {
+	at := func(hours int) time.Time { return start.Add(time.Duration(hours) * time.Hour) }
+
+	return []keyentity.KV{
+		{ID: 10, Key: "service/risk2/x", Status: keyentity.ApprovedKey, CreatedBy: testUser, ApprovedBy: testAdmin, CreateTime: at(10)},
+		{ID: 9, Key: "service/risk2/y", Status: keyentity.ApprovedKey, CreatedBy: testUser, ApprovedBy: testAdmin, CreateTime: at(9)},
+		{ID: 8, Key: "service/risk/a", Status: keyentity.PlacedKey, CreatedBy: testUser, CreateTime: at(8)},
+		{ID: 7, Key: "service/risk2/z", Status: keyentity.ApprovedKey, CreatedBy: testUser, ApprovedBy: testAdmin, CreateTime: at(7)},
+		{ID: 6, Key: "service/risk/b", Status: keyentity.ApprovedKey, CreatedBy: testLead, ApprovedBy: testAdmin, CreateTime: at(6)},
+		{ID: 5, Key: "service/risk/a", Status: keyentity.ApprovedKey, CreatedBy: testUser, ApprovedBy: testLead, CreateTime: at(5)},
+		{ID: 4, Key: "service/risk2/x", Status: keyentity.ApprovedKey, CreatedBy: testUser, ApprovedBy: testAdmin, CreateTime: at(4)},
+		{ID: 3, Key: "service/risk2/x", Status: keyentity.ApprovedKey, CreatedBy: testUser, ApprovedBy: testAdmin, CreateTime: at(3)},
+		{ID: 2, Key: "service/risk/b", Status: keyentity.DissaprovedKey, CreatedBy: testUser, ApprovedBy: testAdmin, CreateTime: at(2)},
+		{ID: 1, Key: "service/risk/a", Status: keyentity.ApprovedKey, CreatedBy: testUser, ApprovedBy: testAdmin, CreateTime: at(1)},
+	}
+}
+
+// fakeKeyHistory works like the repository query, the prefix is matched as a string
+func fakeKeyHistory(rows []keyentity.KV) func(ctx context.Context, key string, isPrefix bool, filter keyentity.HistoryFilter, beforeID, limit int) ([]keyentity.KV, error) {
+	return func(ctx context.Context, key string, isPrefix bool, filter keyentity.HistoryFilter, beforeID, limit int) ([]keyentity.KV, error) {
+		result := make([]keyentity.KV, 0)
+		for _, kv := range rows {
+			switch {
+			case isPrefix && !strings.HasPrefix(kv.Key, key), !isPrefix && kv.Key != key:
+				continue
+			case beforeID > 0 && kv.ID >= beforeID:
+				continue
+			case len(filter.Statuses) > 0 && !containsInt(filter.Statuses, kv.Status):
+				continue
+			case filter.CreatedBy > 0 && kv.CreatedBy != filter.CreatedBy:
+				continue
+			case filter.ApprovedBy > 0 && kv.ApprovedBy != filter.ApprovedBy:
+				continue
+			case !filter.From.IsZero() && kv.CreateTime.Before(filter.From):
+				continue
+			case !filter.To.IsZero() && kv.CreateTime.After(filter.To):
+				continue
+			}
+
+			result = append(result, kv)
+			if len(result) == limit {
+				break
+			}
+		}
+
+		return result, nil
+	}
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGetHistoryKeyPages(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	rows := historyRows(start)
+
+	tests := []struct {
+		name      string
+		key       string
+		isPrefix  bool
+		filter    keyentity.HistoryFilter
+		wantPages [][]int
+	}{
+		{
+			name:      "prefix skips sibling prefix",
+			key:       "service/risk",
+			isPrefix:  true,
+			filter:    keyentity.HistoryFilter{Limit: 2},
+			wantPages: [][]int{{8, 6}, {5, 2}, {1}},
+		},
+		{
+			name:      "exact key",
+			key:       "service/risk/a",
+			filter:    keyentity.HistoryFilter{Limit: 2},
+			wantPages: [][]int{{8, 5}, {1}},
+		},
+		{
+			name:      "created by",
+			key:       "service/risk",
+			isPrefix:  true,
+			filter:    keyentity.HistoryFilter{CreatedBy: testUser, Limit: 3},
+			wantPages: [][]int{{8, 5, 2}, {1}},
+		},
+		{
+			name:      "approved by",
+			key:       "service/risk",
+			isPrefix:  true,
+			filter:    keyentity.HistoryFilter{ApprovedBy: testAdmin, Limit: 2},
+			wantPages: [][]int{{6, 2}, {1}},
+		},
+		{
+			name:      "statuses",
+			key:       "service/risk",
+			isPrefix:  true,
+			filter:    keyentity.HistoryFilter{Statuses: []int{keyentity.ApprovedKey}, Limit: 1},
+			wantPages: [][]int{{6}, {5}, {1}},
+		},
+		{
+			name:      "time window",
+			key:       "service/risk",
+			isPrefix:  true,
+			filter:    keyentity.HistoryFilter{From: start.Add(2 * time.Hour), To: start.Add(6 * time.Hour)},
+			wantPages: [][]int{{6, 5, 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			deps.keyRepo.EXPECT().GetKeyHistory(gomock.Any(), tt.key, tt.isPrefix, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(fakeKeyHistory(rows)).AnyTimes()
+			deps.keyRepo.EXPECT().GetComments(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows).AnyTimes()
+
+			filter := tt.filter
+			pages := make([][]int, 0)
+			for {
+				page, err := u.GetHistoryKey(context.Background(), tt.key, tt.isPrefix, filter, testUser)
+				if err != nil {
+					t.Fatalf("GetHistoryKey() error = %v", err)
+				}
+
+				ids := make([]int, 0, len(page.Items))
+				for _, kv := range page.Items {
+					ids = append(ids, kv.ID)
+				}
+				pages = append(pages, ids)
+
+				if page.NextCursor == "" {
+					break
+				}
+				if len(pages) > len(rows) {
+					t.Fatalf("GetHistoryKey() never ends, pages %v", pages)
+				}
+				filter.Cursor = page.NextCursor
+			}
+
+			if !reflect.DeepEqual(pages, tt.wantPages) {
+				t.Fatalf("GetHistoryKey() pages = %v, want %v", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestGetHistoryKeyInvalidCursor(t *testing.T) {
+	u, _ := newTestUsecase(t)
+
+	_, err := u.GetHistoryKey(context.Background(), "service/risk", true, keyentity.HistoryFilter{Cursor: "not a cursor"}, testUser)
+	if err == nil {
+		t.Fatal("GetHistoryKey() error = nil, want invalid cursor")
+	}
+}
+
+func TestBrowseKeysFlatSkipsSiblingPrefix(t *testing.T) {
+	keys := []string{"service/risk-old/a", "service/risk-old/b", "service/risk/a", "service/risk/b", "service/risk2/a", "service/risk2/b", "service/risk/c"}
+	sort.Strings(keys)
+
+	u, deps := newTestUsecase(t)
+	deps.keyRepo.EXPECT().GetKeyListWithoutValuePage(gomock.Any(), "service/risk", gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, prefix, afterKey string, limit int) ([]string, error) {
+		result := make([]string, 0)
+		for _, key := range keys {
+			if strings.HasPrefix(key, prefix) && key > afterKey && len(result) < limit {
+				result = append(result, key)
+			}
+		}
+		return result, nil
+	}).AnyTimes()
+	deps.keyRepo.EXPECT().GetKeyMetadata(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	opts := keyentity.BrowseOptions{Limit: 2}
+	pages := make([][]string, 0)
+	for {
+		page, err := u.BrowseKeys(context.Background(), "service/risk", opts, testUser)
+		if err != nil {
+			t.Fatalf("BrowseKeys() error = %v", err)
+		}
+
+		names := make([]string, 0, len(page.Nodes))
+		for _, node := range page.Nodes {
+			names = append(names, node.Name)
+		}
+		pages = append(pages, names)
+
+		if page.NextCursor == "" {
+			break
+		}
+		if len(pages) > len(keys) {
+			t.Fatalf("BrowseKeys() never ends, pages %v", pages)
+		}
+		opts.Cursor = page.NextCursor
+	}
+
+	want := [][]string{{"service/risk/a", "service/risk/b"}, {"service/risk/c"}}
+	if !reflect.DeepEqual(pages, want) {
+		t.Fatalf("BrowseKeys() pages = %v, want %v", pages, want)
+	}
+}
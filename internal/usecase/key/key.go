@@ -3,29 +3,71 @@ package key
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
+	"log/slog"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/marde12345/key-flag/internal/config"
 	keyentity "github.com/marde12345/key-flag/internal/entity/key"
 	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/logger"
+	"github.com/marde12345/key-flag/internal/metrics"
+	"github.com/marde12345/key-flag/internal/secret"
+	"github.com/marde12345/key-flag/internal/util"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
 )
 
 type Usecase struct {
-	keyRepo  keyRepository
-	userRepo userRepository
+	keyRepo        keyRepository
+	userRepo       userRepository
+	healthCheckers map[string]HealthChecker
+	reads          *readRecorder
+	timeout        config.Timeout
+	log            *logger.Logger
+	keyring        *secret.Keyring
+	notifier       Notifier
+	notifySlots    chan struct{}
+	notifications  sync.WaitGroup
 }
 
 func New(key keyRepository, user userRepository) *Usecase {
 	return &Usecase{
-		keyRepo:  key,
-		userRepo: user,
+		keyRepo:        tracedKeyRepository{repo: key},
+		userRepo:       tracedUserRepository{repo: user},
+		healthCheckers: make(map[string]HealthChecker),
+		reads:          newReadRecorder(keyentity.DefaultReadSampleRate, keyentity.DefaultMaxPendingReads),
+		timeout:        defaultTimeout,
+		log:            logger.Nop(),
+		notifySlots:    make(chan struct{}, maxPendingNotifications),
 	}
 }
 
-func (u *Usecase) UpdateKey(kv keyentity.KV) error {
-	ctx := context.Background()
+// UpdateKey place new value of the key, baseID is id of the active row the change is based on
+// or 0 when the key has no active value. Change based on stale version is rejected with keyentity.ErrStaleBase.
+// Non empty justification is stored as the first comment of the placed row.
+func (u *Usecase) UpdateKey(ctx context.Context, kv keyentity.KV, baseID int, justification string) error {
+	ctx, finish := u.start(ctx, "key.Usecase.UpdateKey", u.timeout.Write)
+	defer finish()
+
+	if err := u.authorize(ctx, kv.CreatedBy, kv.Key, userentity.RoleUser); err != nil {
+		return err
+	}
+
+	if err := validateValue(kv); err != nil {
+		return err
+	}
+
+	if err := u.checkQuota(ctx, []string{kv.Key}); err != nil {
+		return err
+	}
 
 	//get requested update key first
 	keyWaitingApprovalUpdate, err := u.keyRepo.GetKey(ctx, kv.Key, keyentity.PlacedKey)
@@ -35,8 +77,9 @@ func (u *Usecase) UpdateKey(kv keyentity.KV) error {
 		}
 	}
 
+	// placed value can be amended or withdrawn instead
 	if len(keyWaitingApprovalUpdate) >= 1 {
-		return errors.New("Detected more than one pending approval key")
+		return keyentity.ErrPendingChange
 	}
 
 	//get requested delete key
@@ -48,7 +91,7 @@ func (u *Usecase) UpdateKey(kv keyentity.KV) error {
 	}
 
 	if len(keyWaitingApprovalDelete) >= 1 {
-		return errors.New("Detected more than one pending approval key")
+		return keyentity.ErrPendingChange
 	}
 
 	keyInCanary, err := u.keyRepo.GetKey(ctx, kv.Key, keyentity.CanaryKey)
@@ -62,23 +105,59 @@ func (u *Usecase) UpdateKey(kv keyentity.KV) error {
 		return errors.New("Can not change value in canary.")
 	}
 
-	tx, err := u.keyRepo.GetDBTx(ctx, nil)
+	activeKey, found, err := u.activeKey(ctx, kv.Key)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
+
+	if (found && activeKey.ID != baseID) || (!found && baseID != 0) {
+		return keyentity.ErrStaleBase
+	}
+
+	if err := u.validatePrerequisites(ctx, kv, false); err != nil {
+		return err
+	}
+
+	kv, err = u.encryptSecret(kv)
+	if err != nil {
+		return err
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer endTx()
 
 	// all keys that newly updated will have placed status
-	err = u.keyRepo.CreateKey(ctx, tx, kv.Key, kv.Value, kv.Type, kv.CreatedBy, keyentity.PlacedKey)
+	keyID, err := u.keyRepo.CreateKey(ctx, tx, kv.Key, kv.Value, kv.Type, kv.CreatedBy, keyentity.PlacedKey)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	if err := u.createComment(ctx, tx, keyID, keyentity.CommentKindJustification, justification, kv.CreatedBy); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	placedKey := keyentity.KV{Key: kv.Key, Value: kv.Value, Type: kv.Type, Status: keyentity.PlacedKey, Environment: keyentity.EnvironmentFromContext(ctx)}
+	u.logTransition(ctx, placedKey, kv.CreatedBy)
+	u.notify(ctx, placedKey, kv.CreatedBy, justification)
+	return nil
 }
 
-func (u *Usecase) CreateDeleteKey(kv keyentity.KV) error {
-	ctx := context.Background()
+// CreateDeleteKey place delete request of the key, non empty justification is stored as its first comment
+func (u *Usecase) CreateDeleteKey(ctx context.Context, kv keyentity.KV, justification string) error {
+	ctx, finish := u.start(ctx, "key.Usecase.CreateDeleteKey", u.timeout.Write)
+	defer finish()
+
+	if err := u.authorize(ctx, kv.CreatedBy, kv.Key, userentity.RoleUser); err != nil {
+		return err
+	}
 
 	//get requested update key first
 	keyWaitingApprovalUpdate, err := u.keyRepo.GetKey(ctx, kv.Key, keyentity.PlacedKey)
@@ -88,8 +167,9 @@ func (u *Usecase) CreateDeleteKey(kv keyentity.KV) error {
 		}
 	}
 
+	// placed value can be amended or withdrawn instead
 	if len(keyWaitingApprovalUpdate) >= 1 {
-		return errors.New("Detected more than one pending approval key")
+		return keyentity.ErrPendingChange
 	}
 
 	//get requested delete key
@@ -101,7 +181,7 @@ func (u *Usecase) CreateDeleteKey(kv keyentity.KV) error {
 	}
 
 	if len(keyWaitingApprovalDelete) >= 1 {
-		return errors.New("Detected more than one pending approval key")
+		return keyentity.ErrPendingChange
 	}
 
 	keyInCanary, err := u.keyRepo.GetKey(ctx, kv.Key, keyentity.CanaryKey)
@@ -115,23 +195,51 @@ func (u *Usecase) CreateDeleteKey(kv keyentity.KV) error {
 		return errors.New("Can not delete value in canary.")
 	}
 
-	tx, err := u.keyRepo.GetDBTx(ctx, nil)
+	if err := u.validatePrerequisites(ctx, kv, true); err != nil {
+		return err
+	}
+
+	kv, err = u.encryptSecret(kv)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer endTx()
 
 	// all keys that newly updated will have placedDelete status
-	err = u.keyRepo.CreateKey(ctx, tx, kv.Key, kv.Value, kv.Type, kv.CreatedBy, keyentity.PlacedDeleteKey)
+	keyID, err := u.keyRepo.CreateKey(ctx, tx, kv.Key, kv.Value, kv.Type, kv.CreatedBy, keyentity.PlacedDeleteKey)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	if err := u.createComment(ctx, tx, keyID, keyentity.CommentKindJustification, justification, kv.CreatedBy); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	placedKey := keyentity.KV{Key: kv.Key, Value: kv.Value, Type: kv.Type, Status: keyentity.PlacedDeleteKey, Environment: keyentity.EnvironmentFromContext(ctx)}
+	u.logTransition(ctx, placedKey, kv.CreatedBy)
+	u.notify(ctx, placedKey, kv.CreatedBy, justification)
+	return nil
 }
 
-func (u *Usecase) ApproveKeyWithTx(tx *sql.Tx, key string, userID, status int) error {
-	ctx := context.Background()
+// ApproveKeyWithTx approve or disapprove placed or canary key inside tx, reason is required to disapprove.
+// Canary index of a canary key is not invalidated, the caller invalidates it once tx is committed.
+func (u *Usecase) ApproveKeyWithTx(ctx context.Context, tx *sql.Tx, key string, userID, status int, reason string) error {
+	ctx, finish := u.start(ctx, "key.Usecase.ApproveKeyWithTx", u.timeout.Write)
+	defer finish()
+
+	if err := validateReason(status, reason); err != nil {
+		return err
+	}
 
 	// check if keys placed if no keys placed return error
 	keyPlaced, err := u.keyRepo.GetKey(ctx, key, keyentity.PlacedKey)
@@ -163,6 +271,19 @@ func (u *Usecase) ApproveKeyWithTx(tx *sql.Tx, key string, userID, status int) e
 	modifiedKey.ApprovedBy = userID
 	modifiedKey.UpdateTime = time.Now()
 
+	// active value of related keys may change since the key was placed
+	if status != keyentity.DissaprovedKey {
+		// prerequisites are written against plain values
+		approvedKey, err := u.decryptSecret(modifiedKey)
+		if err != nil {
+			return err
+		}
+
+		if err := u.validatePrerequisites(ctx, approvedKey, false); err != nil {
+			return err
+		}
+	}
+
 	// Destroy all canary ip if any
 	if modifiedKey.Status == keyentity.CanaryKey {
 		if err := u.keyRepo.ModifyCanaryKey(ctx, tx, modifiedKey.ID, keyentity.StatusInactive); err != nil {
@@ -172,7 +293,11 @@ func (u *Usecase) ApproveKeyWithTx(tx *sql.Tx, key string, userID, status int) e
 
 	if status == keyentity.DissaprovedKey {
 		modifiedKey.Status = keyentity.DissaprovedKey
-		return u.keyRepo.ModifyKey(ctx, tx, modifiedKey.ID, modifiedKey)
+		if err := u.keyRepo.ModifyKey(ctx, tx, modifiedKey.ID, modifiedKey); err != nil {
+			return err
+		}
+
+		return u.createComment(ctx, tx, modifiedKey.ID, keyentity.CommentKindDisapproval, reason, userID)
 	}
 
 	// modify current key to approved status and create new approved and active status
@@ -194,11 +319,26 @@ func (u *Usecase) ApproveKeyWithTx(tx *sql.Tx, key string, userID, status int) e
 		return err
 	}
 
-	return u.keyRepo.SetCache(ctx, modifiedKey)
+	cachedKey, err := u.cachedKey(modifiedKey)
+	if err != nil {
+		return err
+	}
+
+	return u.keyRepo.SetCache(ctx, cachedKey)
 }
 
-func (u *Usecase) ApproveKey(key string, userID, status int) error {
-	ctx := context.Background()
+// ApproveKey approve or disapprove placed or canary key, reason is required to disapprove
+func (u *Usecase) ApproveKey(ctx context.Context, key string, userID, status int, reason string) error {
+	ctx, finish := u.start(ctx, "key.Usecase.ApproveKey", u.timeout.Write)
+	defer finish()
+
+	if err := u.authorizeApprover(ctx, userID, key); err != nil {
+		return err
+	}
+
+	if err := validateReason(status, reason); err != nil {
+		return err
+	}
 
 	// check if keys placed if no keys placed return error
 	keyPlaced, err := u.keyRepo.GetKey(ctx, key, keyentity.PlacedKey)
@@ -230,14 +370,28 @@ func (u *Usecase) ApproveKey(key string, userID, status int) error {
 	modifiedKey.ApprovedBy = userID
 	modifiedKey.UpdateTime = time.Now()
 
-	tx, err := u.keyRepo.GetDBTx(ctx, nil)
+	// active value of related keys may change since the key was placed
+	if status != keyentity.DissaprovedKey {
+		// prerequisites are written against plain values
+		approvedKey, err := u.decryptSecret(modifiedKey)
+		if err != nil {
+			return err
+		}
+
+		if err := u.validatePrerequisites(ctx, approvedKey, false); err != nil {
+			return err
+		}
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
+	defer endTx()
 
 	// Destroy all canary ip if any
-	if modifiedKey.Status == keyentity.CanaryKey {
+	wasCanary := modifiedKey.Status == keyentity.CanaryKey
+	if wasCanary {
 		if err := u.keyRepo.ModifyCanaryKey(ctx, tx, modifiedKey.ID, keyentity.StatusInactive); err != nil {
 			return err
 		}
@@ -251,7 +405,22 @@ func (u *Usecase) ApproveKey(key string, userID, status int) error {
 			return err
 		}
 
-		return tx.Commit()
+		if err := u.createComment(ctx, tx, modifiedKey.ID, keyentity.CommentKindDisapproval, reason, userID); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		endTx()
+
+		if wasCanary {
+			u.invalidateCanaryCache(ctx, modifiedKey.Key)
+		}
+
+		u.logTransition(ctx, modifiedKey, userID)
+		u.notify(ctx, modifiedKey, userID, reason)
+		return nil
 	}
 
 	// modify current key to approved status and create new approved and active status
@@ -273,16 +442,42 @@ func (u *Usecase) ApproveKey(key string, userID, status int) error {
 		return err
 	}
 
-	err = u.keyRepo.SetCache(ctx, modifiedKey)
+	cachedKey, err := u.cachedKey(modifiedKey)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	err = u.keyRepo.SetCache(ctx, cachedKey)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	if wasCanary {
+		u.invalidateCanaryCache(ctx, modifiedKey.Key)
+	}
+
+	u.logTransition(ctx, modifiedKey, userID)
+	u.notify(ctx, modifiedKey, userID, "")
+	return nil
 }
 
-func (u *Usecase) ApproveDeleteKey(key string, userID, status int) error {
-	ctx := context.Background()
+// ApproveDeleteKey approve or disapprove placed delete key, reason is required to disapprove
+func (u *Usecase) ApproveDeleteKey(ctx context.Context, key string, userID, status int, reason string) error {
+	ctx, finish := u.start(ctx, "key.Usecase.ApproveDeleteKey", u.timeout.Write)
+	defer finish()
+
+	if err := u.authorizeApprover(ctx, userID, key); err != nil {
+		return err
+	}
+
+	if err := validateReason(status, reason); err != nil {
+		return err
+	}
 
 	// check if keys placed if no keys placed return error
 	keyPlaced, err := u.keyRepo.GetKey(ctx, key, keyentity.PlacedDeleteKey)
@@ -304,14 +499,21 @@ func (u *Usecase) ApproveDeleteKey(key string, userID, status int) error {
 	modifiedKey.ApprovedBy = userID
 	modifiedKey.UpdateTime = time.Now()
 
-	tx, err := u.keyRepo.GetDBTx(ctx, nil)
+	if status != keyentity.DissaprovedKey {
+		if err := u.validatePrerequisites(ctx, modifiedKey, true); err != nil {
+			return err
+		}
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
+	defer endTx()
 
 	// Destroy all canary ip if any
-	if modifiedKey.Status == keyentity.CanaryKey {
+	wasCanary := modifiedKey.Status == keyentity.CanaryKey
+	if wasCanary {
 		if err := u.keyRepo.ModifyCanaryKey(ctx, tx, modifiedKey.ID, keyentity.StatusInactive); err != nil {
 			return err
 		}
@@ -324,7 +526,22 @@ func (u *Usecase) ApproveDeleteKey(key string, userID, status int) error {
 			return err
 		}
 
-		return tx.Commit()
+		if err := u.createComment(ctx, tx, modifiedKey.ID, keyentity.CommentKindDisapproval, reason, userID); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		endTx()
+
+		if wasCanary {
+			u.invalidateCanaryCache(ctx, modifiedKey.Key)
+		}
+
+		u.logTransition(ctx, modifiedKey, userID)
+		u.notify(ctx, modifiedKey, userID, reason)
+		return nil
 	}
 
 	// modify current key to approved status
@@ -346,16 +563,47 @@ func (u *Usecase) ApproveDeleteKey(key string, userID, status int) error {
 		return err
 	}
 
-	err = u.keyRepo.SetCache(ctx, modifiedKey)
+	cachedKey, err := u.cachedKey(modifiedKey)
+	if err != nil {
+		return err
+	}
+
+	err = u.keyRepo.SetCache(ctx, cachedKey)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	if wasCanary {
+		u.invalidateCanaryCache(ctx, modifiedKey.Key)
+	}
+
+	u.logTransition(ctx, modifiedKey, userID)
+	u.notify(ctx, modifiedKey, userID, "")
+	return nil
+}
+
+func (u *Usecase) ApproveKeyCanary(ctx context.Context, key string, userID, status int, nodesIP []string) error {
+	ctx, finish := u.start(ctx, "key.Usecase.ApproveKeyCanary", u.timeout.Write)
+	defer finish()
+
+	targets, err := normalizeCanaryTargets(nodesIP)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	return u.approveKeyCanary(ctx, key, userID, targets, "")
 }
 
-func (u *Usecase) ApproveKeyCanary(key string, userID, status int, nodesIP []string) error {
-	ctx := context.Background()
+// approveKeyCanary put the key in canary for the normalized ip targets and the group, empty group adds no group target
+func (u *Usecase) approveKeyCanary(ctx context.Context, key string, userID int, targets []string, group string) error {
+	if err := u.authorizeApprover(ctx, userID, key); err != nil {
+		return err
+	}
 
 	var isFirstTimeCanary bool
 
@@ -389,19 +637,25 @@ func (u *Usecase) ApproveKeyCanary(key string, userID, status int, nodesIP []str
 	approvedKeyEntry.UpdateTime = time.Now()
 	approvedKeyEntry.Status = keyentity.CanaryKey
 
-	tx, err := u.keyRepo.GetDBTx(ctx, nil)
+	tx, endTx, err := u.beginTx(ctx)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
+	defer endTx()
 
-	for _, ip := range nodesIP {
+	for _, ip := range targets {
 		err = u.keyRepo.CreateCanaryKey(ctx, tx, approvedKeyEntry.ID, ip)
 		if err != nil {
 			return err
 		}
 	}
 
+	if group != "" {
+		if err := u.keyRepo.CreateCanaryGroupKey(ctx, tx, approvedKeyEntry.ID, group); err != nil {
+			return err
+		}
+	}
+
 	if isFirstTimeCanary {
 		// first time canary, modify old key to status canary
 		err = u.keyRepo.ModifyKey(ctx, tx, keyCanary[0].ID, approvedKeyEntry)
@@ -410,25 +664,37 @@ func (u *Usecase) ApproveKeyCanary(key string, userID, status int, nodesIP []str
 		}
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	u.invalidateCanaryCache(ctx, key)
+
+	u.logTransition(ctx, approvedKeyEntry, userID)
+	return nil
 }
 
-func (u *Usecase) DeleteKey(keyID, userID int) error {
-	ctx := context.Background()
+func (u *Usecase) DeleteKey(ctx context.Context, keyID, userID int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.DeleteKey", u.timeout.Write)
+	defer finish()
 
 	keyFetched, err := u.keyRepo.GetKeyByID(ctx, keyID)
 	if err != nil {
 		return err
 	}
 
-	// TODO: get user by id and verify if the user can modify and then change status to approved
+	if err := u.authorize(ctx, userID, keyFetched.Key, userentity.RoleLead); err != nil {
+		return err
+	}
+
 	keyFetched.Status = keyentity.ApprovedAndExpiredKey
 
-	tx, err := u.keyRepo.GetDBTx(ctx, nil)
+	tx, endTx, err := u.beginTx(ctx)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
+	defer endTx()
 
 	err = u.keyRepo.ModifyKey(ctx, tx, keyID, keyFetched)
 	if err != nil {
@@ -440,22 +706,82 @@ func (u *Usecase) DeleteKey(keyID, userID int) error {
 		return err
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	u.logTransition(ctx, keyFetched, userID)
+	return nil
 }
 
-func (u *Usecase) GetHistoryKey(key string, isPrefix bool, limit int) ([]keyentity.KV, error) {
-	ctx := context.Background()
+// GetHistoryKey returns key history newest first, use NextCursor to get the next page
+func (u *Usecase) GetHistoryKey(ctx context.Context, key string, isPrefix bool, filter keyentity.HistoryFilter, userID int) (keyentity.HistoryPage, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetHistoryKey", u.timeout.Read)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, key, userentity.RoleUser); err != nil {
+		return keyentity.HistoryPage{}, err
+	}
+
+	beforeID, err := decodeIDCursor(filter.Cursor)
+	if err != nil {
+		return keyentity.HistoryPage{}, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+
+	// fetch one more row to know if there is next page, the repository matches the prefix as a string
+	// so sibling prefixes like service/risk2 are dropped before the page is cut
+	history := make([]keyentity.KV, 0, limit+1)
+	for len(history) <= limit {
+		rows, err := u.keyRepo.GetKeyHistory(ctx, key, isPrefix, filter, beforeID, limit+1)
+		if err != nil && err != sql.ErrNoRows {
+			return keyentity.HistoryPage{}, err
+		}
+
+		if isPrefix {
+			history = append(history, underPrefix(rows, key)...)
+		} else {
+			history = append(history, rows...)
+		}
+
+		if len(rows) <= limit {
+			break
+		}
+		beforeID = rows[len(rows)-1].ID
+	}
+
+	maskSecrets(history)
+
+	page := keyentity.HistoryPage{Items: history}
+	if len(history) > limit {
+		page.Items = history[:limit]
+		page.NextCursor = encodeIDCursor(page.Items[limit-1].ID)
+	}
+
+	page.Comments, err = u.getComments(ctx, page.Items)
+	if err != nil {
+		return keyentity.HistoryPage{}, err
+	}
 
-	return u.keyRepo.GetKeyHistory(ctx, key, isPrefix, limit)
+	return page, nil
 }
 
-func (u *Usecase) GetKey(key string) (keyentity.KV, error) {
-	ctx := context.Background()
+// GetKey returns active value of the key, the read is recorded for the reader.
+// Secret value is only decrypted when the reader user has access to the key.
+func (u *Usecase) GetKey(ctx context.Context, key string, reader keyentity.Reader) (keyentity.KV, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetKey", u.timeout.Read)
+	defer finish()
 
 	// get from cache first and if failed get from db
 	keyFromCache, err := u.keyRepo.GetCache(ctx, key)
 	if err != nil {
 		keysActive, err := u.keyRepo.GetKey(ctx, key, keyentity.ApprovedKey)
+		metrics.ObserveCache("get_key", metrics.TierDB, len(keysActive) > 0)
 		if err != nil {
 			return keyentity.KV{}, err
 		}
@@ -465,94 +791,421 @@ func (u *Usecase) GetKey(key string) (keyentity.KV, error) {
 			return keyentity.KV{}, errors.New("No key found")
 		}
 
-		return keysActive[0], nil
+		keyFromCache = keysActive[0]
 	}
 
+	u.reads.record(keyentity.EnvironmentFromContext(ctx), []string{key}, reader)
+
 	// get only approved key
-	return keyFromCache, nil
+	kvs := []keyentity.KV{keyFromCache}
+	if err := u.revealSecrets(ctx, kvs, reader.UserID); err != nil {
+		return keyentity.KV{}, err
+	}
+
+	return kvs[0], nil
 }
 
-func (u *Usecase) GetKeys(prefix, ip string) ([]keyentity.KV, error) {
-	ctx := context.Background()
+// GetKeys returns active keys under the prefix with canary value for the reader ip, the reads are recorded for the reader.
+// Secret values are only decrypted when the reader user has access to them.
+func (u *Usecase) GetKeys(ctx context.Context, prefix string, reader keyentity.Reader) ([]keyentity.KV, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetKeys", u.timeout.Read)
+	defer finish()
 
-	// get only approved key
+	// get only approved key, from cache first and if failed from db
 	approvedKeys, err := u.keyRepo.GetCaches(ctx, prefix)
 	if err != nil {
-		return nil, err
+		approvedKeys, err = u.keyRepo.GetKeyByPrefix(ctx, prefix, keyentity.ApprovedAndActive)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		approvedKeys = underPrefix(approvedKeys, prefix)
+		metrics.ObserveCache("get_keys", metrics.TierDB, len(approvedKeys) > 0)
 	}
 
-	// get value for specific ip
-	if ip != "" {
-		canaryKeys, err := u.keyRepo.GetCanaryKV(ctx, ip)
+	// get value for specific ip or canary group
+	if reader.IP != "" || reader.Group != "" {
+		canaryKeys, err := u.getCanaryIndex(ctx, prefix, reader.IP, reader.Group)
 		if err != nil {
 			return nil, err
 		}
 
-		for i, aKey := range approvedKeys {
+		if len(canaryKeys) > 0 {
+			// first match of a key is the most specific range
+			overrides := make(map[string]keyentity.KV, len(canaryKeys))
 			for _, cKey := range canaryKeys {
-				if aKey.Key == cKey.Key {
+				if _, ok := overrides[cKey.Key]; !ok {
+					overrides[cKey.Key] = cKey
+				}
+			}
+
+			for i, aKey := range approvedKeys {
+				if cKey, ok := overrides[aKey.Key]; ok {
 					approvedKeys[i] = cKey
 				}
 			}
 		}
 	}
 
+	keys := make([]string, 0, len(approvedKeys))
+	for _, kv := range approvedKeys {
+		keys = append(keys, kv.Key)
+	}
+	u.reads.record(keyentity.EnvironmentFromContext(ctx), keys, reader)
+
+	if err := u.revealSecrets(ctx, approvedKeys, reader.UserID); err != nil {
+		return nil, err
+	}
+
 	return approvedKeys, nil
 }
 
-func (u *Usecase) BrowseKeys(prefix string) ([]string, error) {
-	return u.keyRepo.GetKeyListWithoutValue(prefix)
+// getCanaryIndex returns canary keys under the prefix for the ip and group, from cache first and if failed from db
+func (u *Usecase) getCanaryIndex(ctx context.Context, prefix, ip, group string) ([]keyentity.KV, error) {
+	// canary index is only kept in ristretto
+	canaryKeys, err := u.keyRepo.GetCanaryCache(ctx, prefix, ip, group)
+	metrics.ObserveCache("get_canary_index", metrics.TierL1, err == nil)
+	if err == nil {
+		return canaryKeys, nil
+	}
+
+	canaryKeys, err = u.keyRepo.GetCanaryKVByPrefix(ctx, prefix, ip, group)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	metrics.ObserveCache("get_canary_index", metrics.TierDB, len(canaryKeys) > 0)
+
+	// empty index is cached as well, most ip has no canary key
+	if err := u.keyRepo.SetCanaryCache(ctx, prefix, ip, group, canaryKeys); err != nil {
+		return nil, err
+	}
+
+	return canaryKeys, nil
+}
+
+// invalidateCanaryCache runs after the change is committed, otherwise a read in between cache the old index again.
+// The change is already stored so failure is only logged, cached index expires by its ttl.
+func (u *Usecase) invalidateCanaryCache(ctx context.Context, key string) {
+	if err := u.keyRepo.InvalidateCanaryCache(ctx, key); err != nil {
+		u.log.WarnContext(ctx, "canary cache invalidation failed", slog.String("key", key), slog.Any("error", err))
+	}
+}
+
+// BrowseKeys list key names under the prefix.
+// When separator is set only immediate children are returned and directories carry their key count.
+func (u *Usecase) BrowseKeys(ctx context.Context, prefix string, opts keyentity.BrowseOptions, userID int) (keyentity.BrowsePage, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.BrowseKeys", u.timeout.Read)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, prefix, userentity.RoleUser); err != nil {
+		return keyentity.BrowsePage{}, err
+	}
+
+	after, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return keyentity.BrowsePage{}, err
+	}
+
+	limit := opts.Limit
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+
+	if opts.Separator == "" {
+		// like GetHistoryKey, drop keys of sibling prefixes before the page is cut
+		nodes := make([]keyentity.BrowseNode, 0, limit+1)
+		for len(nodes) <= limit {
+			keys, err := u.keyRepo.GetKeyListWithoutValuePage(ctx, prefix, after, limit+1)
+			if err != nil && err != sql.ErrNoRows {
+				return keyentity.BrowsePage{}, err
+			}
+
+			for _, key := range keys {
+				if util.IsUnderPrefix(key, prefix) {
+					nodes = append(nodes, keyentity.BrowseNode{Name: key})
+				}
+			}
+
+			if len(keys) <= limit {
+				break
+			}
+			after = keys[len(keys)-1]
+		}
+
+		return u.withMetadata(ctx, browsePage(nodes, limit))
+	}
+
+	// grouped in the repository, so a page never loads every key of a large tribe
+	nodes, err := u.keyRepo.GetKeyChildrenPage(ctx, prefix, opts.Separator, after, limit+1)
+	if err != nil && err != sql.ErrNoRows {
+		return keyentity.BrowsePage{}, err
+	}
+
+	return u.withMetadata(ctx, browsePage(nodes, limit))
+}
+
+// browsePage cut nodes fetched with limit+1 into a page
+func browsePage(nodes []keyentity.BrowseNode, limit int) keyentity.BrowsePage {
+	page := keyentity.BrowsePage{Nodes: nodes}
+	if len(nodes) > limit {
+		page.Nodes = nodes[:limit]
+		page.NextCursor = encodeCursor(page.Nodes[limit-1].Name)
+	}
+
+	return page
+}
+
+// PendingApprovalKey returns placed updates, placed deletes and canaries under the prefix
+func (u *Usecase) PendingApprovalKey(ctx context.Context, prefix string, filter keyentity.PendingFilter, userID int) (keyentity.PendingPage, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.PendingApprovalKey", u.timeout.Read)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, prefix, userentity.RoleUser); err != nil {
+		return keyentity.PendingPage{}, err
+	}
+
+	kinds := filter.Kinds
+	if len(kinds) == 0 {
+		kinds = []string{keyentity.PendingKindUpdate, keyentity.PendingKindDelete, keyentity.PendingKindCanary}
+	}
+
+	ownerships, err := u.getOwnerships(ctx)
+	if err != nil {
+		return keyentity.PendingPage{}, err
+	}
+
+	routed := func(string) bool { return true }
+	if filter.Approver != "" {
+		routed, err = u.approverRouter(ctx, filter.Approver, ownerships)
+		if err != nil {
+			return keyentity.PendingPage{}, err
+		}
+	}
+
+	now := time.Now()
+	pendingKeys := make([]keyentity.KV, 0)
+	for _, kind := range kinds {
+		status, ok := keyentity.PendingKindStatus[kind]
+		if !ok {
+			return keyentity.PendingPage{}, fmt.Errorf("Unknown pending kind %s.", kind)
+		}
+
+		// get key with pending status based on the prefix
+		keys, err := u.keyRepo.GetKeyByPrefix(ctx, prefix, status)
+		if err != nil && err != sql.ErrNoRows {
+			return keyentity.PendingPage{}, err
+		}
+
+		for _, kv := range underPrefix(keys, prefix) {
+			if filter.Author > 0 && kv.CreatedBy != filter.Author {
+				continue
+			}
+
+			if !routed(kv.Key) {
+				continue
+			}
+
+			age := now.Sub(kv.CreateTime)
+			if filter.MinAge > 0 && age < filter.MinAge {
+				continue
+			}
+			if filter.MaxAge > 0 && age > filter.MaxAge {
+				continue
+			}
+
+			pendingKeys = append(pendingKeys, kv)
+		}
+	}
+
+	// oldest first so the longest waiting change is on top
+	sort.SliceStable(pendingKeys, func(i, j int) bool {
+		if pendingKeys[i].CreateTime.Equal(pendingKeys[j].CreateTime) {
+			return pendingKeys[i].ID < pendingKeys[j].ID
+		}
+		if filter.Sort == keyentity.SortNewest {
+			return pendingKeys[i].CreateTime.After(pendingKeys[j].CreateTime)
+		}
+		return pendingKeys[i].CreateTime.Before(pendingKeys[j].CreateTime)
+	})
+
+	total := len(pendingKeys)
+
+	limit := filter.Limit
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+
+	offset := filter.Offset
+	if offset > total {
+		offset = total
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	items, err := u.withActiveDiff(ctx, pendingKeys[offset:end], ownerships)
+	if err != nil {
+		return keyentity.PendingPage{}, err
+	}
+
+	return keyentity.PendingPage{
+		Items: items,
+		Total: total,
+	}, nil
 }
 
-func (u *Usecase) PendingApprovalKey(prefix string) ([]keyentity.KV, error) {
-	ctx := context.Background()
+// withActiveDiff enrich pending keys with current active value, the diff against it, comments and ownership
+func (u *Usecase) withActiveDiff(ctx context.Context, pendingKeys []keyentity.KV, ownerships []keyentity.Ownership) ([]keyentity.PendingKV, error) {
+	result := make([]keyentity.PendingKV, 0, len(pendingKeys))
 
-	// get key with placed status basen on the prefix
-	placedKeys, err := u.keyRepo.GetKeyByPrefix(ctx, prefix, keyentity.PlacedKey)
+	comments, err := u.getComments(ctx, pendingKeys)
 	if err != nil {
-		return []keyentity.KV{}, err
+		return []keyentity.PendingKV{}, err
 	}
 
-	// if keys with placed status found, return
-	if len(placedKeys) > 0 {
-		return placedKeys, nil
+	commentsByKeyID := make(map[int][]keyentity.Comment)
+	for _, comment := range comments {
+		commentsByKeyID[comment.KeyID] = append(commentsByKeyID[comment.KeyID], comment)
+	}
+
+	for _, pendingKey := range pendingKeys {
+		activeKeys, err := u.keyRepo.GetKey(ctx, pendingKey.Key, keyentity.ApprovedAndActive)
+		if err != nil && err != sql.ErrNoRows {
+			return []keyentity.PendingKV{}, err
+		}
+
+		var activeKey keyentity.KV
+		if len(activeKeys) > 0 {
+			activeKey = activeKeys[0]
+		}
+
+		// placed delete will remove the value
+		newValue := pendingKey.Value
+		if pendingKey.Status == keyentity.PlacedDeleteKey {
+			newValue = ""
+		}
+
+		ownership, _ := keyentity.FindOwnership(ownerships, pendingKey.Key)
+		result = append(result, keyentity.PendingKV{
+			Kind:      pendingKind(pendingKey.Status),
+			KV:        maskSecret(pendingKey),
+			Active:    maskSecret(activeKey),
+			Diff:      diffValue(pendingKey.Type, activeKey.Value, newValue),
+			Comments:  commentsByKeyID[pendingKey.ID],
+			Ownership: ownership,
+		})
+	}
+
+	return result, nil
+}
+
+// validateValue reject json typed value that is not valid json, search index cast every json value
+func validateValue(kv keyentity.KV) error {
+	if kv.Type == keyentity.TypeJSON && !json.Valid([]byte(kv.Value)) {
+		return fmt.Errorf("Value of %s is not valid json.", kv.Key)
+	}
+
+	return nil
+}
+
+func pendingKind(status int) string {
+	for kind, kindStatus := range keyentity.PendingKindStatus {
+		if kindStatus == status {
+			return kind
+		}
+	}
+
+	return ""
+}
+
+// DiffHistoryKey compare two versions of the same key from history
+func (u *Usecase) DiffHistoryKey(ctx context.Context, fromID, toID, userID int) (keyentity.Diff, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.DiffHistoryKey", u.timeout.Read)
+	defer finish()
+
+	fromKey, err := u.keyRepo.GetKeyByID(ctx, fromID)
+	if err != nil {
+		return keyentity.Diff{}, err
 	}
 
-	// if keys with placed status not found, get placed delete status
-	placedRejectKeys, err := u.keyRepo.GetKeyByPrefix(ctx, prefix, keyentity.PlacedDeleteKey)
+	toKey, err := u.keyRepo.GetKeyByID(ctx, toID)
 	if err != nil {
-		return []keyentity.KV{}, err
+		return keyentity.Diff{}, err
 	}
 
-	return placedRejectKeys, nil
+	if fromKey.Key != toKey.Key {
+		return keyentity.Diff{}, errors.New("Can not compare versions of different keys.")
+	}
+
+	if err := u.authorize(ctx, userID, toKey.Key, userentity.RoleUser); err != nil {
+		return keyentity.Diff{}, err
+	}
+
+	return diffValue(toKey.Type, fromKey.Value, toKey.Value), nil
 }
 
-// Create service will create key, role user, role admin, and mapping user as lead for that service
-func (u *Usecase) CreateService(username, tribe, service string) error {
-	ctx := context.Background()
-	key := fmt.Sprintf("service/%s/%s/default", tribe, service)
-	prefix := fmt.Sprintf("service/%s/%s", tribe, service)
+// Create service will create key, role user, role admin, and mapping user as lead for that service.
+// Service is created under the root of the namespace, default namespace is service.
+// Requester must be at least lead of the namespace root.
+func (u *Usecase) CreateService(ctx context.Context, username, namespace, tribe, service string, requestedBy int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.CreateService", u.timeout.Write)
+	defer finish()
+
+	if namespace == "" {
+		namespace = userentity.DefaultNamespace
+	}
+
+	ns, err := u.userRepo.GetNamespace(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := u.authorize(ctx, requestedBy, ns.Root, userentity.RoleLead); err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("%s/%s/%s/default", ns.Root, tribe, service)
+	prefix := fmt.Sprintf("%s/%s/%s", ns.Root, tribe, service)
 
 	if u.keyRepo.IsKeyExist(ctx, key) {
 		return errors.New("Service already exist.")
 	}
 
+	if err := u.checkQuota(ctx, []string{key}); err != nil {
+		return err
+	}
+
 	user, err := u.userRepo.GetUser(ctx, username)
 	if err != nil {
 		return err
 	}
 
-	tx, err := u.keyRepo.GetDBTx(ctx, nil)
+	tx, endTx, err := u.beginTx(ctx)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
+	defer endTx()
 
-	if err := u.keyRepo.CreateKey(ctx, tx, key, "false", "bool", user.ID, keyentity.PlacedKey); err != nil {
+	if _, err := u.keyRepo.CreateKey(ctx, tx, key, "false", "bool", user.ID, keyentity.PlacedKey); err != nil {
 		return err
 	}
 
-	if err := u.ApproveKeyWithTx(tx, key, user.ID, keyentity.ApprovedAndActive); err != nil {
+	// service identity is used to resolve canary targets of its keys
+	if _, err := u.keyRepo.CreateServiceEntry(ctx, tx, keyentity.Service{
+		Namespace: ns.Name,
+		Tribe:     tribe,
+		Name:      service,
+		Prefix:    prefix,
+		CreatedBy: user.ID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.ApproveKeyWithTx(ctx, tx, key, user.ID, keyentity.ApprovedAndActive, ""); err != nil {
 		return err
 	}
 
@@ -576,55 +1229,25 @@ func (u *Usecase) CreateService(username, tribe, service string) error {
 		return err
 	}
 
-	return tx.Commit()
-}
-
-func (u *Usecase) RegisterCanaryDeployment(service string, nodesIP []string) error {
-	ctx := context.Background()
-
-	return u.keyRepo.RegisterCanaryDeployment(ctx, service, nodesIP)
-}
-
-func (u *Usecase) ReleaseCanaryIP(service string) error {
-	ctx := context.Background()
-
-	return u.keyRepo.ReleaseCanaryIP(ctx, service)
-}
-
-func (u *Usecase) GetKeyCanaryIP(id int) ([]string, []string, error) {
-	ctx := context.Background()
-	var canaryIPs, recommendedIP []string
-
-	// Get current canary IP from db
-	canaryKVs, err := u.keyRepo.GetCanaryKVByID(ctx, id)
-	if err != nil && err != sql.ErrNoRows {
-		return canaryIPs, recommendedIP, err
-	}
-	for _, ckv := range canaryKVs {
-		canaryIPs = append(canaryIPs, ckv.IP)
-	}
-
-	// Get recommended canary IP in redis from jenkins
-	requestedKey, err := u.keyRepo.GetKeyByID(ctx, id)
-	if err != nil && err != sql.ErrNoRows {
-		return canaryIPs, recommendedIP, err
+	// tribe owns the service, without user owners every superuser of the service approves until lead sets them
+	if err := u.keyRepo.SaveOwnership(ctx, tx, keyentity.Ownership{
+		Prefix: prefix,
+		Owners: []keyentity.Owner{
+			{Kind: keyentity.OwnerKindTeam, Name: tribe},
+		},
+		Description: fmt.Sprintf("Service %s of tribe %s", service, tribe),
+		Tags:        []string{ns.Name, tribe},
+		UpdateTime:  time.Now(),
+		UpdatedBy:   user.ID,
+	}); err != nil {
+		return err
 	}
 
-	if requestedKey.Key != "" {
-		path := strings.Split(requestedKey.Key, "/")
-
-		var service string
-		if len(path) > 3 {
-			service = path[2]
-		} else {
-			service = path[len(path)-2]
-		}
-
-		ips := u.keyRepo.GetCanaryIP(ctx, service)
-		for _, ip := range ips {
-			recommendedIP = append(recommendedIP, ip)
-		}
+	if err := tx.Commit(); err != nil {
+		return err
 	}
+	endTx()
 
-	return canaryIPs, recommendedIP, nil
+	u.logTransition(ctx, keyentity.KV{Key: key, Value: "false", Status: keyentity.ApprovedAndActive}, user.ID)
+	return nil
 }
@@ -0,0 +1,99 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+const (
+	testAdmin = 1
+	testUser  = 2
+	testLead  = 3
+)
+
+// testDeps are mocks behind the usecase made by newTestUsecase.
+// Users, their access and namespaces are stubs the test can change before calling the usecase.
+type testDeps struct {
+	keyRepo    *MockkeyRepository
+	userRepo   *MockuserRepository
+	db         sqlmock.Sqlmock
+	access     map[int][]userentity.Role
+	namespaces []userentity.Namespace
+	users      []userentity.User
+}
+
+// newTestUsecase returns usecase where testAdmin is admin and testUser is user of the service namespace,
+// testLead is lead of service/risk only. Transactions are begun on sqlmock, expect them with deps.db.
+func newTestUsecase(t testing.TB) (*Usecase, *testDeps) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		db.Close()
+	})
+
+	deps := &testDeps{
+		keyRepo:  NewMockkeyRepository(ctrl),
+		userRepo: NewMockuserRepository(ctrl),
+		db:       mock,
+		access: map[int][]userentity.Role{
+			testAdmin: {{ID: 1, Prefix: "service", Permission: userentity.RoleAdmin}},
+			testUser:  {{ID: 2, Prefix: "service", Permission: userentity.RoleUser}},
+			testLead:  {{ID: 3, Prefix: "service/risk", Permission: userentity.RoleLead}},
+		},
+		namespaces: []userentity.Namespace{{ID: 1, Name: "service", Root: "service"}},
+		users:      []userentity.User{{ID: testAdmin, Username: "admin"}, {ID: testUser, Username: "user"}, {ID: testLead, Username: "lead"}},
+	}
+
+	deps.keyRepo.EXPECT().GetDBTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+		return db.BeginTx(ctx, opts)
+	}).AnyTimes()
+	deps.userRepo.EXPECT().GetUserAccess(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, userID int) ([]userentity.Role, error) {
+		return deps.access[userID], nil
+	}).AnyTimes()
+	deps.userRepo.EXPECT().GetNamespaces(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]userentity.Namespace, error) {
+		return deps.namespaces, nil
+	}).AnyTimes()
+	deps.userRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, username string) (userentity.User, error) {
+		for _, user := range deps.users {
+			if user.Username == username {
+				return user, nil
+			}
+		}
+		return userentity.User{}, sql.ErrNoRows
+	}).AnyTimes()
+
+	return New(deps.keyRepo, deps.userRepo), deps
+}
+
+// expectKeys make GetKey of the key and status return the rows, other statuses of the key return nothing.
+// Call it once per key before the usecase, later calls for the same key are not matched.
+func (d *testDeps) expectKeys(key string, rows map[int][]keyentity.KV) {
+	d.keyRepo.EXPECT().GetKey(gomock.Any(), key, gomock.Any()).DoAndReturn(func(ctx context.Context, key string, status int) ([]keyentity.KV, error) {
+		return rows[status], nil
+	}).AnyTimes()
+}
+
+// expectNoOwnership make every key owned by nobody
+func (d *testDeps) expectNoOwnership() {
+	d.keyRepo.EXPECT().GetOwnerships(gomock.Any()).Return(nil, sql.ErrNoRows).AnyTimes()
+}
+
+// expectNoPrerequisite make every key free of prerequisites
+func (d *testDeps) expectNoPrerequisite() {
+	d.keyRepo.EXPECT().GetPrerequisites(gomock.Any()).Return(nil, sql.ErrNoRows).AnyTimes()
+}
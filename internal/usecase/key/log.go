@@ -0,0 +1,25 @@
+package key
+
+import (
+	"context"
+	"log/slog"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	"github.com/marde12345/key-flag/internal/logger"
+)
+
+func (u *Usecase) SetLogger(log *logger.Logger) {
+	u.log = log
+}
+
+// logTransition log the key moving into its status by the actor, value is redacted for sensitive key and masked for secret
+func (u *Usecase) logTransition(ctx context.Context, kv keyentity.KV, actor int) {
+	kv = maskSecret(kv)
+	u.log.InfoContext(ctx, "key transition",
+		slog.String("key", kv.Key),
+		slog.Int("actor", actor),
+		slog.String("status", kv.StatusString()),
+		slog.Int("change_set_id", kv.ChangeSetID),
+		u.log.Value(kv.Key, kv.Value),
+	)
+}
@@ -0,0 +1,133 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+// UpdateKeyMetadata replace metadata of the key, it does not change the value so no approval is needed
+func (u *Usecase) UpdateKeyMetadata(ctx context.Context, metadata keyentity.KeyMetadata, userID int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.UpdateKeyMetadata", u.timeout.Write)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, metadata.Key, userentity.RoleUser); err != nil {
+		return err
+	}
+
+	// cache may miss or outlive the key, db is the source of truth
+	_, found, err := u.activeKey(ctx, metadata.Key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.New("No key found")
+	}
+
+	switch metadata.Lifetime {
+	case "":
+		metadata.Lifetime = keyentity.LifetimePermanent
+		fallthrough
+	case keyentity.LifetimePermanent:
+		if !metadata.ExpiryDate.IsZero() {
+			return errors.New("Permanent key can not have expiry date.")
+		}
+	case keyentity.LifetimeTemporary:
+		if metadata.ExpiryDate.IsZero() {
+			return errors.New("Temporary key requires expiry date.")
+		}
+	default:
+		return errors.New("Lifetime must be permanent or temporary.")
+	}
+
+	metadata.UpdateTime = time.Now()
+	metadata.UpdatedBy = userID
+	return u.keyRepo.SaveKeyMetadata(ctx, metadata)
+}
+
+func (u *Usecase) GetKeyMetadata(ctx context.Context, key string, userID int) (keyentity.KeyMetadata, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetKeyMetadata", u.timeout.Read)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, key, userentity.RoleUser); err != nil {
+		return keyentity.KeyMetadata{}, err
+	}
+
+	metadata, err := u.getKeyMetadata(ctx, []string{key})
+	if err != nil {
+		return keyentity.KeyMetadata{}, err
+	}
+
+	if m, ok := metadata[key]; ok {
+		return m, nil
+	}
+
+	// key without metadata is permanent until said otherwise
+	return keyentity.KeyMetadata{Key: key, Lifetime: keyentity.LifetimePermanent}, nil
+}
+
+// expiredKeys returns the keys that are temporary and past their expiry date
+func (u *Usecase) expiredKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	metadata, err := u.getKeyMetadata(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expired := make(map[string]bool)
+	for key, m := range metadata {
+		if m.Expired(now) {
+			expired[key] = true
+		}
+	}
+
+	return expired, nil
+}
+
+// getKeyMetadata returns metadata of the keys by key in one query
+func (u *Usecase) getKeyMetadata(ctx context.Context, keys []string) (map[string]keyentity.KeyMetadata, error) {
+	result := make(map[string]keyentity.KeyMetadata, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	metadata, err := u.keyRepo.GetKeyMetadata(ctx, keys)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	for _, m := range metadata {
+		result[m.Key] = m
+	}
+
+	return result, nil
+}
+
+// withMetadata attach metadata to key nodes of the page
+func (u *Usecase) withMetadata(ctx context.Context, page keyentity.BrowsePage) (keyentity.BrowsePage, error) {
+	keys := make([]string, 0, len(page.Nodes))
+	for _, node := range page.Nodes {
+		if !node.IsDir {
+			keys = append(keys, node.Name)
+		}
+	}
+
+	metadata, err := u.getKeyMetadata(ctx, keys)
+	if err != nil {
+		return keyentity.BrowsePage{}, err
+	}
+
+	now := time.Now()
+	for i, node := range page.Nodes {
+		if m, ok := metadata[node.Name]; ok && !node.IsDir {
+			page.Nodes[i].Metadata = &m
+			page.Nodes[i].Expired = m.Expired(now)
+		}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,68 @@
+package key
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+func TestUpdateKeyMetadataReadDB(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	// cache is never asked, it may miss an active key or keep a deleted one
+	deps.expectKeys("service/risk/flag", map[int][]keyentity.KV{
+		keyentity.ApprovedAndActive: {{ID: 1, Key: "service/risk/flag", Value: "true", Status: keyentity.ApprovedAndActive}},
+	})
+	deps.expectKeys("service/risk/deleted", nil)
+
+	deps.keyRepo.EXPECT().SaveKeyMetadata(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, metadata keyentity.KeyMetadata) error {
+		if metadata.Lifetime != keyentity.LifetimePermanent {
+			t.Errorf("lifetime = %q, want permanent", metadata.Lifetime)
+		}
+		return nil
+	})
+
+	metadata := keyentity.KeyMetadata{Key: "service/risk/flag", Description: "risk check"}
+	if err := u.UpdateKeyMetadata(context.Background(), metadata, testUser); err != nil {
+		t.Fatalf("UpdateKeyMetadata() error = %v", err)
+	}
+
+	if err := u.UpdateKeyMetadata(context.Background(), keyentity.KeyMetadata{Key: "service/risk/deleted"}, testUser); err == nil {
+		t.Fatal("UpdateKeyMetadata() of key missing in db error = nil")
+	}
+}
+
+func TestGetStaleKeysExpired(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	now := time.Now()
+
+	activeKeys := make([]keyentity.KV, 0)
+	for i, key := range []string{"service/risk/expired", "service/risk/temporary", "service/risk/recent"} {
+		activeKeys = append(activeKeys, keyentity.KV{ID: i + 1, Key: key, Value: "true", Status: keyentity.ApprovedAndActive, UpdateTime: now.Add(-time.Hour)})
+	}
+	deps.keyRepo.EXPECT().GetKeyByPrefix(gomock.Any(), "service/risk", gomock.Any()).DoAndReturn(func(ctx context.Context, prefix string, status int) ([]keyentity.KV, error) {
+		if status == keyentity.ApprovedAndActive {
+			return activeKeys, nil
+		}
+		return nil, nil
+	}).AnyTimes()
+	deps.keyRepo.EXPECT().GetKeyMetadata(gomock.Any(), gomock.Any()).Return([]keyentity.KeyMetadata{
+		{Key: "service/risk/expired", Lifetime: keyentity.LifetimeTemporary, ExpiryDate: now.Add(-time.Minute)},
+		{Key: "service/risk/temporary", Lifetime: keyentity.LifetimeTemporary, ExpiryDate: now.Add(time.Hour)},
+	}, nil)
+	deps.keyRepo.EXPECT().CountKeyChanges(gomock.Any(), []string{"service/risk/expired"}, keyentity.ApprovedKey).Return(nil, nil)
+	deps.keyRepo.EXPECT().GetLastKeyReads(gomock.Any(), []string{"service/risk/expired"}).Return(nil, nil)
+
+	staleKeys, err := u.GetStaleKeys(context.Background(), "service/risk", 0, testUser)
+	if err != nil {
+		t.Fatalf("GetStaleKeys() error = %v", err)
+	}
+
+	// recently changed keys are only stale once expired
+	if len(staleKeys) != 1 || staleKeys[0].KV.Key != "service/risk/expired" || !staleKeys[0].Expired {
+		t.Fatalf("GetStaleKeys() = %+v, want only the expired key", staleKeys)
+	}
+}
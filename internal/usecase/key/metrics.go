@@ -0,0 +1,82 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	"github.com/marde12345/key-flag/internal/metrics"
+)
+
+// RunMetrics refresh pending and canary gauges every interval until ctx is done
+func (u *Usecase) RunMetrics(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// gauges keep the last value when refresh failed
+			_ = u.RefreshMetrics(ctx)
+		}
+	}
+}
+
+// RefreshMetrics set pending, canary key and canary target gauges of every service
+func (u *Usecase) RefreshMetrics(ctx context.Context) error {
+	ctx, finish := u.start(ctx, "key.Usecase.RefreshMetrics", u.timeout.Background)
+	defer finish()
+
+	services, err := u.keyRepo.GetServices(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	pending := make(map[string]map[string]int, len(services))
+	targets := make(map[string]map[string]int, len(services))
+	for _, service := range services {
+		pending[service.Prefix] = make(map[string]int)
+		for kind, status := range keyentity.PendingKindStatus {
+			keys, err := u.keyRepo.GetKeyByPrefix(ctx, service.Prefix, status)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			pending[service.Prefix][kind] = len(underPrefix(keys, service.Prefix))
+		}
+
+		groups, err := u.keyRepo.GetCanaryGroups(ctx, service.ID)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		targets[service.Prefix] = make(map[string]int, len(groups))
+		for _, group := range groups {
+			groupTargets, err := u.keyRepo.GetCanaryTargets(ctx, service.ID, group.Name)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			targets[service.Prefix][group.Name] = len(groupTargets)
+		}
+	}
+
+	// reset so removed services and groups are not reported anymore
+	metrics.PendingKeys.Reset()
+	metrics.CanaryKeys.Reset()
+	metrics.CanaryTargets.Reset()
+	for prefix, kinds := range pending {
+		for kind, count := range kinds {
+			metrics.PendingKeys.WithLabelValues(prefix, kind).Set(float64(count))
+		}
+		metrics.CanaryKeys.WithLabelValues(prefix).Set(float64(kinds[keyentity.PendingKindCanary]))
+	}
+	for prefix, groups := range targets {
+		for group, count := range groups {
+			metrics.CanaryTargets.WithLabelValues(prefix, group).Set(float64(count))
+		}
+	}
+
+	return nil
+}
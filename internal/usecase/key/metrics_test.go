@@ -0,0 +1,36 @@
+package key
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	"github.com/marde12345/key-flag/internal/metrics"
+)
+
+func TestGetKeysCacheMissFallbackToDB(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	deps.keyRepo.EXPECT().GetCaches(gomock.Any(), "service/risk").Return(nil, errors.New("redis: connection refused"))
+	deps.keyRepo.EXPECT().GetKeyByPrefix(gomock.Any(), "service/risk", keyentity.ApprovedAndActive).Return([]keyentity.KV{
+		{ID: 1, Key: "service/risk/flag", Value: "true", Status: keyentity.ApprovedAndActive},
+		{ID: 2, Key: "service/risky/flag", Value: "false", Status: keyentity.ApprovedAndActive},
+	}, nil)
+
+	dbHits := metrics.CacheRequests.WithLabelValues("get_keys", metrics.TierDB, metrics.ResultHit)
+	before := testutil.ToFloat64(dbHits)
+
+	kvs, err := u.GetKeys(context.Background(), "service/risk", keyentity.Reader{})
+	if err != nil {
+		t.Fatalf("GetKeys() error = %v", err)
+	}
+	if len(kvs) != 1 || kvs[0].Key != "service/risk/flag" {
+		t.Fatalf("GetKeys() = %+v, want active key inside the prefix", kvs)
+	}
+	if got := testutil.ToFloat64(dbHits) - before; got != 1 {
+		t.Fatalf("db hits = %v, want 1", got)
+	}
+}
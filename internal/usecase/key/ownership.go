@@ -0,0 +1,239 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+// Notifier deliver notification to owners of the key, see internal/notifier for implementations
+type Notifier interface {
+	Notify(ctx context.Context, notification keyentity.Notification) error
+}
+
+// SetNotifier register notifier of key transitions, without notifier nobody is notified
+func (u *Usecase) SetNotifier(notifier Notifier) {
+	u.notifier = notifier
+}
+
+// SetOwnership replace ownership of the prefix
+func (u *Usecase) SetOwnership(ctx context.Context, ownership keyentity.Ownership, userID int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.SetOwnership", u.timeout.Write)
+	defer finish()
+
+	if ownership.Prefix == "" {
+		return errors.New("Prefix is required.")
+	}
+
+	if err := u.authorize(ctx, userID, ownership.Prefix, userentity.RoleLead); err != nil {
+		return err
+	}
+
+	if len(ownership.Owners) == 0 {
+		return errors.New("At least one owner is required.")
+	}
+
+	for _, owner := range ownership.Owners {
+		if owner.Name == "" {
+			return errors.New("Owner name is required.")
+		}
+
+		switch owner.Kind {
+		case keyentity.OwnerKindTeam:
+		case keyentity.OwnerKindUser:
+			if _, err := u.userRepo.GetUser(ctx, owner.Name); err != nil {
+				return fmt.Errorf("User %s is not found.", owner.Name)
+			}
+		default:
+			return fmt.Errorf("Unknown owner kind %s.", owner.Kind)
+		}
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer endTx()
+
+	ownership.UpdateTime = time.Now()
+	ownership.UpdatedBy = userID
+	if err := u.keyRepo.SaveOwnership(ctx, tx, ownership); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetOwnership returns ownership of the most specific prefix covering the key
+func (u *Usecase) GetOwnership(ctx context.Context, key string, userID int) (keyentity.Ownership, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetOwnership", u.timeout.Read)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, key, userentity.RoleUser); err != nil {
+		return keyentity.Ownership{}, err
+	}
+
+	ownerships, err := u.getOwnerships(ctx)
+	if err != nil {
+		return keyentity.Ownership{}, err
+	}
+
+	ownership, ok := keyentity.FindOwnership(ownerships, key)
+	if !ok {
+		return keyentity.Ownership{}, sql.ErrNoRows
+	}
+
+	return ownership, nil
+}
+
+func (u *Usecase) getOwnerships(ctx context.Context) ([]keyentity.Ownership, error) {
+	ownerships, err := u.keyRepo.GetOwnerships(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	return ownerships, nil
+}
+
+// authorizeApprover check the user can approve changes of the key, see canApprove
+func (u *Usecase) authorizeApprover(ctx context.Context, userID int, key string) error {
+	roles, err := u.userRepo.GetUserAccess(ctx, userID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if !userentity.HasPermission(roles, key, userentity.RoleSuperUser) {
+		return userentity.ErrForbidden
+	}
+
+	ownerships, err := u.getOwnerships(ctx)
+	if err != nil {
+		return err
+	}
+
+	var lookupErr error
+	isApprover := func(username string) bool {
+		user, err := u.userRepo.GetUser(ctx, username)
+		if err != nil && err != sql.ErrNoRows {
+			lookupErr = err
+			return false
+		}
+
+		return err == nil && user.ID == userID
+	}
+
+	if canApprove(roles, key, ownerships, isApprover) {
+		return nil
+	}
+
+	if lookupErr != nil {
+		return lookupErr
+	}
+
+	return keyentity.ErrNotApprover
+}
+
+// approverRouter returns check if a change of the key is routed to the approver, see canApprove
+func (u *Usecase) approverRouter(ctx context.Context, approver string, ownerships []keyentity.Ownership) (func(key string) bool, error) {
+	user, err := u.userRepo.GetUser(ctx, approver)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := u.userRepo.GetUserAccess(ctx, user.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	isApprover := func(username string) bool {
+		return username == user.Username
+	}
+
+	return func(key string) bool {
+		return canApprove(roles, key, ownerships, isApprover)
+	}, nil
+}
+
+// canApprove is the one rule of who approves changes of the key. Lead and admin of the key always do,
+// superuser of the key does when the owning prefix has no user owners or isApprover matches one of them.
+func canApprove(roles []userentity.Role, key string, ownerships []keyentity.Ownership, isApprover func(username string) bool) bool {
+	if userentity.HasPermission(roles, key, userentity.RoleLead) {
+		return true
+	}
+
+	if !userentity.HasPermission(roles, key, userentity.RoleSuperUser) {
+		return false
+	}
+
+	ownership, ok := keyentity.FindOwnership(ownerships, key)
+	if !ok {
+		return true
+	}
+
+	usernames := ownership.Usernames()
+	if len(usernames) == 0 {
+		return true
+	}
+
+	for _, username := range usernames {
+		if isApprover(username) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maxPendingNotifications bound notifications delivered at the same time, more are dropped
+const maxPendingNotifications = 64
+
+// notify owners of the key about the transition in the background, so a slow notifier doesn't hold the request.
+// Delivery has its own background budget and failure is only logged since the transition is committed.
+func (u *Usecase) notify(ctx context.Context, kv keyentity.KV, actor int, reason string) {
+	if u.notifier == nil {
+		return
+	}
+
+	select {
+	case u.notifySlots <- struct{}{}:
+	default:
+		u.log.WarnContext(ctx, "notification dropped, too many in flight", slog.String("key", kv.Key))
+		return
+	}
+
+	u.notifications.Add(1)
+	go func() {
+		defer u.notifications.Done()
+		defer func() { <-u.notifySlots }()
+
+		ctx, finish := u.start(context.WithoutCancel(ctx), "key.Usecase.notify", u.timeout.Background)
+		defer finish()
+
+		ownerships, err := u.getOwnerships(ctx)
+		if err != nil {
+			u.log.WarnContext(ctx, "notification skipped", slog.String("key", kv.Key), slog.Any("error", err))
+			return
+		}
+
+		ownership, _ := keyentity.FindOwnership(ownerships, kv.Key)
+		if err := u.notifier.Notify(ctx, keyentity.Notification{
+			KV:        maskSecret(kv),
+			Actor:     actor,
+			Reason:    reason,
+			Ownership: ownership,
+		}); err != nil {
+			u.log.WarnContext(ctx, "notification failed", slog.String("key", kv.Key), slog.Any("error", err))
+		}
+	}()
+}
+
+// WaitNotifications block until notifications in the background are delivered, call it on shutdown
+func (u *Usecase) WaitNotifications() {
+	u.notifications.Wait()
+}
@@ -0,0 +1,163 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+// expectApproval expect placed service/risk/flag to be approved
+func expectApproval(deps *testDeps) {
+	deps.expectNoPrerequisite()
+	deps.expectKeys("service/risk/flag", map[int][]keyentity.KV{
+		keyentity.PlacedKey: {{ID: 1, Key: "service/risk/flag", Value: "true", Status: keyentity.PlacedKey}},
+	})
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().ModifyOldActiveKey(gomock.Any(), gomock.Any(), "service/risk/flag").Return(nil)
+	deps.keyRepo.EXPECT().CreateKeyEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().SetCache(gomock.Any(), gomock.Any()).Return(nil)
+	deps.db.ExpectCommit()
+}
+
+func TestApproveKeyOnlyByUserOwner(t *testing.T) {
+	const testOther = 4
+
+	tests := []struct {
+		name    string
+		userID  int
+		wantErr error
+	}{
+		{name: "user owner", userID: testUser},
+		{name: "superuser not owning the key", userID: testOther, wantErr: keyentity.ErrNotApprover},
+		{name: "lead not owning the key", userID: testLead},
+		{name: "admin not owning the key", userID: testAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			deps.users = append(deps.users, userentity.User{ID: testOther, Username: "other"})
+			deps.access[testUser] = []userentity.Role{{ID: 2, Prefix: "service", Permission: userentity.RoleSuperUser}}
+			deps.access[testOther] = []userentity.Role{{ID: 4, Prefix: "service", Permission: userentity.RoleSuperUser}}
+			deps.keyRepo.EXPECT().GetOwnerships(gomock.Any()).Return([]keyentity.Ownership{{
+				ID:     1,
+				Prefix: "service/risk",
+				Owners: []keyentity.Owner{{Kind: keyentity.OwnerKindTeam, Name: "risk"}, {Kind: keyentity.OwnerKindUser, Name: "user"}},
+			}}, nil).AnyTimes()
+			if tt.wantErr == nil {
+				expectApproval(deps)
+			}
+
+			err := u.ApproveKey(context.Background(), "service/risk/flag", tt.userID, keyentity.ApprovedKey, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApproveKey() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApproveKeyOfNewServiceByLead(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	deps.expectNoPrerequisite()
+	deps.userRepo.EXPECT().GetNamespace(gomock.Any(), "service").Return(deps.namespaces[0], nil)
+	deps.keyRepo.EXPECT().IsKeyExist(gomock.Any(), "service/risk/sauron/default").Return(false)
+	deps.expectKeys("service/risk/sauron/default", map[int][]keyentity.KV{
+		keyentity.PlacedKey: {{ID: 1, Key: "service/risk/sauron/default", Value: "false", Status: keyentity.PlacedKey}},
+	})
+
+	var ownerships []keyentity.Ownership
+	deps.keyRepo.EXPECT().GetOwnerships(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]keyentity.Ownership, error) {
+		return ownerships, nil
+	}).AnyTimes()
+
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().CreateKey(gomock.Any(), gomock.Any(), "service/risk/sauron/default", "false", "bool", testUser, keyentity.PlacedKey).Return(1, nil)
+	deps.keyRepo.EXPECT().CreateServiceEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
+	deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().ModifyOldActiveKey(gomock.Any(), gomock.Any(), "service/risk/sauron/default").Return(nil)
+	deps.keyRepo.EXPECT().CreateKeyEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().SetCache(gomock.Any(), gomock.Any()).Return(nil)
+	deps.userRepo.EXPECT().CreateRole(gomock.Any(), gomock.Any(), "service/risk/sauron", gomock.Any(), testUser).Return(5, nil).Times(3)
+	deps.userRepo.EXPECT().MapUserAccess(gomock.Any(), gomock.Any(), testUser, gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().SaveOwnership(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, tx *sql.Tx, ownership keyentity.Ownership) error {
+		ownership.ID = 1
+		ownerships = append(ownerships, ownership)
+		return nil
+	})
+	deps.db.ExpectCommit()
+
+	if err := u.CreateService(context.Background(), "user", "", "risk", "sauron", testAdmin); err != nil {
+		t.Fatalf("CreateService() error = %v", err)
+	}
+	if len(ownerships) != 1 || len(ownerships[0].Usernames()) != 0 {
+		t.Fatalf("ownerships = %+v, want the tribe only", ownerships)
+	}
+
+	// lead of the tribe did not create the service and still approves its changes
+	deps.expectKeys("service/risk/sauron/flag", map[int][]keyentity.KV{
+		keyentity.PlacedKey: {{ID: 2, Key: "service/risk/sauron/flag", Value: "true", Status: keyentity.PlacedKey}},
+	})
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), 2, gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().ModifyOldActiveKey(gomock.Any(), gomock.Any(), "service/risk/sauron/flag").Return(nil)
+	deps.keyRepo.EXPECT().CreateKeyEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().SetCache(gomock.Any(), gomock.Any()).Return(nil)
+	deps.db.ExpectCommit()
+
+	if err := u.ApproveKey(context.Background(), "service/risk/sauron/flag", testLead, keyentity.ApprovedKey, ""); err != nil {
+		t.Fatalf("ApproveKey() by lead error = %v", err)
+	}
+}
+
+// blockingNotifier hold every notification until released
+type blockingNotifier struct {
+	release chan struct{}
+	mu      sync.Mutex
+	sent    []keyentity.Notification
+}
+
+func (n *blockingNotifier) Notify(ctx context.Context, notification keyentity.Notification) error {
+	<-n.release
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestNotifyDoesNotBlockRequest(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	notifier := &blockingNotifier{release: make(chan struct{})}
+	u.SetNotifier(notifier)
+	deps.expectNoOwnership()
+	expectApproval(deps)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- u.ApproveKey(context.Background(), "service/risk/flag", testAdmin, keyentity.ApprovedKey, "")
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("ApproveKey() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ApproveKey() waits for the notifier")
+	}
+
+	close(notifier.release)
+	u.WaitNotifications()
+	if len(notifier.sent) != 1 || notifier.sent[0].KV.Key != "service/risk/flag" {
+		t.Fatalf("sent = %+v, want notification of the approval", notifier.sent)
+	}
+}
@@ -0,0 +1,88 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+func TestPendingApprovalKey(t *testing.T) {
+	now := time.Now()
+	rows := map[int][]keyentity.KV{
+		keyentity.PlacedKey: {
+			{ID: 1, Key: "service/risk/a", Value: "1", Status: keyentity.PlacedKey, CreatedBy: testUser, CreateTime: now.Add(-3 * time.Hour)},
+			{ID: 3, Key: "service/risk/c", Value: "3", Status: keyentity.PlacedKey, CreatedBy: testLead, CreateTime: now.Add(-time.Hour)},
+			// sibling prefix, not under service/risk
+			{ID: 5, Key: "service/riskier/x", Value: "5", Status: keyentity.PlacedKey, CreatedBy: testUser, CreateTime: now.Add(-4 * time.Hour)},
+		},
+		keyentity.PlacedDeleteKey: {
+			{ID: 2, Key: "service/risk/b", Value: "2", Status: keyentity.PlacedDeleteKey, CreatedBy: testLead, CreateTime: now.Add(-2 * time.Hour)},
+			{ID: 4, Key: "service/risk/d", Value: "4", Status: keyentity.PlacedDeleteKey, CreatedBy: testUser, CreateTime: now.Add(-30 * time.Minute)},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		filter    keyentity.PendingFilter
+		wantIDs   []int
+		wantTotal int
+		wantErr   bool
+	}{
+		{name: "oldest first", wantIDs: []int{1, 2, 3, 4}, wantTotal: 4},
+		{name: "newest first", filter: keyentity.PendingFilter{Sort: keyentity.SortNewest}, wantIDs: []int{4, 3, 2, 1}, wantTotal: 4},
+		{name: "updates only", filter: keyentity.PendingFilter{Kinds: []string{keyentity.PendingKindUpdate}}, wantIDs: []int{1, 3}, wantTotal: 2},
+		{name: "deletes only", filter: keyentity.PendingFilter{Kinds: []string{keyentity.PendingKindDelete}}, wantIDs: []int{2, 4}, wantTotal: 2},
+		{name: "author", filter: keyentity.PendingFilter{Author: testLead}, wantIDs: []int{2, 3}, wantTotal: 2},
+		{name: "author and kind", filter: keyentity.PendingFilter{Author: testUser, Kinds: []string{keyentity.PendingKindDelete}}, wantIDs: []int{4}, wantTotal: 1},
+		{name: "min age", filter: keyentity.PendingFilter{MinAge: 90 * time.Minute}, wantIDs: []int{1, 2}, wantTotal: 2},
+		{name: "max age", filter: keyentity.PendingFilter{MaxAge: 90 * time.Minute}, wantIDs: []int{3, 4}, wantTotal: 2},
+		{name: "age window newest first", filter: keyentity.PendingFilter{MinAge: 45 * time.Minute, MaxAge: 150 * time.Minute, Sort: keyentity.SortNewest}, wantIDs: []int{3, 2}, wantTotal: 2},
+		{name: "first page", filter: keyentity.PendingFilter{Limit: 2}, wantIDs: []int{1, 2}, wantTotal: 4},
+		{name: "last full page", filter: keyentity.PendingFilter{Limit: 2, Offset: 2}, wantIDs: []int{3, 4}, wantTotal: 4},
+		{name: "partial page", filter: keyentity.PendingFilter{Limit: 2, Offset: 3}, wantIDs: []int{4}, wantTotal: 4},
+		{name: "offset at total", filter: keyentity.PendingFilter{Limit: 2, Offset: 4}, wantIDs: []int{}, wantTotal: 4},
+		{name: "offset past total", filter: keyentity.PendingFilter{Offset: 10}, wantIDs: []int{}, wantTotal: 4},
+		{name: "negative offset", filter: keyentity.PendingFilter{Limit: 1, Offset: -1}, wantIDs: []int{1}, wantTotal: 4},
+		{name: "unknown kind", filter: keyentity.PendingFilter{Kinds: []string{"rollback"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			deps.expectNoOwnership()
+			deps.keyRepo.EXPECT().GetKeyByPrefix(gomock.Any(), "service/risk", gomock.Any()).DoAndReturn(func(ctx context.Context, prefix string, status int) ([]keyentity.KV, error) {
+				return rows[status], nil
+			}).AnyTimes()
+			deps.keyRepo.EXPECT().GetKey(gomock.Any(), gomock.Any(), keyentity.ApprovedAndActive).Return(nil, sql.ErrNoRows).AnyTimes()
+			deps.keyRepo.EXPECT().GetComments(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows).AnyTimes()
+
+			page, err := u.PendingApprovalKey(context.Background(), "service/risk", tt.filter, testUser)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PendingApprovalKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			ids := make([]int, 0, len(page.Items))
+			for _, item := range page.Items {
+				ids = append(ids, item.KV.ID)
+				if want := pendingKind(item.KV.Status); item.Kind != want {
+					t.Fatalf("kind of %d = %s, want %s", item.KV.ID, item.Kind, want)
+				}
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Fatalf("PendingApprovalKey() ids = %v, want %v", ids, tt.wantIDs)
+			}
+			if page.Total != tt.wantTotal {
+				t.Fatalf("PendingApprovalKey() total = %d, want %d", page.Total, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/metrics"
+)
+
+// maxPrerequisiteDepth guard evaluation against chain that is too long to make sense
+const maxPrerequisiteDepth = 10
+
+// AddPrerequisite make keys covered by the prerequisite depend on its required key
+func (u *Usecase) AddPrerequisite(ctx context.Context, prerequisite keyentity.Prerequisite, userID int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.AddPrerequisite", u.timeout.Write)
+	defer finish()
+
+	if prerequisite.Key == "" || prerequisite.RequiredKey == "" {
+		return errors.New("Key and required key are required.")
+	}
+
+	// wildcard covering the required key is fine, Covers never applies the prerequisite to its required key
+	if prerequisite.Key == prerequisite.RequiredKey {
+		return errors.New("Key can not depend on itself.")
+	}
+
+	if err := u.authorize(ctx, userID, prerequisite.Prefix(), userentity.RoleLead); err != nil {
+		return err
+	}
+
+	// required key can not be changed freely anymore once keys depend on it
+	if err := u.authorize(ctx, userID, prerequisite.RequiredKey, userentity.RoleLead); err != nil {
+		return err
+	}
+
+	// required value is compared in plain text, a secret would be compared against its ciphertext
+	required, found, err := u.activeKey(ctx, prerequisite.RequiredKey)
+	if err != nil {
+		return err
+	}
+
+	if found && required.Type == keyentity.TypeSecret {
+		return errors.New("Secret key can not be a prerequisite.")
+	}
+
+	prerequisites, err := u.keyRepo.GetPrerequisites(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	// walk every key required by the required key, none of them may be covered by the new prerequisite
+	visited := map[string]bool{prerequisite.RequiredKey: true}
+	queue := []string{prerequisite.RequiredKey}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, p := range prerequisites {
+			if !p.Covers(current) {
+				continue
+			}
+
+			if prerequisite.Covers(p.RequiredKey) {
+				return fmt.Errorf("Prerequisite on %s creates a cycle through %s.", prerequisite.RequiredKey, p.RequiredKey)
+			}
+
+			if !visited[p.RequiredKey] {
+				visited[p.RequiredKey] = true
+				queue = append(queue, p.RequiredKey)
+			}
+		}
+	}
+
+	prerequisite.CreatedBy = userID
+	prerequisite.Status = keyentity.StatusActive
+
+	if err := u.keyRepo.CreatePrerequisite(ctx, prerequisite); err != nil {
+		return err
+	}
+
+	u.invalidatePrerequisiteCache(ctx)
+	return nil
+}
+
+func (u *Usecase) RemovePrerequisite(ctx context.Context, id, userID int) error {
+	ctx, finish := u.start(ctx, "key.Usecase.RemovePrerequisite", u.timeout.Write)
+	defer finish()
+
+	prerequisite, err := u.keyRepo.GetPrerequisiteByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := u.authorize(ctx, userID, prerequisite.Prefix(), userentity.RoleLead); err != nil {
+		return err
+	}
+
+	if err := u.keyRepo.ModifyPrerequisiteStatus(ctx, id, keyentity.StatusInactive); err != nil {
+		return err
+	}
+
+	u.invalidatePrerequisiteCache(ctx)
+	return nil
+}
+
+// GetPrerequisites returns prerequisites the key depends on and the ones requiring the key
+func (u *Usecase) GetPrerequisites(ctx context.Context, key string) ([]keyentity.Prerequisite, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetPrerequisites", u.timeout.Read)
+	defer finish()
+
+	prerequisites, err := u.activePrerequisites(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]keyentity.Prerequisite, 0)
+	for _, p := range prerequisites {
+		if p.Covers(key) || p.RequiredKey == key {
+			result = append(result, p)
+		}
+	}
+
+	return result, nil
+}
+
+// GetEffectiveKeys is GetKeys with prerequisites evaluated,
+// key whose prerequisite is not met gets the fallback value of the prerequisite.
+func (u *Usecase) GetEffectiveKeys(ctx context.Context, prefix string, reader keyentity.Reader) ([]keyentity.KV, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetEffectiveKeys", u.timeout.Read)
+	defer finish()
+
+	kvs, err := u.GetKeys(ctx, prefix, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	prerequisites, err := u.activePrerequisites(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prerequisites) == 0 {
+		return kvs, nil
+	}
+
+	values := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		values[kv.Key] = kv.Value
+	}
+
+	evaluated := make(map[string]string)
+	for i, kv := range kvs {
+		value, err := u.effectiveValue(ctx, prerequisites, values, evaluated, kv.Key, 0)
+		if err != nil {
+			return nil, err
+		}
+		kvs[i].Value = value
+	}
+
+	return kvs, nil
+}
+
+func (u *Usecase) effectiveValue(ctx context.Context, prerequisites []keyentity.Prerequisite, values, evaluated map[string]string, key string, depth int) (string, error) {
+	if value, ok := evaluated[key]; ok {
+		return value, nil
+	}
+
+	if depth >= maxPrerequisiteDepth {
+		return "", fmt.Errorf("Prerequisite chain of %s is longer than %d.", key, maxPrerequisiteDepth)
+	}
+
+	// keys outside of requested prefix are read from the active version
+	value, ok := values[key]
+	if !ok {
+		active, found, err := u.cachedActiveKey(ctx, key)
+		if err != nil {
+			return "", err
+		}
+		if !found {
+			evaluated[key] = ""
+			return "", nil
+		}
+		value = active.Value
+	}
+
+	for _, p := range prerequisites {
+		if !p.Covers(key) {
+			continue
+		}
+
+		required, err := u.effectiveValue(ctx, prerequisites, values, evaluated, p.RequiredKey, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		if required != p.RequiredValue {
+			value = p.FallbackValue
+			break
+		}
+	}
+
+	evaluated[key] = value
+	return value, nil
+}
+
+// validatePrerequisites make sure new plain value of the key, or its deletion, doesn't leave
+// the key on while its prerequisite is off or a dependent key on while the key is off
+func (u *Usecase) validatePrerequisites(ctx context.Context, kv keyentity.KV, deleted bool) error {
+	prerequisites, err := u.keyRepo.GetPrerequisites(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	key, value := kv.Key, kv.Value
+	for _, p := range prerequisites {
+		if p.RequiredKey == key && !deleted && kv.Type == keyentity.TypeSecret {
+			return fmt.Errorf("Key %s is required by %s and can not be a secret.", key, p.Key)
+		}
+
+		if p.Covers(key) && !deleted && value != p.FallbackValue {
+			required, found, err := u.activeKey(ctx, p.RequiredKey)
+			if err != nil {
+				return err
+			}
+
+			if !found || required.Value != p.RequiredValue {
+				return fmt.Errorf("Key %s requires %s to be %s.", key, p.RequiredKey, p.RequiredValue)
+			}
+		}
+
+		if p.RequiredKey == key && (deleted || value != p.RequiredValue) {
+			dependents, err := u.keyRepo.GetKeyByPrefix(ctx, p.Prefix(), keyentity.ApprovedAndActive)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+
+			for _, dependent := range dependents {
+				dependent, err := u.decryptSecret(dependent)
+				if err != nil {
+					return err
+				}
+
+				if p.Covers(dependent.Key) && dependent.Value != p.FallbackValue {
+					return fmt.Errorf("Key %s is required by %s, set it to %s first.", key, dependent.Key, p.FallbackValue)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (u *Usecase) activeKey(ctx context.Context, key string) (keyentity.KV, bool, error) {
+	keys, err := u.keyRepo.GetKey(ctx, key, keyentity.ApprovedAndActive)
+	if err != nil && err != sql.ErrNoRows {
+		return keyentity.KV{}, false, err
+	}
+
+	if len(keys) == 0 {
+		return keyentity.KV{}, false, nil
+	}
+
+	return keys[0], true, nil
+}
+
+// cachedActiveKey is activeKey read through the key cache, for read paths
+func (u *Usecase) cachedActiveKey(ctx context.Context, key string) (keyentity.KV, bool, error) {
+	if kv, err := u.keyRepo.GetCache(ctx, key); err == nil {
+		return kv, true, nil
+	}
+
+	kv, found, err := u.activeKey(ctx, key)
+	metrics.ObserveCache("get_effective_keys", metrics.TierDB, found)
+	return kv, found, err
+}
+
+// activePrerequisites returns every active prerequisite, from cache first and if failed from db
+func (u *Usecase) activePrerequisites(ctx context.Context) ([]keyentity.Prerequisite, error) {
+	prerequisites, err := u.keyRepo.GetPrerequisiteCache(ctx)
+	metrics.ObserveCache("get_prerequisites", metrics.TierL1, err == nil)
+	if err == nil {
+		return prerequisites, nil
+	}
+
+	prerequisites, err = u.keyRepo.GetPrerequisites(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	metrics.ObserveCache("get_prerequisites", metrics.TierDB, len(prerequisites) > 0)
+
+	// empty set is cached as well, most keys have no prerequisite
+	if err := u.keyRepo.SetPrerequisiteCache(ctx, prerequisites); err != nil {
+		return nil, err
+	}
+
+	return prerequisites, nil
+}
+
+// invalidatePrerequisiteCache runs after the change is stored, failure is only logged and the cache expires by its ttl
+func (u *Usecase) invalidatePrerequisiteCache(ctx context.Context) {
+	if err := u.keyRepo.InvalidatePrerequisiteCache(ctx); err != nil {
+		u.log.WarnContext(ctx, "prerequisite cache invalidation failed", slog.Any("error", err))
+	}
+}
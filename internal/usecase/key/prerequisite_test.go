@@ -0,0 +1,135 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+func TestAddPrerequisite(t *testing.T) {
+	tests := []struct {
+		name         string
+		prerequisite keyentity.Prerequisite
+		userID       int
+		wantErr      bool
+	}{
+		{
+			name:         "depends on itself",
+			prerequisite: keyentity.Prerequisite{Key: "service/risk/flag", RequiredKey: "service/risk/flag"},
+			userID:       testAdmin,
+			wantErr:      true,
+		},
+		{
+			name:         "wildcard covering the required key",
+			prerequisite: keyentity.Prerequisite{Key: "service/risk/*", RequiredKey: "service/risk/enabled"},
+			userID:       testLead,
+		},
+		{
+			name:         "required key outside of user access",
+			prerequisite: keyentity.Prerequisite{Key: "service/risk/flag", RequiredKey: "service/payment/enabled"},
+			userID:       testLead,
+			wantErr:      true,
+		},
+		{
+			name:         "required key in user access",
+			prerequisite: keyentity.Prerequisite{Key: "service/risk/flag", RequiredKey: "service/payment/enabled"},
+			userID:       testAdmin,
+		},
+		{
+			// required value would be compared against the ciphertext
+			name:         "secret required key",
+			prerequisite: keyentity.Prerequisite{Key: "service/risk/flag", RequiredKey: "service/risk/token"},
+			userID:       testAdmin,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			deps.expectNoPrerequisite()
+			deps.keyRepo.EXPECT().GetKey(gomock.Any(), gomock.Any(), keyentity.ApprovedAndActive).DoAndReturn(func(ctx context.Context, key string, status int) ([]keyentity.KV, error) {
+				if key == "service/risk/token" {
+					return []keyentity.KV{{ID: 1, Key: key, Value: "enc:v2:...", Type: keyentity.TypeSecret, Status: status}}, nil
+				}
+				return nil, sql.ErrNoRows
+			}).AnyTimes()
+			deps.keyRepo.EXPECT().InvalidatePrerequisiteCache(gomock.Any()).Return(nil).AnyTimes()
+
+			created := 0
+			if !tt.wantErr {
+				created = 1
+			}
+			deps.keyRepo.EXPECT().CreatePrerequisite(gomock.Any(), gomock.Any()).Return(nil).Times(created)
+
+			err := u.AddPrerequisite(context.Background(), tt.prerequisite, tt.userID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddPrerequisite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetEffectiveKeysPrerequisiteCache(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	prerequisite := keyentity.Prerequisite{ID: 1, Key: "service/risk/flag", RequiredKey: "service/payment/enabled", RequiredValue: "true", FallbackValue: "false", Status: keyentity.StatusActive}
+
+	// ristretto entry of the prerequisites
+	var cached []keyentity.Prerequisite
+	hit := false
+	deps.keyRepo.EXPECT().GetPrerequisiteCache(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]keyentity.Prerequisite, error) {
+		if !hit {
+			return nil, errors.New("cache miss")
+		}
+		return cached, nil
+	}).AnyTimes()
+	deps.keyRepo.EXPECT().SetPrerequisiteCache(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, prerequisites []keyentity.Prerequisite) error {
+		cached, hit = prerequisites, true
+		return nil
+	}).AnyTimes()
+	deps.keyRepo.EXPECT().InvalidatePrerequisiteCache(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+		hit = false
+		return nil
+	}).AnyTimes()
+
+	// db is read once before and once after the removal
+	deps.keyRepo.EXPECT().GetPrerequisites(gomock.Any()).Return([]keyentity.Prerequisite{prerequisite}, nil)
+	deps.keyRepo.EXPECT().GetPrerequisites(gomock.Any()).Return(nil, sql.ErrNoRows)
+	deps.keyRepo.EXPECT().GetPrerequisiteByID(gomock.Any(), 1).Return(prerequisite, nil)
+	deps.keyRepo.EXPECT().ModifyPrerequisiteStatus(gomock.Any(), 1, keyentity.StatusInactive).Return(nil)
+
+	deps.keyRepo.EXPECT().GetCaches(gomock.Any(), "service/risk").DoAndReturn(func(ctx context.Context, prefix string) ([]keyentity.KV, error) {
+		return []keyentity.KV{{ID: 2, Key: "service/risk/flag", Value: "true", Status: keyentity.ApprovedAndActive}}, nil
+	}).AnyTimes()
+	// required key outside of the prefix is read from the key cache, not from db
+	deps.keyRepo.EXPECT().GetCache(gomock.Any(), "service/payment/enabled").
+		Return(keyentity.KV{ID: 3, Key: "service/payment/enabled", Value: "false", Status: keyentity.ApprovedAndActive}, nil).AnyTimes()
+
+	effectiveValue := func() string {
+		t.Helper()
+		kvs, err := u.GetEffectiveKeys(context.Background(), "service/risk", keyentity.Reader{})
+		if err != nil {
+			t.Fatalf("GetEffectiveKeys() error = %v", err)
+		}
+		return kvs[0].Value
+	}
+
+	for i := 0; i < 2; i++ {
+		if got := effectiveValue(); got != "false" {
+			t.Fatalf("effective value = %q, want fallback false", got)
+		}
+	}
+
+	if err := u.RemovePrerequisite(context.Background(), 1, testLead); err != nil {
+		t.Fatalf("RemovePrerequisite() error = %v", err)
+	}
+
+	if got := effectiveValue(); got != "true" {
+		t.Fatalf("effective value after removal = %q, want true", got)
+	}
+}
@@ -0,0 +1,188 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/util"
+)
+
+// PromoteKeys place active keys of the source environment into the target environment,
+// so the change still goes through approval of the target. DryRun only returns the diff preview.
+func (u *Usecase) PromoteKeys(ctx context.Context, req keyentity.PromotionRequest, userID int) (keyentity.PromotionResult, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.PromoteKeys", u.timeout.Write)
+	defer finish()
+
+	if req.SourceEnvironment == "" || req.TargetEnvironment == "" {
+		return keyentity.PromotionResult{}, errors.New("Source and target environment are required.")
+	}
+
+	if req.SourceEnvironment == req.TargetEnvironment {
+		return keyentity.PromotionResult{}, errors.New("Source and target environment must be different.")
+	}
+
+	if err := u.authorize(ctx, userID, req.Prefix, userentity.RoleUser); err != nil {
+		return keyentity.PromotionResult{}, err
+	}
+
+	sourceKeys, err := u.keyRepo.GetKeyByPrefixInEnvironment(ctx, req.SourceEnvironment, req.Prefix, keyentity.ApprovedAndActive)
+	if err != nil && err != sql.ErrNoRows {
+		return keyentity.PromotionResult{}, err
+	}
+	sourceKeys = underPrefix(sourceKeys, req.Prefix)
+
+	targetKeys, err := u.keyRepo.GetKeyByPrefixInEnvironment(ctx, req.TargetEnvironment, req.Prefix, keyentity.ApprovedAndActive)
+	if err != nil && err != sql.ErrNoRows {
+		return keyentity.PromotionResult{}, err
+	}
+
+	targetByKey := make(map[string]keyentity.KV, len(targetKeys))
+	for _, kv := range targetKeys {
+		targetByKey[kv.Key] = kv
+	}
+
+	// only promote requested keys if any
+	if len(req.Keys) > 0 {
+		sourceByKey := make(map[string]keyentity.KV, len(sourceKeys))
+		for _, kv := range sourceKeys {
+			sourceByKey[kv.Key] = kv
+		}
+
+		sourceKeys = make([]keyentity.KV, 0, len(req.Keys))
+		for _, key := range req.Keys {
+			if !util.IsUnderPrefix(key, req.Prefix) {
+				return keyentity.PromotionResult{}, fmt.Errorf("Key %s is outside of prefix %s.", key, req.Prefix)
+			}
+
+			kv, ok := sourceByKey[key]
+			if !ok {
+				return keyentity.PromotionResult{}, fmt.Errorf("Key %s is not active in %s.", key, req.SourceEnvironment)
+			}
+
+			sourceKeys = append(sourceKeys, kv)
+		}
+	}
+
+	sort.Slice(sourceKeys, func(i, j int) bool {
+		return sourceKeys[i].Key < sourceKeys[j].Key
+	})
+
+	result := keyentity.PromotionResult{
+		Items:     make([]keyentity.PromotionItem, 0),
+		Unchanged: make([]string, 0),
+	}
+	for _, sourceKey := range sourceKeys {
+		targetKey := targetByKey[sourceKey.Key]
+		same, err := u.sameValue(sourceKey.Key, sourceKey.Type, targetKey.Value, sourceKey.Value)
+		if err != nil {
+			return keyentity.PromotionResult{}, err
+		}
+
+		if same && targetKey.Type == sourceKey.Type {
+			result.Unchanged = append(result.Unchanged, sourceKey.Key)
+			continue
+		}
+
+		result.Items = append(result.Items, keyentity.PromotionItem{
+			Key:    sourceKey.Key,
+			Source: sourceKey,
+			Target: targetKey,
+			Diff:   diffValue(sourceKey.Type, targetKey.Value, sourceKey.Value),
+		})
+	}
+
+	if req.DryRun || len(result.Items) == 0 {
+		return maskPromotion(result), nil
+	}
+
+	promotedKeys := make([]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		promotedKeys = append(promotedKeys, item.Key)
+	}
+
+	if err := u.checkQuota(ctx, promotedKeys); err != nil {
+		return keyentity.PromotionResult{}, err
+	}
+
+	for _, item := range result.Items {
+		pending, err := u.hasPendingChangeInEnvironment(ctx, req.TargetEnvironment, item.Key)
+		if err != nil {
+			return keyentity.PromotionResult{}, err
+		}
+		if pending {
+			return keyentity.PromotionResult{}, fmt.Errorf("Key %s already has pending change in %s.", item.Key, req.TargetEnvironment)
+		}
+	}
+
+	// prerequisites are checked against active values of the target, not the source
+	targetCtx := keyentity.WithEnvironment(ctx, req.TargetEnvironment)
+	for _, item := range result.Items {
+		source, err := u.decryptSecret(item.Source)
+		if err != nil {
+			return keyentity.PromotionResult{}, err
+		}
+
+		if err := u.validatePrerequisites(targetCtx, source, false); err != nil {
+			return keyentity.PromotionResult{}, err
+		}
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return keyentity.PromotionResult{}, err
+	}
+	defer endTx()
+
+	description := fmt.Sprintf("Promote %s from %s to %s", req.Prefix, req.SourceEnvironment, req.TargetEnvironment)
+	changeSetID, err := u.keyRepo.CreateChangeSet(ctx, tx, keyentity.ChangeSet{
+		Description: description,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		return keyentity.PromotionResult{}, err
+	}
+
+	placedKeys := make([]keyentity.KV, 0, len(result.Items))
+	for _, item := range result.Items {
+		kv := keyentity.KV{
+			Key:         item.Key,
+			Value:       item.Source.Value,
+			Type:        item.Source.Type,
+			CreatedBy:   userID,
+			Status:      keyentity.PlacedKey,
+			ChangeSetID: changeSetID,
+			Environment: req.TargetEnvironment,
+		}
+		if err := u.keyRepo.CreateKeyEntry(ctx, tx, kv); err != nil {
+			return keyentity.PromotionResult{}, err
+		}
+		placedKeys = append(placedKeys, kv)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return keyentity.PromotionResult{}, err
+	}
+	endTx()
+
+	for _, kv := range placedKeys {
+		u.logTransition(ctx, kv, userID)
+		u.notify(ctx, kv, userID, description)
+	}
+
+	result.ChangeSetID = changeSetID
+	return maskPromotion(result), nil
+}
+
+func maskPromotion(result keyentity.PromotionResult) keyentity.PromotionResult {
+	for i, item := range result.Items {
+		result.Items[i].Source = maskSecret(item.Source)
+		result.Items[i].Target = maskSecret(item.Target)
+	}
+
+	return result
+}
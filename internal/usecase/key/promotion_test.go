@@ -0,0 +1,43 @@
+package key
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+func TestPromoteKeysPrerequisiteOfTarget(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	deps.keyRepo.EXPECT().GetKeyByPrefixInEnvironment(gomock.Any(), "staging", "service/risk", keyentity.ApprovedAndActive).
+		Return([]keyentity.KV{{ID: 1, Key: "service/risk/flag", Value: "true", Type: keyentity.TypeString}}, nil)
+	deps.keyRepo.EXPECT().GetKeyByPrefixInEnvironment(gomock.Any(), "production", "service/risk", keyentity.ApprovedAndActive).
+		Return([]keyentity.KV{{ID: 2, Key: "service/risk/flag", Value: "false", Type: keyentity.TypeString}}, nil)
+	deps.keyRepo.EXPECT().GetKeyInEnvironment(gomock.Any(), "production", "service/risk/flag", gomock.Any()).Return(nil, nil).Times(3)
+	deps.keyRepo.EXPECT().GetPrerequisites(gomock.Any()).Return([]keyentity.Prerequisite{{
+		Key:           "service/risk/flag",
+		RequiredKey:   "service/risk/enabled",
+		RequiredValue: "true",
+		FallbackValue: "false",
+	}}, nil)
+
+	// required key is on in staging only, the promotion must look at production
+	deps.keyRepo.EXPECT().GetKey(gomock.Any(), "service/risk/enabled", keyentity.ApprovedAndActive).
+		DoAndReturn(func(ctx context.Context, key string, status int) ([]keyentity.KV, error) {
+			if keyentity.EnvironmentFromContext(ctx) == "staging" {
+				return []keyentity.KV{{Key: key, Value: "true"}}, nil
+			}
+			return []keyentity.KV{{Key: key, Value: "false"}}, nil
+		})
+
+	_, err := u.PromoteKeys(context.Background(), keyentity.PromotionRequest{
+		SourceEnvironment: "staging",
+		TargetEnvironment: "production",
+		Prefix:            "service/risk",
+	}, testUser)
+	if err == nil {
+		t.Fatal("PromoteKeys() error = nil, want prerequisite unmet in production")
+	}
+}
@@ -3,6 +3,7 @@ package key
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	// entity dependency
 	keyentity "github.com/marde12345/key-flag/internal/entity/key"
@@ -11,29 +12,106 @@ import (
 
 //go:generate mockgen -source=repository.go -package=key -destination=repository_mock_test.go
 
+// keyRepository methods without environment work on environment of the ctx, see keyentity.EnvironmentFromContext.
+// Cache and canary index are kept per environment as well.
+// Prefix lookups match the start of the key, filter them with underPrefix when only keys inside the prefix path are meant.
 type keyRepository interface {
 	GetDBTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
 	GetKey(ctx context.Context, key string, status int) ([]keyentity.KV, error)
 	GetKeyByID(ctx context.Context, keyID int) (keyentity.KV, error)
 	GetKeyByPrefix(ctx context.Context, prefix string, status int) ([]keyentity.KV, error)
-	GetKeyHistory(ctx context.Context, key string, isPrefix bool, limit int) ([]keyentity.KV, error)
-	GetKeyListWithoutValue(prefix string) ([]string, error)
+	GetKeyHistory(ctx context.Context, key string, isPrefix bool, filter keyentity.HistoryFilter, beforeID, limit int) ([]keyentity.KV, error)
+	GetKeyListWithoutValuePage(ctx context.Context, prefix, afterKey string, limit int) ([]string, error)
+	// GetKeyChildrenPage returns immediate children of the prefix path ordered by name after afterName.
+	// Key is cut after the first separator following the prefix, the cut name is a directory carrying its key count.
+	GetKeyChildrenPage(ctx context.Context, prefix, separator, afterName string, limit int) ([]keyentity.BrowseNode, error)
+	// CreateKeyEntry and CreateKey return keyentity.ErrPendingChange when unique index of pending rows is violated,
+	// so concurrent placement of the same key fails even after both passed the pending check.
 	CreateKeyEntry(ctx context.Context, tx *sql.Tx, kv keyentity.KV) error
-	CreateKey(ctx context.Context, tx *sql.Tx, key string, value string, valType string, userID, status int) error
+	// CreateKey returns id of the new row
+	CreateKey(ctx context.Context, tx *sql.Tx, key string, value string, valType string, userID, status int) (int, error)
+	// ModifyKey update value, type, status, author, approver and update time of the row
 	ModifyKey(ctx context.Context, tx *sql.Tx, keyID int, kv keyentity.KV) error
+	// GetKeysByType returns keys of the type under the prefix in every status and environment
+	GetKeysByType(ctx context.Context, prefix, valType string) ([]keyentity.KV, error)
+	CreateComment(ctx context.Context, tx *sql.Tx, comment keyentity.Comment) (int, error)
+	GetCommentByID(ctx context.Context, id int) (keyentity.Comment, error)
+	// GetComments returns comments of the key rows ordered by create time
+	GetComments(ctx context.Context, keyIDs []int) ([]keyentity.Comment, error)
+	// SaveOwnership insert or replace ownership of the prefix
+	SaveOwnership(ctx context.Context, tx *sql.Tx, ownership keyentity.Ownership) error
+	GetOwnerships(ctx context.Context) ([]keyentity.Ownership, error)
+	// SaveKeyMetadata insert or replace metadata of the key
+	SaveKeyMetadata(ctx context.Context, metadata keyentity.KeyMetadata) error
+	GetKeyMetadata(ctx context.Context, keys []string) ([]keyentity.KeyMetadata, error)
+	ModifyKeyValue(ctx context.Context, tx *sql.Tx, keyID int, value string) error
+	// GetCache and GetCaches return error when both ristretto and redis missed,
+	// hit and miss of each tier are recorded into metrics.CacheRequests as metrics.TierL1 and metrics.TierL2
 	SetCache(ctx context.Context, key keyentity.KV) error
 	GetCache(ctx context.Context, key string) (keyentity.KV, error)
 	GetCaches(ctx context.Context, key string) ([]keyentity.KV, error)
 	InvalidateCache(ctx context.Context, key string) error
 	ModifyOldActiveKey(ctx context.Context, tx *sql.Tx, key string) error
 	IsKeyExist(ctx context.Context, key string) bool
-	RegisterCanaryDeployment(ctx context.Context, service string, nodesIP []string) error
-	ReleaseCanaryIP(ctx context.Context, service string) error
-	GetCanaryIP(ctx context.Context, service string) []string
-	GetCanaryKV(ctx context.Context, ip string) ([]keyentity.KV, error)
+	// GetCanaryKVByPrefix returns canary keys under the prefix whose target ip or range contains the ip, or whose target is the group.
+	// Ip targets come first ordered from the most specific range, then group targets, so the first match of a key wins.
+	// Empty ip or group matches nothing.
+	GetCanaryKVByPrefix(ctx context.Context, prefix, ip, group string) ([]keyentity.KV, error)
+	// GetCanaryCache returns canary index of the prefix for the ip and group from ristretto, error on miss.
+	GetCanaryCache(ctx context.Context, prefix, ip, group string) ([]keyentity.KV, error)
+	// SetCanaryCache store canary index of the prefix for the ip and group with short ttl,
+	// so index cached by a read racing with a canary change heals itself.
+	SetCanaryCache(ctx context.Context, prefix, ip, group string, kvs []keyentity.KV) error
+	// InvalidateCanaryCache drop every cached canary index whose prefix covers the key, for every ip and group.
+	InvalidateCanaryCache(ctx context.Context, key string) error
+	CreatePrerequisite(ctx context.Context, prerequisite keyentity.Prerequisite) error
+	GetPrerequisiteByID(ctx context.Context, id int) (keyentity.Prerequisite, error)
+	// GetPrerequisites returns every active prerequisite
+	GetPrerequisites(ctx context.Context) ([]keyentity.Prerequisite, error)
+	// GetPrerequisiteCache returns every active prerequisite from ristretto, error on miss.
+	GetPrerequisiteCache(ctx context.Context) ([]keyentity.Prerequisite, error)
+	// SetPrerequisiteCache store active prerequisites with short ttl, like SetCanaryCache.
+	SetPrerequisiteCache(ctx context.Context, prerequisites []keyentity.Prerequisite) error
+	InvalidatePrerequisiteCache(ctx context.Context) error
+	ModifyPrerequisiteStatus(ctx context.Context, id, status int) error
+	// SaveKeyReads add the counts to stored reads and keep the latest read time,
+	// every read is stored in its own Environment instead of the one of ctx
+	SaveKeyReads(ctx context.Context, reads []keyentity.KeyRead) error
+	// GetKeyReads returns reads of the key ordered by last read time, most recent first
+	GetKeyReads(ctx context.Context, key string) ([]keyentity.KeyRead, error)
+	// GetLastKeyReads returns the most recent read of each key in one query, keys never read are left out
+	GetLastKeyReads(ctx context.Context, keys []string) ([]keyentity.KeyRead, error)
+	// CountKeyChanges returns number of rows of each key in the status in one query, keys without row are left out
+	CountKeyChanges(ctx context.Context, keys []string, status int) (map[string]int, error)
+	// CreateCanaryKey store target as inet, target is single ip or cidr range.
 	CreateCanaryKey(ctx context.Context, tx *sql.Tx, id int, ip string) error
+	// CreateCanaryGroupKey target every node reading with the group, also nodes joining after the approval.
+	CreateCanaryGroupKey(ctx context.Context, tx *sql.Tx, id int, group string) error
+	// ModifyCanaryKey change status of both ip and group targets of the key
 	ModifyCanaryKey(ctx context.Context, tx *sql.Tx, id, status int) error
 	GetCanaryKVByID(ctx context.Context, id int) ([]keyentity.CanaryKV, error)
+	// SearchKeys match every key when text is empty. query.Expired keeps temporary keys past their expiry date
+	// like KeyMetadata.Expired, it is part of the query so query.Limit is filled with expired keys only.
+	SearchKeys(ctx context.Context, prefixes []string, query keyentity.SearchQuery) ([]keyentity.KV, error)
+	CreateChangeSet(ctx context.Context, tx *sql.Tx, changeSet keyentity.ChangeSet) (int, error)
+	GetKeyInEnvironment(ctx context.Context, environment, key string, status int) ([]keyentity.KV, error)
+	GetKeyByPrefixInEnvironment(ctx context.Context, environment, prefix string, status int) ([]keyentity.KV, error)
+	CountKeyByPrefix(ctx context.Context, prefix string) (int, error)
+	CreateServiceEntry(ctx context.Context, tx *sql.Tx, service keyentity.Service) (int, error)
+	GetServices(ctx context.Context) ([]keyentity.Service, error)
+	CreateCanaryGroup(ctx context.Context, group keyentity.CanaryGroup) error
+	GetCanaryGroups(ctx context.Context, serviceID int) ([]keyentity.CanaryGroup, error)
+	SetCanaryTarget(ctx context.Context, target keyentity.CanaryTarget, ttl time.Duration) error
+	DeleteCanaryTarget(ctx context.Context, target keyentity.CanaryTarget) error
+	GetCanaryTargets(ctx context.Context, serviceID int, group string) ([]keyentity.CanaryTarget, error)
+	CreateCanaryGate(ctx context.Context, gate keyentity.CanaryGate) error
+	GetActiveCanaryGates(ctx context.Context) ([]keyentity.CanaryGate, error)
+	// ModifyCanaryGateStatus and CreateCanaryDecision are written in the tx deciding the canary
+	ModifyCanaryGateStatus(ctx context.Context, tx *sql.Tx, keyID, status int) error
+	// ModifyCanaryGateFailures store number of unhealthy checks in a row of the gate
+	ModifyCanaryGateFailures(ctx context.Context, keyID, failures int) error
+	CreateCanaryDecision(ctx context.Context, tx *sql.Tx, decision keyentity.CanaryDecision) error
+	GetCanaryDecisions(ctx context.Context, keyID int) ([]keyentity.CanaryDecision, error)
 }
 
 type userRepository interface {
@@ -42,4 +120,7 @@ type userRepository interface {
 	CreateRole(ctx context.Context, tx *sql.Tx, prefix, permission string, userID int) (int, error)
 	MapUserAccess(ctx context.Context, tx *sql.Tx, userID int, roles []userentity.Role) error
 	GetUser(ctx context.Context, username string) (userentity.User, error)
+	GetUserAccess(ctx context.Context, userID int) ([]userentity.Role, error)
+	GetNamespace(ctx context.Context, name string) (userentity.Namespace, error)
+	GetNamespaces(ctx context.Context) ([]userentity.Namespace, error)
 }
@@ -0,0 +1,650 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/tracing"
+)
+
+// tracedKeyRepository wrap every keyRepository call with a span
+type tracedKeyRepository struct {
+	repo keyRepository
+}
+
+// tracedUserRepository wrap every userRepository call with a span
+type tracedUserRepository struct {
+	repo userRepository
+}
+
+// GetDBTx span covers BEGIN only, beginTx spans the transaction until commit or rollback
+func (r tracedKeyRepository) GetDBTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetDBTx")
+	defer span.End()
+
+	result, err := r.repo.GetDBTx(ctx, opts)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetKey(ctx context.Context, key string, status int) ([]keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetKey")
+	defer span.End()
+
+	result, err := r.repo.GetKey(ctx, key, status)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetKeyByID(ctx context.Context, keyID int) (keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetKeyByID")
+	defer span.End()
+
+	result, err := r.repo.GetKeyByID(ctx, keyID)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetKeyByPrefix(ctx context.Context, prefix string, status int) ([]keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetKeyByPrefix")
+	defer span.End()
+
+	result, err := r.repo.GetKeyByPrefix(ctx, prefix, status)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetKeyHistory(ctx context.Context, key string, isPrefix bool, filter keyentity.HistoryFilter, beforeID, limit int) ([]keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetKeyHistory")
+	defer span.End()
+
+	result, err := r.repo.GetKeyHistory(ctx, key, isPrefix, filter, beforeID, limit)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetKeyListWithoutValuePage(ctx context.Context, prefix, afterKey string, limit int) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetKeyListWithoutValuePage")
+	defer span.End()
+
+	result, err := r.repo.GetKeyListWithoutValuePage(ctx, prefix, afterKey, limit)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetKeyChildrenPage(ctx context.Context, prefix, separator, afterName string, limit int) ([]keyentity.BrowseNode, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetKeyChildrenPage")
+	defer span.End()
+
+	result, err := r.repo.GetKeyChildrenPage(ctx, prefix, separator, afterName, limit)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) CreateKeyEntry(ctx context.Context, tx *sql.Tx, kv keyentity.KV) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.CreateKeyEntry")
+	defer span.End()
+
+	err := r.repo.CreateKeyEntry(ctx, tx, kv)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) CreateKey(ctx context.Context, tx *sql.Tx, key string, value string, valType string, userID, status int) (int, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.CreateKey")
+	defer span.End()
+
+	result, err := r.repo.CreateKey(ctx, tx, key, value, valType, userID, status)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) ModifyKey(ctx context.Context, tx *sql.Tx, keyID int, kv keyentity.KV) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.ModifyKey")
+	defer span.End()
+
+	err := r.repo.ModifyKey(ctx, tx, keyID, kv)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) GetKeysByType(ctx context.Context, prefix, valType string) ([]keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetKeysByType")
+	defer span.End()
+
+	result, err := r.repo.GetKeysByType(ctx, prefix, valType)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) CreateComment(ctx context.Context, tx *sql.Tx, comment keyentity.Comment) (int, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.CreateComment")
+	defer span.End()
+
+	result, err := r.repo.CreateComment(ctx, tx, comment)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetCommentByID(ctx context.Context, id int) (keyentity.Comment, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetCommentByID")
+	defer span.End()
+
+	result, err := r.repo.GetCommentByID(ctx, id)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetComments(ctx context.Context, keyIDs []int) ([]keyentity.Comment, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetComments")
+	defer span.End()
+
+	result, err := r.repo.GetComments(ctx, keyIDs)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) SaveOwnership(ctx context.Context, tx *sql.Tx, ownership keyentity.Ownership) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.SaveOwnership")
+	defer span.End()
+
+	err := r.repo.SaveOwnership(ctx, tx, ownership)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) GetOwnerships(ctx context.Context) ([]keyentity.Ownership, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetOwnerships")
+	defer span.End()
+
+	result, err := r.repo.GetOwnerships(ctx)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) SaveKeyMetadata(ctx context.Context, metadata keyentity.KeyMetadata) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.SaveKeyMetadata")
+	defer span.End()
+
+	err := r.repo.SaveKeyMetadata(ctx, metadata)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) GetKeyMetadata(ctx context.Context, keys []string) ([]keyentity.KeyMetadata, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetKeyMetadata")
+	defer span.End()
+
+	result, err := r.repo.GetKeyMetadata(ctx, keys)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) ModifyKeyValue(ctx context.Context, tx *sql.Tx, keyID int, value string) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.ModifyKeyValue")
+	defer span.End()
+
+	err := r.repo.ModifyKeyValue(ctx, tx, keyID, value)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) SetCache(ctx context.Context, key keyentity.KV) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.SetCache")
+	defer span.End()
+
+	err := r.repo.SetCache(ctx, key)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) GetCache(ctx context.Context, key string) (keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetCache")
+	defer span.End()
+
+	result, err := r.repo.GetCache(ctx, key)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetCaches(ctx context.Context, key string) ([]keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetCaches")
+	defer span.End()
+
+	result, err := r.repo.GetCaches(ctx, key)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) InvalidateCache(ctx context.Context, key string) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.InvalidateCache")
+	defer span.End()
+
+	err := r.repo.InvalidateCache(ctx, key)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) ModifyOldActiveKey(ctx context.Context, tx *sql.Tx, key string) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.ModifyOldActiveKey")
+	defer span.End()
+
+	err := r.repo.ModifyOldActiveKey(ctx, tx, key)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) IsKeyExist(ctx context.Context, key string) bool {
+	ctx, span := tracing.Start(ctx, "keyRepository.IsKeyExist")
+	defer span.End()
+
+	return r.repo.IsKeyExist(ctx, key)
+}
+
+func (r tracedKeyRepository) GetCanaryKVByPrefix(ctx context.Context, prefix, ip, group string) ([]keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetCanaryKVByPrefix")
+	defer span.End()
+
+	result, err := r.repo.GetCanaryKVByPrefix(ctx, prefix, ip, group)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetCanaryCache(ctx context.Context, prefix, ip, group string) ([]keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetCanaryCache")
+	defer span.End()
+
+	result, err := r.repo.GetCanaryCache(ctx, prefix, ip, group)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) SetCanaryCache(ctx context.Context, prefix, ip, group string, kvs []keyentity.KV) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.SetCanaryCache")
+	defer span.End()
+
+	err := r.repo.SetCanaryCache(ctx, prefix, ip, group, kvs)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) InvalidateCanaryCache(ctx context.Context, key string) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.InvalidateCanaryCache")
+	defer span.End()
+
+	err := r.repo.InvalidateCanaryCache(ctx, key)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) CreatePrerequisite(ctx context.Context, prerequisite keyentity.Prerequisite) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.CreatePrerequisite")
+	defer span.End()
+
+	err := r.repo.CreatePrerequisite(ctx, prerequisite)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) GetPrerequisiteByID(ctx context.Context, id int) (keyentity.Prerequisite, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetPrerequisiteByID")
+	defer span.End()
+
+	result, err := r.repo.GetPrerequisiteByID(ctx, id)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetPrerequisites(ctx context.Context) ([]keyentity.Prerequisite, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetPrerequisites")
+	defer span.End()
+
+	result, err := r.repo.GetPrerequisites(ctx)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetPrerequisiteCache(ctx context.Context) ([]keyentity.Prerequisite, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetPrerequisiteCache")
+	defer span.End()
+
+	result, err := r.repo.GetPrerequisiteCache(ctx)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) SetPrerequisiteCache(ctx context.Context, prerequisites []keyentity.Prerequisite) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.SetPrerequisiteCache")
+	defer span.End()
+
+	err := r.repo.SetPrerequisiteCache(ctx, prerequisites)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) InvalidatePrerequisiteCache(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.InvalidatePrerequisiteCache")
+	defer span.End()
+
+	err := r.repo.InvalidatePrerequisiteCache(ctx)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) ModifyPrerequisiteStatus(ctx context.Context, id, status int) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.ModifyPrerequisiteStatus")
+	defer span.End()
+
+	err := r.repo.ModifyPrerequisiteStatus(ctx, id, status)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) SaveKeyReads(ctx context.Context, reads []keyentity.KeyRead) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.SaveKeyReads")
+	defer span.End()
+
+	err := r.repo.SaveKeyReads(ctx, reads)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) GetKeyReads(ctx context.Context, key string) ([]keyentity.KeyRead, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetKeyReads")
+	defer span.End()
+
+	result, err := r.repo.GetKeyReads(ctx, key)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetLastKeyReads(ctx context.Context, keys []string) ([]keyentity.KeyRead, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetLastKeyReads")
+	defer span.End()
+
+	result, err := r.repo.GetLastKeyReads(ctx, keys)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) CountKeyChanges(ctx context.Context, keys []string, status int) (map[string]int, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.CountKeyChanges")
+	defer span.End()
+
+	result, err := r.repo.CountKeyChanges(ctx, keys, status)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) CreateCanaryKey(ctx context.Context, tx *sql.Tx, id int, ip string) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.CreateCanaryKey")
+	defer span.End()
+
+	err := r.repo.CreateCanaryKey(ctx, tx, id, ip)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) CreateCanaryGroupKey(ctx context.Context, tx *sql.Tx, id int, group string) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.CreateCanaryGroupKey")
+	defer span.End()
+
+	err := r.repo.CreateCanaryGroupKey(ctx, tx, id, group)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) ModifyCanaryKey(ctx context.Context, tx *sql.Tx, id, status int) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.ModifyCanaryKey")
+	defer span.End()
+
+	err := r.repo.ModifyCanaryKey(ctx, tx, id, status)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) GetCanaryKVByID(ctx context.Context, id int) ([]keyentity.CanaryKV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetCanaryKVByID")
+	defer span.End()
+
+	result, err := r.repo.GetCanaryKVByID(ctx, id)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) SearchKeys(ctx context.Context, prefixes []string, query keyentity.SearchQuery) ([]keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.SearchKeys")
+	defer span.End()
+
+	result, err := r.repo.SearchKeys(ctx, prefixes, query)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) CreateChangeSet(ctx context.Context, tx *sql.Tx, changeSet keyentity.ChangeSet) (int, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.CreateChangeSet")
+	defer span.End()
+
+	result, err := r.repo.CreateChangeSet(ctx, tx, changeSet)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetKeyInEnvironment(ctx context.Context, environment, key string, status int) ([]keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetKeyInEnvironment")
+	defer span.End()
+
+	result, err := r.repo.GetKeyInEnvironment(ctx, environment, key, status)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetKeyByPrefixInEnvironment(ctx context.Context, environment, prefix string, status int) ([]keyentity.KV, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetKeyByPrefixInEnvironment")
+	defer span.End()
+
+	result, err := r.repo.GetKeyByPrefixInEnvironment(ctx, environment, prefix, status)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) CountKeyByPrefix(ctx context.Context, prefix string) (int, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.CountKeyByPrefix")
+	defer span.End()
+
+	result, err := r.repo.CountKeyByPrefix(ctx, prefix)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) CreateServiceEntry(ctx context.Context, tx *sql.Tx, service keyentity.Service) (int, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.CreateServiceEntry")
+	defer span.End()
+
+	result, err := r.repo.CreateServiceEntry(ctx, tx, service)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) GetServices(ctx context.Context) ([]keyentity.Service, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetServices")
+	defer span.End()
+
+	result, err := r.repo.GetServices(ctx)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) CreateCanaryGroup(ctx context.Context, group keyentity.CanaryGroup) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.CreateCanaryGroup")
+	defer span.End()
+
+	err := r.repo.CreateCanaryGroup(ctx, group)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) GetCanaryGroups(ctx context.Context, serviceID int) ([]keyentity.CanaryGroup, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetCanaryGroups")
+	defer span.End()
+
+	result, err := r.repo.GetCanaryGroups(ctx, serviceID)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) SetCanaryTarget(ctx context.Context, target keyentity.CanaryTarget, ttl time.Duration) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.SetCanaryTarget")
+	defer span.End()
+
+	err := r.repo.SetCanaryTarget(ctx, target, ttl)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) DeleteCanaryTarget(ctx context.Context, target keyentity.CanaryTarget) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.DeleteCanaryTarget")
+	defer span.End()
+
+	err := r.repo.DeleteCanaryTarget(ctx, target)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) GetCanaryTargets(ctx context.Context, serviceID int, group string) ([]keyentity.CanaryTarget, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetCanaryTargets")
+	defer span.End()
+
+	result, err := r.repo.GetCanaryTargets(ctx, serviceID, group)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) CreateCanaryGate(ctx context.Context, gate keyentity.CanaryGate) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.CreateCanaryGate")
+	defer span.End()
+
+	err := r.repo.CreateCanaryGate(ctx, gate)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) GetActiveCanaryGates(ctx context.Context) ([]keyentity.CanaryGate, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetActiveCanaryGates")
+	defer span.End()
+
+	result, err := r.repo.GetActiveCanaryGates(ctx)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedKeyRepository) ModifyCanaryGateStatus(ctx context.Context, tx *sql.Tx, keyID, status int) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.ModifyCanaryGateStatus")
+	defer span.End()
+
+	err := r.repo.ModifyCanaryGateStatus(ctx, tx, keyID, status)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) ModifyCanaryGateFailures(ctx context.Context, keyID, failures int) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.ModifyCanaryGateFailures")
+	defer span.End()
+
+	err := r.repo.ModifyCanaryGateFailures(ctx, keyID, failures)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) CreateCanaryDecision(ctx context.Context, tx *sql.Tx, decision keyentity.CanaryDecision) error {
+	ctx, span := tracing.Start(ctx, "keyRepository.CreateCanaryDecision")
+	defer span.End()
+
+	err := r.repo.CreateCanaryDecision(ctx, tx, decision)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedKeyRepository) GetCanaryDecisions(ctx context.Context, keyID int) ([]keyentity.CanaryDecision, error) {
+	ctx, span := tracing.Start(ctx, "keyRepository.GetCanaryDecisions")
+	defer span.End()
+
+	result, err := r.repo.GetCanaryDecisions(ctx, keyID)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) GetDBTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetDBTx")
+	defer span.End()
+
+	result, err := r.repo.GetDBTx(ctx, opts)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) VerifyUser(ctx context.Context, email, token string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.VerifyUser")
+	defer span.End()
+
+	result, err := r.repo.VerifyUser(ctx, email, token)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) CreateRole(ctx context.Context, tx *sql.Tx, prefix, permission string, userID int) (int, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.CreateRole")
+	defer span.End()
+
+	result, err := r.repo.CreateRole(ctx, tx, prefix, permission, userID)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) MapUserAccess(ctx context.Context, tx *sql.Tx, userID int, roles []userentity.Role) error {
+	ctx, span := tracing.Start(ctx, "userRepository.MapUserAccess")
+	defer span.End()
+
+	err := r.repo.MapUserAccess(ctx, tx, userID, roles)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedUserRepository) GetUser(ctx context.Context, username string) (userentity.User, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetUser")
+	defer span.End()
+
+	result, err := r.repo.GetUser(ctx, username)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) GetUserAccess(ctx context.Context, userID int) ([]userentity.Role, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetUserAccess")
+	defer span.End()
+
+	result, err := r.repo.GetUserAccess(ctx, userID)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) GetNamespace(ctx context.Context, name string) (userentity.Namespace, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetNamespace")
+	defer span.End()
+
+	result, err := r.repo.GetNamespace(ctx, name)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) GetNamespaces(ctx context.Context) ([]userentity.Namespace, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetNamespaces")
+	defer span.End()
+
+	result, err := r.repo.GetNamespaces(ctx)
+	tracing.RecordError(span, err)
+	return result, err
+}
@@ -0,0 +1,92 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/util"
+)
+
+// SearchKeys find active keys matching the query inside prefixes the user has access to
+func (u *Usecase) SearchKeys(ctx context.Context, userID int, query keyentity.SearchQuery) ([]keyentity.KV, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.SearchKeys", u.timeout.Read)
+	defer finish()
+
+	if query.Text == "" && len(query.Tags) == 0 && !query.Expired {
+		return nil, errors.New("Search text can not be empty.")
+	}
+
+	switch query.Mode {
+	case "":
+		query.Mode = keyentity.SearchModeSubstring
+	case keyentity.SearchModeSubstring:
+	case keyentity.SearchModeRegex:
+		if _, err := regexp.Compile(query.Text); err != nil {
+			return nil, errors.New("Invalid regex.")
+		}
+	case keyentity.SearchModeJSONPath:
+		if !strings.HasPrefix(query.Text, "$") {
+			return nil, errors.New("JSON path must start with $.")
+		}
+		// json path only make sense for json value
+		query.InKey = false
+		query.InValue = true
+		query.InMetadata = false
+	default:
+		return nil, errors.New("Unknown search mode.")
+	}
+
+	if !query.InKey && !query.InValue && !query.InMetadata {
+		query.InKey = true
+		query.InValue = true
+		query.InMetadata = true
+	}
+
+	if query.Limit <= 0 || query.Limit > maxPageLimit {
+		query.Limit = defaultPageLimit
+	}
+
+	roles, err := u.userRepo.GetUserAccess(ctx, userID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	prefixes := accessiblePrefixes(roles, query.Prefix)
+	if len(prefixes) == 0 {
+		return nil, errors.New("You don't have access to this prefix.")
+	}
+
+	keys, err := u.keyRepo.SearchKeys(ctx, prefixes, query)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	maskSecrets(keys)
+	return keys, nil
+}
+
+// accessiblePrefixes returns part of the prefix that covered by user roles.
+// Role above the prefix give access to the whole prefix, role below the prefix only give access to the role prefix.
+func accessiblePrefixes(roles []userentity.Role, prefix string) []string {
+	prefixes := make([]string, 0)
+	for _, role := range roles {
+		if role.Prefix == "" {
+			continue
+		}
+
+		if util.IsUnderPrefix(prefix, role.Prefix) {
+			return []string{prefix}
+		}
+
+		if util.IsUnderPrefix(role.Prefix, prefix) {
+			prefixes = append(prefixes, role.Prefix)
+		}
+	}
+
+	return prefixes
+}
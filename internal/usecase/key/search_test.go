@@ -0,0 +1,125 @@
+package key
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+func TestSearchKeysMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   keyentity.SearchQuery
+		want    keyentity.SearchQuery
+		wantErr bool
+	}{
+		{
+			name:  "substring by default in every field",
+			query: keyentity.SearchQuery{Prefix: "service/risk", Text: "sauron"},
+			want: keyentity.SearchQuery{
+				Prefix: "service/risk", Text: "sauron", Mode: keyentity.SearchModeSubstring,
+				InKey: true, InValue: true, InMetadata: true, Limit: defaultPageLimit,
+			},
+		},
+		{
+			name:  "regex in value only",
+			query: keyentity.SearchQuery{Prefix: "service/risk", Text: "^v[0-9]+$", Mode: keyentity.SearchModeRegex, InValue: true, Limit: 10},
+			want: keyentity.SearchQuery{
+				Prefix: "service/risk", Text: "^v[0-9]+$", Mode: keyentity.SearchModeRegex, InValue: true, Limit: 10,
+			},
+		},
+		{
+			name:    "invalid regex",
+			query:   keyentity.SearchQuery{Prefix: "service/risk", Text: "v[0-9", Mode: keyentity.SearchModeRegex},
+			wantErr: true,
+		},
+		{
+			name:  "json path only match value",
+			query: keyentity.SearchQuery{Prefix: "service/risk", Text: "$.rules[*].enabled", Mode: keyentity.SearchModeJSONPath, InKey: true, Limit: maxPageLimit + 1},
+			want: keyentity.SearchQuery{
+				Prefix: "service/risk", Text: "$.rules[*].enabled", Mode: keyentity.SearchModeJSONPath, InValue: true, Limit: defaultPageLimit,
+			},
+		},
+		{
+			name:    "json path without root",
+			query:   keyentity.SearchQuery{Prefix: "service/risk", Text: "rules", Mode: keyentity.SearchModeJSONPath},
+			wantErr: true,
+		},
+		{
+			name:    "unknown mode",
+			query:   keyentity.SearchQuery{Prefix: "service/risk", Text: "sauron", Mode: "fuzzy"},
+			wantErr: true,
+		},
+		{
+			name:    "empty text",
+			query:   keyentity.SearchQuery{Prefix: "service/risk"},
+			wantErr: true,
+		},
+		{
+			name:  "tags without text",
+			query: keyentity.SearchQuery{Prefix: "service/risk", Tags: []string{"checkout"}},
+			want: keyentity.SearchQuery{
+				Prefix: "service/risk", Mode: keyentity.SearchModeSubstring, Tags: []string{"checkout"},
+				InKey: true, InValue: true, InMetadata: true, Limit: defaultPageLimit,
+			},
+		},
+		{
+			// expiry is matched by the repository before its limit, the result is not filtered again
+			name:  "expired without text",
+			query: keyentity.SearchQuery{Prefix: "service/risk", Expired: true, Limit: 10},
+			want: keyentity.SearchQuery{
+				Prefix: "service/risk", Mode: keyentity.SearchModeSubstring, Expired: true,
+				InKey: true, InValue: true, InMetadata: true, Limit: 10,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := newTestUsecase(t)
+			if !tt.wantErr {
+				deps.keyRepo.EXPECT().SearchKeys(gomock.Any(), []string{"service/risk"}, tt.want).
+					Return([]keyentity.KV{{Key: "service/risk/token", Value: "secret", Type: keyentity.TypeSecret}}, nil)
+			}
+
+			kvs, err := u.SearchKeys(context.Background(), testUser, tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SearchKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// matched secret is listed but never revealed
+			if !tt.wantErr && (len(kvs) != 1 || kvs[0].Value != keyentity.SecretMask) {
+				t.Fatalf("SearchKeys() = %+v, want masked secret", kvs)
+			}
+		})
+	}
+}
+
+func TestAccessiblePrefixes(t *testing.T) {
+	roles := []userentity.Role{
+		{Prefix: "service/risk", Permission: userentity.RoleUser},
+		{Prefix: "service/payment/gateway", Permission: userentity.RoleUser},
+		{Prefix: "service/payment/ledger", Permission: userentity.RoleUser},
+	}
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "service/risk/sauron", want: []string{"service/risk/sauron"}},
+		{prefix: "service/payment", want: []string{"service/payment/gateway", "service/payment/ledger"}},
+		{prefix: "service", want: []string{"service/risk", "service/payment/gateway", "service/payment/ledger"}},
+		// sibling sharing the name start is not covered
+		{prefix: "service/risky", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := accessiblePrefixes(roles, tt.prefix); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("accessiblePrefixes(%q) = %v, want %v", tt.prefix, got, tt.want)
+		}
+	}
+}
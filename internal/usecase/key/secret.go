@@ -0,0 +1,235 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/secret"
+)
+
+// SetKeyring enable secret typed keys, without keyring secret value can not be written
+func (u *Usecase) SetKeyring(keyring *secret.Keyring) {
+	u.keyring = keyring
+}
+
+// RotateSecrets rewrap every secret value under the prefix with the current key encryption key,
+// so old key can be removed from the keyring. It returns number of rewrapped values.
+func (u *Usecase) RotateSecrets(ctx context.Context, prefix string, userID int) (int, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.RotateSecrets", u.timeout.Background)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, prefix, userentity.RoleAdmin); err != nil {
+		return 0, err
+	}
+
+	if u.keyring == nil {
+		return 0, errors.New("Secret keyring is not configured.")
+	}
+
+	// every status, pending and history rows must be readable after the old key is gone
+	secretKeys, err := u.keyRepo.GetKeysByType(ctx, prefix, keyentity.TypeSecret)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	secretKeys = underPrefix(secretKeys, prefix)
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer endTx()
+
+	rotated := 0
+	activeKeys := make([]keyentity.KV, 0)
+	for _, kv := range secretKeys {
+		if !secret.IsEncrypted(kv.Value) {
+			continue
+		}
+
+		value, changed, err := u.keyring.Rewrap(kv.Key, kv.Value)
+		if err != nil {
+			return 0, err
+		}
+		if !changed {
+			continue
+		}
+
+		if err := u.keyRepo.ModifyKeyValue(ctx, tx, kv.ID, value); err != nil {
+			return 0, err
+		}
+		rotated++
+
+		if kv.Status == keyentity.ApprovedAndActive {
+			kv.Value = value
+			activeKeys = append(activeKeys, kv)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	endTx()
+
+	// encrypted cache still hold value wrapped by the old key
+	if u.keyring.EncryptCache() {
+		for _, kv := range activeKeys {
+			if err := u.keyRepo.SetCache(ctx, kv); err != nil {
+				return rotated, err
+			}
+		}
+	}
+
+	u.log.InfoContext(ctx, "secrets rotated",
+		slog.String("prefix", prefix),
+		slog.Int("actor", userID),
+		slog.Int("rotated", rotated),
+	)
+
+	return rotated, nil
+}
+
+// encryptSecret encrypt value of secret typed key given by the user before it is stored, bound to the key name.
+// The value is always encrypted, even when it looks encrypted, otherwise a copied ciphertext of another key
+// would be stored as is and decrypted for the user. Values read back from storage must not be passed here.
+func (u *Usecase) encryptSecret(kv keyentity.KV) (keyentity.KV, error) {
+	if kv.Type != keyentity.TypeSecret {
+		return kv, nil
+	}
+
+	if u.keyring == nil {
+		return kv, errors.New("Secret keyring is not configured.")
+	}
+
+	value, err := u.keyring.Encrypt(kv.Key, kv.Value)
+	if err != nil {
+		return kv, err
+	}
+
+	kv.Value = value
+	return kv, nil
+}
+
+// decryptSecret return stored secret typed key with its plain value, so it can be compared with plain values
+func (u *Usecase) decryptSecret(kv keyentity.KV) (keyentity.KV, error) {
+	if kv.Type != keyentity.TypeSecret || !secret.IsEncrypted(kv.Value) {
+		return kv, nil
+	}
+
+	if u.keyring == nil {
+		return kv, errors.New("Secret keyring is not configured.")
+	}
+
+	value, err := u.keyring.Decrypt(kv.Key, kv.Value)
+	if err != nil {
+		return kv, err
+	}
+
+	kv.Value = value
+	return kv, nil
+}
+
+// cachedKey return the key as it should be stored in redis and consul
+func (u *Usecase) cachedKey(kv keyentity.KV) (keyentity.KV, error) {
+	if kv.Type != keyentity.TypeSecret || u.keyring == nil || u.keyring.EncryptCache() || !secret.IsEncrypted(kv.Value) {
+		return kv, nil
+	}
+
+	value, err := u.keyring.Decrypt(kv.Key, kv.Value)
+	if err != nil {
+		return kv, err
+	}
+
+	kv.Value = value
+	return kv, nil
+}
+
+// revealSecrets decrypt secret values the reader user can read and mask the rest
+func (u *Usecase) revealSecrets(ctx context.Context, kvs []keyentity.KV, userID int) error {
+	var roles []userentity.Role
+	rolesLoaded := false
+
+	for i, kv := range kvs {
+		if kv.Type != keyentity.TypeSecret {
+			continue
+		}
+
+		if !rolesLoaded && userID > 0 {
+			var err error
+			roles, err = u.userRepo.GetUserAccess(ctx, userID)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+		}
+		rolesLoaded = true
+
+		if !userentity.HasPermission(roles, kv.Key, userentity.RoleUser) {
+			kvs[i].Value = keyentity.SecretMask
+			continue
+		}
+
+		// value from decrypted cache
+		if !secret.IsEncrypted(kv.Value) {
+			continue
+		}
+
+		if u.keyring == nil {
+			return errors.New("Secret keyring is not configured.")
+		}
+
+		value, err := u.keyring.Decrypt(kv.Key, kv.Value)
+		if err != nil {
+			return err
+		}
+		kvs[i].Value = value
+	}
+
+	return nil
+}
+
+// sameValue compare values of the key of the type, secrets are compared by their plaintext
+func (u *Usecase) sameValue(key, valType, a, b string) (bool, error) {
+	if valType != keyentity.TypeSecret || u.keyring == nil {
+		return a == b, nil
+	}
+
+	var err error
+	if secret.IsEncrypted(a) {
+		if a, err = u.keyring.Decrypt(key, a); err != nil {
+			return false, err
+		}
+	}
+	if secret.IsEncrypted(b) {
+		if b, err = u.keyring.Decrypt(key, b); err != nil {
+			return false, err
+		}
+	}
+
+	return a == b, nil
+}
+
+func maskSecret(kv keyentity.KV) keyentity.KV {
+	if kv.Type == keyentity.TypeSecret {
+		kv.Value = maskValue(kv.Value)
+	}
+
+	return kv
+}
+
+func maskSecrets(kvs []keyentity.KV) {
+	for i := range kvs {
+		kvs[i] = maskSecret(kvs[i])
+	}
+}
+
+// maskValue keep empty value empty, so deletion is still visible
+func maskValue(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return keyentity.SecretMask
+}
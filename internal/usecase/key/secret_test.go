@@ -0,0 +1,125 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/marde12345/key-flag/internal/config"
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	"github.com/marde12345/key-flag/internal/secret"
+)
+
+func newTestKeyring(t *testing.T) *secret.Keyring {
+	t.Helper()
+
+	keyring, err := secret.NewKeyring(config.Secret{
+		CurrentKeyID: "k1",
+		Keys:         map[string]string{"k1": base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keyring
+}
+
+func TestUpdateKeyEncryptValueThatLooksEncrypted(t *testing.T) {
+	keyring := newTestKeyring(t)
+	u, deps := newTestUsecase(t)
+	u.SetKeyring(keyring)
+	deps.expectNoPrerequisite()
+	deps.expectKeys("service/risk/token", nil)
+
+	// ciphertext of a secret of another service the user can not read
+	copied, err := keyring.Encrypt("service/payment/password", "payment password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stored string
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().CreateKey(gomock.Any(), gomock.Any(), "service/risk/token", gomock.Any(), keyentity.TypeSecret, testUser, keyentity.PlacedKey).
+		DoAndReturn(func(ctx context.Context, tx *sql.Tx, key, value, valType string, userID, status int) (int, error) {
+			stored = value
+			return 1, nil
+		})
+	deps.db.ExpectCommit()
+
+	kv := keyentity.KV{Key: "service/risk/token", Value: copied, Type: keyentity.TypeSecret, CreatedBy: testUser}
+	if err := u.UpdateKey(context.Background(), kv, 0, ""); err != nil {
+		t.Fatalf("UpdateKey() error = %v", err)
+	}
+
+	if stored == copied {
+		t.Fatal("value that looks encrypted is stored as is")
+	}
+
+	value, err := keyring.Decrypt("service/risk/token", stored)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if value != copied {
+		t.Fatalf("decrypted value = %q, want the value given by the user", value)
+	}
+}
+
+func TestApproveKeyPrerequisiteOfSecret(t *testing.T) {
+	keyring := newTestKeyring(t)
+	u, deps := newTestUsecase(t)
+	u.SetKeyring(keyring)
+	deps.expectNoOwnership()
+
+	stored, err := keyring.Encrypt("service/risk/token", "on")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// service/risk/flag requires the token, required values are compared in plain text
+	prerequisite := keyentity.Prerequisite{Key: "service/risk/flag", RequiredKey: "service/risk/token", RequiredValue: "on", FallbackValue: "false"}
+	deps.keyRepo.EXPECT().GetPrerequisites(gomock.Any()).Return([]keyentity.Prerequisite{prerequisite}, nil)
+	deps.expectKeys("service/risk/token", map[int][]keyentity.KV{
+		keyentity.PlacedKey: {{ID: 1, Key: "service/risk/token", Value: stored, Type: keyentity.TypeSecret, Status: keyentity.PlacedKey}},
+	})
+
+	// so a required key can not become a secret
+	if err := u.ApproveKey(context.Background(), "service/risk/token", testAdmin, keyentity.ApprovedKey, ""); err == nil {
+		t.Fatal("ApproveKey() error = nil, want secret prerequisite error")
+	}
+}
+
+func TestApproveKeySecretDependent(t *testing.T) {
+	keyring := newTestKeyring(t)
+	u, deps := newTestUsecase(t)
+	u.SetKeyring(keyring)
+	deps.expectNoOwnership()
+
+	fallback, err := keyring.Encrypt("service/risk/token", "none")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the secret dependent already holds the fallback, its ciphertext would not match it
+	prerequisite := keyentity.Prerequisite{Key: "service/risk/token", RequiredKey: "service/risk/enabled", RequiredValue: "true", FallbackValue: "none"}
+	deps.keyRepo.EXPECT().GetPrerequisites(gomock.Any()).Return([]keyentity.Prerequisite{prerequisite}, nil)
+	deps.keyRepo.EXPECT().GetKeyByPrefix(gomock.Any(), prerequisite.Prefix(), keyentity.ApprovedAndActive).
+		Return([]keyentity.KV{{Key: "service/risk/token", Value: fallback, Type: keyentity.TypeSecret, Status: keyentity.ApprovedAndActive}}, nil)
+	deps.expectKeys("service/risk/enabled", map[int][]keyentity.KV{
+		keyentity.PlacedKey: {{ID: 1, Key: "service/risk/enabled", Value: "false", Status: keyentity.PlacedKey}},
+	})
+
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().ModifyKey(gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().ModifyOldActiveKey(gomock.Any(), gomock.Any(), "service/risk/enabled").Return(nil)
+	deps.keyRepo.EXPECT().CreateKeyEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	deps.keyRepo.EXPECT().SetCache(gomock.Any(), gomock.Any()).Return(nil)
+	deps.db.ExpectCommit()
+
+	if err := u.ApproveKey(context.Background(), "service/risk/enabled", testAdmin, keyentity.ApprovedKey, ""); err != nil {
+		t.Fatalf("ApproveKey() error = %v", err)
+	}
+}
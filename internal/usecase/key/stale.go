@@ -0,0 +1,208 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+// GetStaleKeys list active keys under the prefix unchanged for at least minAge and temporary keys past their expiry date, oldest first.
+// Keys with pending change are skipped since somebody is still working on them.
+func (u *Usecase) GetStaleKeys(ctx context.Context, prefix string, minAge time.Duration, userID int) ([]keyentity.StaleKey, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetStaleKeys", u.timeout.Read)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, prefix, userentity.RoleUser); err != nil {
+		return nil, err
+	}
+
+	staleKeys, err := u.staleKeys(ctx, prefix, minAge)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range staleKeys {
+		staleKeys[i].KV = maskSecret(staleKeys[i].KV)
+	}
+
+	return staleKeys, nil
+}
+
+// CreateStaleDeleteRequests place delete request of the stale keys under one change set for the owning team to approve.
+// Empty keys means every stale key under the prefix.
+func (u *Usecase) CreateStaleDeleteRequests(ctx context.Context, prefix string, minAge time.Duration, keys []string, userID int) (keyentity.StaleCleanupResult, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.CreateStaleDeleteRequests", u.timeout.Write)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, prefix, userentity.RoleUser); err != nil {
+		return keyentity.StaleCleanupResult{}, err
+	}
+
+	staleKeys, err := u.staleKeys(ctx, prefix, minAge)
+	if err != nil {
+		return keyentity.StaleCleanupResult{}, err
+	}
+
+	staleByKey := make(map[string]keyentity.StaleKey, len(staleKeys))
+	for _, staleKey := range staleKeys {
+		staleByKey[staleKey.KV.Key] = staleKey
+	}
+
+	if len(keys) == 0 {
+		for _, staleKey := range staleKeys {
+			keys = append(keys, staleKey.KV.Key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return keyentity.StaleCleanupResult{}, errors.New("No stale key found.")
+	}
+
+	for _, key := range keys {
+		if _, ok := staleByKey[key]; !ok {
+			return keyentity.StaleCleanupResult{}, fmt.Errorf("Key %s is not stale.", key)
+		}
+
+		if err := u.validatePrerequisites(ctx, keyentity.KV{Key: key}, true); err != nil {
+			return keyentity.StaleCleanupResult{}, err
+		}
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return keyentity.StaleCleanupResult{}, err
+	}
+	defer endTx()
+
+	description := fmt.Sprintf("Delete %d stale keys under %s", len(keys), prefix)
+	changeSetID, err := u.keyRepo.CreateChangeSet(ctx, tx, keyentity.ChangeSet{
+		Description: description,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		return keyentity.StaleCleanupResult{}, err
+	}
+
+	placedKeys := make([]keyentity.KV, 0, len(keys))
+	for _, key := range keys {
+		kv := staleByKey[key].KV
+		placedKey := keyentity.KV{
+			Key:         kv.Key,
+			Value:       kv.Value,
+			Type:        kv.Type,
+			CreatedBy:   userID,
+			Status:      keyentity.PlacedDeleteKey,
+			ChangeSetID: changeSetID,
+			Environment: keyentity.EnvironmentFromContext(ctx),
+		}
+		if err := u.keyRepo.CreateKeyEntry(ctx, tx, placedKey); err != nil {
+			return keyentity.StaleCleanupResult{}, err
+		}
+		placedKeys = append(placedKeys, placedKey)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return keyentity.StaleCleanupResult{}, err
+	}
+	endTx()
+
+	// owning team approves the deletion, so it is told the same way as for UpdateKey
+	for _, kv := range placedKeys {
+		u.logTransition(ctx, kv, userID)
+		u.notify(ctx, kv, userID, description)
+	}
+
+	return keyentity.StaleCleanupResult{ChangeSetID: changeSetID, Keys: keys}, nil
+}
+
+func (u *Usecase) staleKeys(ctx context.Context, prefix string, minAge time.Duration) ([]keyentity.StaleKey, error) {
+	if minAge <= 0 {
+		minAge = keyentity.DefaultStaleAge
+	}
+
+	activeKeys, err := u.keyRepo.GetKeyByPrefix(ctx, prefix, keyentity.ApprovedAndActive)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	activeKeys = underPrefix(activeKeys, prefix)
+
+	names := make([]string, 0, len(activeKeys))
+	for _, kv := range activeKeys {
+		names = append(names, kv.Key)
+	}
+
+	expired, err := u.expiredKeys(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[string]bool)
+	for _, status := range []int{keyentity.PlacedKey, keyentity.PlacedDeleteKey, keyentity.CanaryKey} {
+		keys, err := u.keyRepo.GetKeyByPrefix(ctx, prefix, status)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+
+		for _, kv := range keys {
+			pending[kv.Key] = true
+		}
+	}
+
+	now := time.Now()
+	candidates := make([]keyentity.KV, 0)
+	for _, kv := range activeKeys {
+		if (now.Sub(kv.UpdateTime) < minAge && !expired[kv.Key]) || pending[kv.Key] {
+			continue
+		}
+
+		candidates = append(candidates, kv)
+	}
+
+	if len(candidates) == 0 {
+		return []keyentity.StaleKey{}, nil
+	}
+
+	candidateNames := make([]string, 0, len(candidates))
+	for _, kv := range candidates {
+		candidateNames = append(candidateNames, kv.Key)
+	}
+
+	// approved rows tell if the key was ever changed after it was created
+	changes, err := u.keyRepo.CountKeyChanges(ctx, candidateNames, keyentity.ApprovedKey)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	reads, err := u.keyRepo.GetLastKeyReads(ctx, candidateNames)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	lastRead := make(map[string]keyentity.KeyRead, len(reads))
+	for _, read := range reads {
+		lastRead[read.Key] = read
+	}
+
+	result := make([]keyentity.StaleKey, 0, len(candidates))
+	for _, kv := range candidates {
+		result = append(result, keyentity.StaleKey{
+			KV:       kv,
+			Age:      now.Sub(kv.UpdateTime),
+			Changes:  changes[kv.Key],
+			LastRead: lastRead[kv.Key],
+			Expired:  expired[kv.Key],
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Age > result[j].Age
+	})
+
+	return result, nil
+}
@@ -0,0 +1,93 @@
+package key
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+// expectStaleKeys make service/risk/old and service/risk/older stale, the batch lookups are expected once
+func expectStaleKeys(deps *testDeps, lastRead time.Time) {
+	now := time.Now()
+	activeKeys := []keyentity.KV{
+		{ID: 1, Key: "service/risk/old", Value: "true", Status: keyentity.ApprovedAndActive, UpdateTime: now.Add(-100 * 24 * time.Hour)},
+		{ID: 2, Key: "service/risk/older", Value: "true", Status: keyentity.ApprovedAndActive, UpdateTime: now.Add(-200 * 24 * time.Hour)},
+		{ID: 3, Key: "service/risk/recent", Value: "true", Status: keyentity.ApprovedAndActive, UpdateTime: now},
+	}
+	deps.keyRepo.EXPECT().GetKeyByPrefix(gomock.Any(), "service/risk", gomock.Any()).DoAndReturn(func(ctx context.Context, prefix string, status int) ([]keyentity.KV, error) {
+		if status == keyentity.ApprovedAndActive {
+			return activeKeys, nil
+		}
+		return nil, nil
+	}).AnyTimes()
+	deps.keyRepo.EXPECT().GetKeyMetadata(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	staleNames := []string{"service/risk/old", "service/risk/older"}
+	deps.keyRepo.EXPECT().CountKeyChanges(gomock.Any(), staleNames, keyentity.ApprovedKey).Return(map[string]int{"service/risk/old": 4}, nil)
+	deps.keyRepo.EXPECT().GetLastKeyReads(gomock.Any(), staleNames).Return([]keyentity.KeyRead{
+		{Key: "service/risk/older", Client: "sauron", LastReadTime: lastRead},
+	}, nil)
+}
+
+func TestGetStaleKeys(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	lastRead := time.Now().Add(-time.Hour)
+	expectStaleKeys(deps, lastRead)
+
+	staleKeys, err := u.GetStaleKeys(context.Background(), "service/risk", 0, testUser)
+	if err != nil {
+		t.Fatalf("GetStaleKeys() error = %v", err)
+	}
+
+	// oldest first, changes and last read come from one lookup of every stale key
+	if len(staleKeys) != 2 {
+		t.Fatalf("GetStaleKeys() = %+v, want 2 keys", staleKeys)
+	}
+	if staleKeys[0].KV.Key != "service/risk/older" || staleKeys[0].Changes != 0 || !staleKeys[0].LastRead.LastReadTime.Equal(lastRead) {
+		t.Fatalf("GetStaleKeys()[0] = %+v, want older key read an hour ago", staleKeys[0])
+	}
+	if staleKeys[1].KV.Key != "service/risk/old" || staleKeys[1].Changes != 4 || !staleKeys[1].LastRead.LastReadTime.IsZero() {
+		t.Fatalf("GetStaleKeys()[1] = %+v, want old key changed 4 times and never read", staleKeys[1])
+	}
+}
+
+func TestCreateStaleDeleteRequestsNotifyOwners(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	notifier := &blockingNotifier{release: make(chan struct{})}
+	close(notifier.release)
+	u.SetNotifier(notifier)
+	deps.expectNoOwnership()
+	deps.expectNoPrerequisite()
+	expectStaleKeys(deps, time.Time{})
+
+	deps.db.ExpectBegin()
+	deps.keyRepo.EXPECT().CreateChangeSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(9, nil)
+	deps.keyRepo.EXPECT().CreateKeyEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	deps.db.ExpectCommit()
+
+	result, err := u.CreateStaleDeleteRequests(context.Background(), "service/risk", 0, nil, testUser)
+	if err != nil {
+		t.Fatalf("CreateStaleDeleteRequests() error = %v", err)
+	}
+	if result.ChangeSetID != 9 || len(result.Keys) != 2 {
+		t.Fatalf("CreateStaleDeleteRequests() = %+v, want 2 keys in change set 9", result)
+	}
+
+	u.WaitNotifications()
+	notified := make([]string, 0, len(notifier.sent))
+	for _, notification := range notifier.sent {
+		if notification.KV.Status != keyentity.PlacedDeleteKey {
+			t.Fatalf("notification = %+v, want placed delete", notification)
+		}
+		notified = append(notified, notification.KV.Key)
+	}
+	sort.Strings(notified)
+	if len(notified) != 2 || notified[0] != "service/risk/old" || notified[1] != "service/risk/older" {
+		t.Fatalf("notified = %v, want both stale keys", notified)
+	}
+}
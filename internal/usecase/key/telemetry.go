@@ -0,0 +1,208 @@
+package key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+type readKey struct {
+	environment string
+	key         string
+	client      string
+	ip          string
+}
+
+// readRecorder aggregate sampled reads in memory until they are flushed.
+// Sample rate is read without the lock, so reads that are not sampled never wait for each other.
+type readRecorder struct {
+	sampleRate atomic.Uint64
+	mu         sync.Mutex
+	maxReads   int
+	reads      map[readKey]keyentity.KeyRead
+	dropped    int64
+}
+
+func newReadRecorder(sampleRate float64, maxReads int) *readRecorder {
+	r := &readRecorder{
+		maxReads: maxReads,
+		reads:    make(map[readKey]keyentity.KeyRead),
+	}
+	r.setSampleRate(sampleRate)
+
+	return r
+}
+
+func (r *readRecorder) setSampleRate(rate float64) {
+	r.sampleRate.Store(math.Float64bits(math.Min(rate, 1)))
+}
+
+// record is sampled per call, every key of sampled call is counted with weight of the sample rate.
+// Reads of the same key in different environments are counted apart.
+func (r *readRecorder) record(environment string, keys []string, reader keyentity.Reader) {
+	sampleRate := math.Float64frombits(r.sampleRate.Load())
+	if sampleRate <= 0 || len(keys) == 0 {
+		return
+	}
+	if sampleRate < 1 && rand.Float64() >= sampleRate {
+		return
+	}
+
+	weight := int64(math.Round(1 / sampleRate))
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		k := readKey{environment: environment, key: key, client: reader.Client, ip: reader.IP}
+		read, ok := r.reads[k]
+		if !ok && len(r.reads) >= r.maxReads {
+			r.dropped += weight
+			continue
+		}
+
+		read.Environment, read.Key, read.Client, read.IP = environment, key, reader.Client, reader.IP
+		read.Count += weight
+		read.LastReadTime = now
+		r.reads[k] = read
+	}
+}
+
+// take returns aggregated reads and estimated count of dropped reads, then reset the recorder
+func (r *readRecorder) take() ([]keyentity.KeyRead, int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reads := make([]keyentity.KeyRead, 0, len(r.reads))
+	for _, read := range r.reads {
+		reads = append(reads, read)
+	}
+	dropped := r.dropped
+	r.reads = make(map[readKey]keyentity.KeyRead)
+	r.dropped = 0
+
+	return reads, dropped
+}
+
+// restore put back reads failed to be flushed so they are retried on the next flush,
+// reads that no longer fit are dropped
+func (r *readRecorder) restore(reads []keyentity.KeyRead) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, read := range reads {
+		k := readKey{environment: read.Environment, key: read.Key, client: read.Client, ip: read.IP}
+		current, ok := r.reads[k]
+		if !ok {
+			if len(r.reads) >= r.maxReads {
+				r.dropped += read.Count
+				continue
+			}
+
+			r.reads[k] = read
+			continue
+		}
+
+		current.Count += read.Count
+		r.reads[k] = current
+	}
+}
+
+// SetReadSampleRate set fraction of reads recorded by telemetry, 0 disable it and 1 record every read
+func (u *Usecase) SetReadSampleRate(rate float64) {
+	u.reads.setSampleRate(rate)
+}
+
+// RunReadTelemetry flush recorded reads every interval until ctx is done, remaining reads are flushed on exit
+func (u *Usecase) RunReadTelemetry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			_ = u.FlushReadTelemetry(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+			// failed reads are kept for the next round
+			_ = u.FlushReadTelemetry(ctx)
+		}
+	}
+}
+
+func (u *Usecase) FlushReadTelemetry(ctx context.Context) error {
+	ctx, finish := u.start(ctx, "key.Usecase.FlushReadTelemetry", u.timeout.Background)
+	defer finish()
+
+	reads, dropped := u.reads.take()
+	if dropped > 0 {
+		u.log.WarnContext(ctx, "read telemetry dropped reads above the pending limit", slog.Int64("dropped", dropped))
+	}
+	if len(reads) == 0 {
+		return nil
+	}
+
+	if err := u.keyRepo.SaveKeyReads(ctx, reads); err != nil {
+		u.reads.restore(reads)
+		return err
+	}
+
+	return nil
+}
+
+// GetKeyReads returns clients that read the key, most recent first
+func (u *Usecase) GetKeyReads(ctx context.Context, key string, userID int) ([]keyentity.KeyRead, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetKeyReads", u.timeout.Read)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, key, userentity.RoleUser); err != nil {
+		return nil, err
+	}
+
+	reads, err := u.keyRepo.GetKeyReads(ctx, key)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	return reads, nil
+}
+
+// GetKeyDetail returns active version of the key along with its readers
+func (u *Usecase) GetKeyDetail(ctx context.Context, key string, userID int) (keyentity.KeyDetail, error) {
+	ctx, finish := u.start(ctx, "key.Usecase.GetKeyDetail", u.timeout.Read)
+	defer finish()
+
+	if err := u.authorize(ctx, userID, key, userentity.RoleUser); err != nil {
+		return keyentity.KeyDetail{}, err
+	}
+
+	kv, found, err := u.activeKey(ctx, key)
+	if err != nil {
+		return keyentity.KeyDetail{}, err
+	}
+	if !found {
+		return keyentity.KeyDetail{}, errors.New("No key found")
+	}
+
+	reads, err := u.keyRepo.GetKeyReads(ctx, key)
+	if err != nil && err != sql.ErrNoRows {
+		return keyentity.KeyDetail{}, err
+	}
+
+	metadata, err := u.getKeyMetadata(ctx, []string{key})
+	if err != nil {
+		return keyentity.KeyDetail{}, err
+	}
+
+	return keyentity.KeyDetail{KV: maskSecret(kv), Metadata: metadata[key], Reads: reads}, nil
+}
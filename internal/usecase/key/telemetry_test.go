@@ -0,0 +1,123 @@
+package key
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	keyentity "github.com/marde12345/key-flag/internal/entity/key"
+)
+
+func TestReadRecorderPendingLimit(t *testing.T) {
+	r := newReadRecorder(1, 2)
+	reader := keyentity.Reader{Client: "sauron", IP: "10.0.0.1"}
+
+	r.record(keyentity.DefaultEnvironment, []string{"a", "b", "c"}, reader)
+	// known key is still counted when the recorder is full
+	r.record(keyentity.DefaultEnvironment, []string{"a"}, reader)
+
+	reads, dropped := r.take()
+	if len(reads) != 2 {
+		t.Fatalf("reads = %+v, want 2", reads)
+	}
+	if dropped != 1 {
+		t.Fatalf("dropped = %d, want 1", dropped)
+	}
+	for _, read := range reads {
+		if read.Key == "a" && read.Count != 2 {
+			t.Fatalf("count of a = %d, want 2", read.Count)
+		}
+	}
+
+	// failed flush of a and b while d is recorded, a is merged and b no longer fits
+	r.record(keyentity.DefaultEnvironment, []string{"d"}, reader)
+	r.record(keyentity.DefaultEnvironment, []string{"a"}, reader)
+	r.restore([]keyentity.KeyRead{
+		{Environment: keyentity.DefaultEnvironment, Key: "a", Client: reader.Client, IP: reader.IP, Count: 2},
+		{Environment: keyentity.DefaultEnvironment, Key: "b", Client: reader.Client, IP: reader.IP, Count: 5},
+	})
+	if reads, dropped = r.take(); len(reads) != 2 || dropped != 5 {
+		t.Fatalf("after restore reads = %+v dropped = %d, want 2 reads and 5 dropped", reads, dropped)
+	}
+}
+
+func TestReadRecorderConcurrentRecord(t *testing.T) {
+	r := newReadRecorder(1, keyentity.DefaultMaxPendingReads)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r.record(keyentity.DefaultEnvironment, []string{"a"}, keyentity.Reader{Client: fmt.Sprint(i)})
+				if j == 50 {
+					r.setSampleRate(1)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	reads, _ := r.take()
+	total := int64(0)
+	for _, read := range reads {
+		total += read.Count
+	}
+	if total != 800 {
+		t.Fatalf("total count = %d, want 800", total)
+	}
+}
+
+func BenchmarkReadRecorderRecord(b *testing.B) {
+	for _, rate := range []float64{0.01, 1} {
+		b.Run(fmt.Sprint(rate), func(b *testing.B) {
+			r := newReadRecorder(rate, keyentity.DefaultMaxPendingReads)
+			keys := []string{"service/risk/a", "service/risk/b"}
+			b.RunParallel(func(pb *testing.PB) {
+				reader := keyentity.Reader{Client: "sauron", IP: "10.0.0.1"}
+				for pb.Next() {
+					r.record(keyentity.DefaultEnvironment, keys, reader)
+				}
+			})
+		})
+	}
+}
+
+func TestReadTelemetryPerEnvironment(t *testing.T) {
+	u, deps := newTestUsecase(t)
+	u.SetReadSampleRate(1)
+
+	deps.keyRepo.EXPECT().GetCache(gomock.Any(), "service/risk/flag").DoAndReturn(func(ctx context.Context, key string) (keyentity.KV, error) {
+		return keyentity.KV{ID: 1, Key: key, Value: "true", Status: keyentity.ApprovedAndActive, Environment: keyentity.EnvironmentFromContext(ctx)}, nil
+	}).AnyTimes()
+
+	var saved []keyentity.KeyRead
+	deps.keyRepo.EXPECT().SaveKeyReads(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, reads []keyentity.KeyRead) error {
+		saved = reads
+		return nil
+	})
+
+	// the same reader reads the key in both environments
+	reader := keyentity.Reader{Client: "sauron", IP: "10.0.0.1"}
+	for _, environment := range []string{keyentity.DefaultEnvironment, "staging", "staging"} {
+		if _, err := u.GetKey(keyentity.WithEnvironment(context.Background(), environment), "service/risk/flag", reader); err != nil {
+			t.Fatalf("GetKey() error = %v", err)
+		}
+	}
+
+	if err := u.FlushReadTelemetry(context.Background()); err != nil {
+		t.Fatalf("FlushReadTelemetry() error = %v", err)
+	}
+
+	counts := make(map[string]int64)
+	for _, read := range saved {
+		counts[read.Environment] += read.Count
+	}
+	if len(saved) != 2 || counts[keyentity.DefaultEnvironment] != 1 || counts["staging"] != 2 {
+		t.Fatalf("saved reads = %+v, want one production and two staging reads kept apart", saved)
+	}
+}
@@ -0,0 +1,49 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/marde12345/key-flag/internal/config"
+	"github.com/marde12345/key-flag/internal/tracing"
+)
+
+var defaultTimeout = config.Timeout{
+	Read:       2 * time.Second,
+	Write:      5 * time.Second,
+	Background: 30 * time.Second,
+}
+
+// SetTimeout override budget of usecase calls, zero field keeps the current budget
+func (u *Usecase) SetTimeout(timeout config.Timeout) {
+	if timeout.Read > 0 {
+		u.timeout.Read = timeout.Read
+	}
+	if timeout.Write > 0 {
+		u.timeout.Write = timeout.Write
+	}
+	if timeout.Background > 0 {
+		u.timeout.Background = timeout.Background
+	}
+}
+
+// start open span of the usecase call and bound it by the budget.
+// Transactions are started with the returned ctx so they are rolled back once it is canceled, the returned finish must be deferred.
+func (u *Usecase) start(ctx context.Context, name string, budget time.Duration) (context.Context, func()) {
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	ctx, span := tracing.Start(ctx, name)
+
+	return ctx, func() {
+		span.End()
+		cancel()
+	}
+}
+
+// beginTx begin transaction traced until it is committed or rolled back.
+// The returned end must be deferred and called right after commit when more work follows.
+func (u *Usecase) beginTx(ctx context.Context) (*sql.Tx, func(), error) {
+	return tracing.BeginTx(ctx, "user.Usecase.tx", func(ctx context.Context) (*sql.Tx, error) {
+		return u.userRepo.GetDBTx(ctx, nil)
+	})
+}
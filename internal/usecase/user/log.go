@@ -0,0 +1,27 @@
+package user
+
+import (
+	"context"
+	"log/slog"
+
+	// internal dependency
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/logger"
+)
+
+func (u *Usecase) SetLogger(log *logger.Logger) {
+	u.log = log
+}
+
+// logRoles log change of roles by the actor, userID is 0 when roles are not tied to a user
+func (u *Usecase) logRoles(ctx context.Context, msg string, userID, actor int, roles []userentity.Role) {
+	for _, role := range roles {
+		u.log.InfoContext(ctx, msg,
+			slog.Int("user_id", userID),
+			slog.Int("actor", actor),
+			slog.Int("role_id", role.ID),
+			slog.String("prefix", role.Prefix),
+			slog.String("permission", role.Permission),
+		)
+	}
+}
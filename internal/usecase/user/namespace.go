@@ -0,0 +1,144 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	// internal dependency
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/util"
+)
+
+// CreateNamespace create isolated namespace and make the user admin of its root.
+// Only admin of the default namespace, which holds every key from before namespaces, can create one.
+func (u *Usecase) CreateNamespace(ctx context.Context, namespace userentity.Namespace, adminUserID, requestedBy int) error {
+	ctx, finish := u.start(ctx, "user.Usecase.CreateNamespace", u.timeout.Write)
+	defer finish()
+
+	if namespace.Name == "" || namespace.Root == "" {
+		return errors.New("Namespace name and root are required.")
+	}
+
+	defaultNamespace, err := u.userRepo.GetNamespace(ctx, userentity.DefaultNamespace)
+	if err != nil {
+		return err
+	}
+
+	if err := u.authorize(ctx, requestedBy, defaultNamespace.Root, userentity.RoleAdmin); err != nil {
+		return err
+	}
+
+	namespaces, err := u.userRepo.GetNamespaces(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	// root can not overlap, otherwise admin of one namespace can see the other
+	for _, existing := range namespaces {
+		if existing.Name == namespace.Name {
+			return fmt.Errorf("Namespace %s already exist.", namespace.Name)
+		}
+
+		if util.IsUnderPrefix(namespace.Root, existing.Root) || util.IsUnderPrefix(existing.Root, namespace.Root) {
+			return fmt.Errorf("Namespace root %s overlap with namespace %s.", namespace.Root, existing.Name)
+		}
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer endTx()
+
+	namespace.CreatedBy = adminUserID
+	if _, err := u.userRepo.CreateNamespace(ctx, tx, namespace); err != nil {
+		return err
+	}
+
+	roleID, err := u.userRepo.CreateRole(ctx, tx, namespace.Root, userentity.RoleAdmin, adminUserID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userRepo.MapUserAccess(ctx, tx, adminUserID, []userentity.Role{{ID: roleID}}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	u.log.InfoContext(ctx, "namespace created",
+		slog.String("namespace", namespace.Name),
+		slog.String("root", namespace.Root),
+		slog.Int("admin", adminUserID),
+		slog.Int("actor", requestedBy),
+	)
+	return nil
+}
+
+func (u *Usecase) GetNamespace(ctx context.Context, name string) (userentity.Namespace, error) {
+	ctx, finish := u.start(ctx, "user.Usecase.GetNamespace", u.timeout.Read)
+	defer finish()
+
+	return u.userRepo.GetNamespace(ctx, name)
+}
+
+// authorize check if user has at least permission on the prefix
+func (u *Usecase) authorize(ctx context.Context, userID int, prefix, permission string) error {
+	roles, err := u.userRepo.GetUserAccess(ctx, userID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if !userentity.HasPermission(roles, prefix, permission) {
+		return userentity.ErrForbidden
+	}
+
+	return nil
+}
+
+// authorizeRoles check if requester can grant or revoke the roles,
+// requester must be lead of the role prefix and can not manage role higher than his own
+func (u *Usecase) authorizeRoles(ctx context.Context, requestedBy int, roles []userentity.Role) error {
+	requesterRoles, err := u.userRepo.GetUserAccess(ctx, requestedBy)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	for _, role := range roles {
+		// access is mapped by id, prefix and permission sent by the requester can not be trusted
+		stored, err := u.userRepo.GetRoleByID(ctx, role.ID)
+		if err != nil {
+			return err
+		}
+
+		if !userentity.HasPermission(requesterRoles, stored.Prefix, userentity.RoleLead) ||
+			!userentity.HasPermission(requesterRoles, stored.Prefix, stored.Permission) {
+			return userentity.ErrForbidden
+		}
+	}
+
+	return nil
+}
+
+// visibleRoles filter roles to the one inside prefixes the requester has access to
+func (u *Usecase) visibleRoles(ctx context.Context, requestedBy int, roles []userentity.Role) ([]userentity.Role, error) {
+	requesterRoles, err := u.userRepo.GetUserAccess(ctx, requestedBy)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	result := make([]userentity.Role, 0, len(roles))
+	for _, role := range roles {
+		if userentity.HasPermission(requesterRoles, role.Prefix, userentity.RoleUser) {
+			result = append(result, role)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"go.uber.org/mock/gomock"
+
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+func TestCreateNamespace(t *testing.T) {
+	const (
+		defaultAdmin = 1
+		opsAdmin     = 2
+	)
+
+	tests := []struct {
+		name        string
+		namespace   userentity.Namespace
+		requestedBy int
+		wantErr     bool
+	}{
+		{
+			name:        "new root",
+			namespace:   userentity.Namespace{Name: "payment", Root: "payment", KeyQuota: 100},
+			requestedBy: defaultAdmin,
+		},
+		{
+			name:        "admin of another namespace",
+			namespace:   userentity.Namespace{Name: "payment", Root: "payment"},
+			requestedBy: opsAdmin,
+			wantErr:     true,
+		},
+		{
+			name:        "root inside other namespace",
+			namespace:   userentity.Namespace{Name: "risk", Root: "service/risk"},
+			requestedBy: defaultAdmin,
+			wantErr:     true,
+		},
+		{
+			name:        "root above other namespace",
+			namespace:   userentity.Namespace{Name: "all", Root: "ops"},
+			requestedBy: defaultAdmin,
+			wantErr:     true,
+		},
+		{
+			name:        "duplicate name",
+			namespace:   userentity.Namespace{Name: "ops", Root: "infra"},
+			requestedBy: defaultAdmin,
+			wantErr:     true,
+		},
+		{
+			name:        "empty root",
+			namespace:   userentity.Namespace{Name: "payment"},
+			requestedBy: defaultAdmin,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockuserRepository(gomock.NewController(t))
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			repo.EXPECT().GetNamespace(gomock.Any(), userentity.DefaultNamespace).
+				Return(userentity.Namespace{ID: 1, Name: userentity.DefaultNamespace, Root: "service"}, nil).AnyTimes()
+			repo.EXPECT().GetNamespaces(gomock.Any()).Return([]userentity.Namespace{
+				{ID: 1, Name: userentity.DefaultNamespace, Root: "service"},
+				{ID: 2, Name: "ops", Root: "ops/tools"},
+			}, nil).AnyTimes()
+			repo.EXPECT().GetUserAccess(gomock.Any(), defaultAdmin).
+				Return([]userentity.Role{{ID: 1, Prefix: "service", Permission: userentity.RoleAdmin}}, nil).AnyTimes()
+			repo.EXPECT().GetUserAccess(gomock.Any(), opsAdmin).
+				Return([]userentity.Role{{ID: 2, Prefix: "ops/tools", Permission: userentity.RoleAdmin}}, nil).AnyTimes()
+
+			// namespace, admin role of its root and the access of its admin are written together
+			if !tt.wantErr {
+				mock.ExpectBegin()
+				repo.EXPECT().GetDBTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+					return db.BeginTx(ctx, opts)
+				})
+				repo.EXPECT().CreateNamespace(gomock.Any(), gomock.Any(), userentity.Namespace{
+					Name: tt.namespace.Name, Root: tt.namespace.Root, KeyQuota: tt.namespace.KeyQuota, CreatedBy: opsAdmin,
+				}).Return(3, nil)
+				repo.EXPECT().CreateRole(gomock.Any(), gomock.Any(), tt.namespace.Root, userentity.RoleAdmin, opsAdmin).Return(4, nil)
+				repo.EXPECT().MapUserAccess(gomock.Any(), gomock.Any(), opsAdmin, []userentity.Role{{ID: 4}}).Return(nil)
+				mock.ExpectCommit()
+			}
+
+			err = New(repo).CreateNamespace(context.Background(), tt.namespace, opsAdmin, tt.requestedBy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateNamespace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"database/sql"
 
 	// entity dependency
-	userentity "github.com/tokopedia/kv-middleware/internal/entity/user"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
 )
 
 //go:generate mockgen -source=repository.go -package=user -destination=repository_mock_test.go
@@ -23,4 +23,8 @@ type userRepository interface {
 	GetRole(ctx context.Context, prefix, permission string) (userentity.Role, error)
 	RevokeUserAccess(ctx context.Context, userID, roleID, requestedBy int) error
 	SearchRole(ctx context.Context, prefix string) ([]userentity.Role, error)
+	GetRoleByID(ctx context.Context, roleID int) (userentity.Role, error)
+	CreateNamespace(ctx context.Context, tx *sql.Tx, namespace userentity.Namespace) (int, error)
+	GetNamespace(ctx context.Context, name string) (userentity.Namespace, error)
+	GetNamespaces(ctx context.Context) ([]userentity.Namespace, error)
 }
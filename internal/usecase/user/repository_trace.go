@@ -0,0 +1,159 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/tracing"
+)
+
+// tracedUserRepository wrap every userRepository call with a span
+type tracedUserRepository struct {
+	repo userRepository
+}
+
+// GetDBTx span covers BEGIN only, beginTx spans the transaction until commit or rollback
+func (r tracedUserRepository) GetDBTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetDBTx")
+	defer span.End()
+
+	result, err := r.repo.GetDBTx(ctx, opts)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) GetUser(ctx context.Context, username string) (userentity.User, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetUser")
+	defer span.End()
+
+	result, err := r.repo.GetUser(ctx, username)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) GetUserAccess(ctx context.Context, userID int) ([]userentity.Role, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetUserAccess")
+	defer span.End()
+
+	result, err := r.repo.GetUserAccess(ctx, userID)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) VerifyUser(ctx context.Context, email, token string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.VerifyUser")
+	defer span.End()
+
+	result, err := r.repo.VerifyUser(ctx, email, token)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) MapUserAccess(ctx context.Context, tx *sql.Tx, userID int, roles []userentity.Role) error {
+	ctx, span := tracing.Start(ctx, "userRepository.MapUserAccess")
+	defer span.End()
+
+	err := r.repo.MapUserAccess(ctx, tx, userID, roles)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedUserRepository) DeleteUserAccess(ctx context.Context, email string) error {
+	ctx, span := tracing.Start(ctx, "userRepository.DeleteUserAccess")
+	defer span.End()
+
+	err := r.repo.DeleteUserAccess(ctx, email)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedUserRepository) CreateUser(ctx context.Context, username, email, token string) error {
+	ctx, span := tracing.Start(ctx, "userRepository.CreateUser")
+	defer span.End()
+
+	err := r.repo.CreateUser(ctx, username, email, token)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedUserRepository) CreateRole(ctx context.Context, tx *sql.Tx, prefix, permission string, userID int) (int, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.CreateRole")
+	defer span.End()
+
+	result, err := r.repo.CreateRole(ctx, tx, prefix, permission, userID)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) GetAllRoles(ctx context.Context) ([]userentity.Role, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetAllRoles")
+	defer span.End()
+
+	result, err := r.repo.GetAllRoles(ctx)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) GetRole(ctx context.Context, prefix, permission string) (userentity.Role, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetRole")
+	defer span.End()
+
+	result, err := r.repo.GetRole(ctx, prefix, permission)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) RevokeUserAccess(ctx context.Context, userID, roleID, requestedBy int) error {
+	ctx, span := tracing.Start(ctx, "userRepository.RevokeUserAccess")
+	defer span.End()
+
+	err := r.repo.RevokeUserAccess(ctx, userID, roleID, requestedBy)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r tracedUserRepository) SearchRole(ctx context.Context, prefix string) ([]userentity.Role, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.SearchRole")
+	defer span.End()
+
+	result, err := r.repo.SearchRole(ctx, prefix)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) GetRoleByID(ctx context.Context, roleID int) (userentity.Role, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetRoleByID")
+	defer span.End()
+
+	result, err := r.repo.GetRoleByID(ctx, roleID)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) CreateNamespace(ctx context.Context, tx *sql.Tx, namespace userentity.Namespace) (int, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.CreateNamespace")
+	defer span.End()
+
+	result, err := r.repo.CreateNamespace(ctx, tx, namespace)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) GetNamespace(ctx context.Context, name string) (userentity.Namespace, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetNamespace")
+	defer span.End()
+
+	result, err := r.repo.GetNamespace(ctx, name)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r tracedUserRepository) GetNamespaces(ctx context.Context) ([]userentity.Namespace, error) {
+	ctx, span := tracing.Start(ctx, "userRepository.GetNamespaces")
+	defer span.End()
+
+	result, err := r.repo.GetNamespaces(ctx)
+	tracing.RecordError(span, err)
+	return result, err
+}
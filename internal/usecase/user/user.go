@@ -6,21 +6,28 @@ import (
 	"fmt"
 
 	// internal dependency
-	userentity "github.com/tokopedia/kv-middleware/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/config"
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+	"github.com/marde12345/key-flag/internal/logger"
 )
 
 type Usecase struct {
 	userRepo userRepository
+	timeout  config.Timeout
+	log      *logger.Logger
 }
 
 func New(user userRepository) *Usecase {
 	return &Usecase{
-		userRepo: user,
+		userRepo: tracedUserRepository{repo: user},
+		timeout:  defaultTimeout,
+		log:      logger.Nop(),
 	}
 }
 
-func (u *Usecase) CreateUser(user userentity.User) error {
-	ctx := context.Background()
+func (u *Usecase) CreateUser(ctx context.Context, user userentity.User) error {
+	ctx, finish := u.start(ctx, "user.Usecase.CreateUser", u.timeout.Write)
+	defer finish()
 
 	// check user is exist or not first
 	// prevent double row
@@ -34,8 +41,42 @@ func (u *Usecase) CreateUser(user userentity.User) error {
 	return u.userRepo.CreateUser(ctx, user.Username, user.Email, user.Token)
 }
 
-func (u *Usecase) GetUserDetails(username string) (userentity.UserDetails, error) {
-	ctx := context.Background()
+// Authenticate returns the user of username when token belongs to it
+func (u *Usecase) Authenticate(ctx context.Context, username, token string) (userentity.User, error) {
+	ctx, finish := u.start(ctx, "user.Usecase.Authenticate", u.timeout.Read)
+	defer finish()
+
+	if username == "" || token == "" {
+		return userentity.User{}, userentity.ErrUnauthenticated
+	}
+
+	user, err := u.userRepo.GetUser(ctx, username)
+	if err != nil && err != sql.ErrNoRows {
+		return userentity.User{}, err
+	}
+
+	if user.ID == 0 {
+		return userentity.User{}, userentity.ErrUnauthenticated
+	}
+
+	verified, err := u.userRepo.VerifyUser(ctx, user.Email, token)
+	if err != nil {
+		return userentity.User{}, err
+	}
+
+	if !verified {
+		return userentity.User{}, userentity.ErrUnauthenticated
+	}
+
+	// never carry the token further than the check
+	user.Token = ""
+
+	return user, nil
+}
+
+func (u *Usecase) GetUserDetails(ctx context.Context, username string) (userentity.UserDetails, error) {
+	ctx, finish := u.start(ctx, "user.Usecase.GetUserDetails", u.timeout.Read)
+	defer finish()
 
 	user, err := u.userRepo.GetUser(ctx, username)
 	if err != nil && err != sql.ErrNoRows {
@@ -60,14 +101,26 @@ func (u *Usecase) GetUserDetails(username string) (userentity.UserDetails, error
 	}, nil
 }
 
-func (u *Usecase) CreateRole(roles []userentity.Role, userID int) error {
-	ctx := context.Background()
+func (u *Usecase) CreateRole(ctx context.Context, roles []userentity.Role, userID int) error {
+	ctx, finish := u.start(ctx, "user.Usecase.CreateRole", u.timeout.Write)
+	defer finish()
 
-	tx, err := u.userRepo.GetDBTx(ctx, nil)
+	for _, role := range roles {
+		if _, ok := userentity.RoleRank[role.Permission]; !ok {
+			return fmt.Errorf("Unknown permission %s.", role.Permission)
+		}
+
+		// only admin of the namespace can create role inside it
+		if err := u.authorize(ctx, userID, role.Prefix, userentity.RoleAdmin); err != nil {
+			return err
+		}
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
+	defer endTx()
 
 	for _, role := range roles {
 		_, err := u.userRepo.CreateRole(ctx, tx, role.Prefix, role.Permission, userID)
@@ -76,40 +129,71 @@ func (u *Usecase) CreateRole(roles []userentity.Role, userID int) error {
 		}
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	u.logRoles(ctx, "role created", 0, userID, roles)
+	return nil
 }
 
-func (u *Usecase) MapUserAccess(userID int, roles []userentity.Role) error {
-	ctx := context.Background()
+// MapUserAccess grant roles to user, requester must be lead of the role prefix and not lower than the granted role
+func (u *Usecase) MapUserAccess(ctx context.Context, userID, requestedBy int, roles []userentity.Role) error {
+	ctx, finish := u.start(ctx, "user.Usecase.MapUserAccess", u.timeout.Write)
+	defer finish()
 
-	tx, err := u.userRepo.GetDBTx(ctx, nil)
+	if err := u.authorizeRoles(ctx, requestedBy, roles); err != nil {
+		return err
+	}
+
+	tx, endTx, err := u.beginTx(ctx)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
+	defer endTx()
 
 	err = u.userRepo.MapUserAccess(ctx, tx, userID, roles)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	endTx()
+
+	u.logRoles(ctx, "user access granted", userID, requestedBy, roles)
+	return nil
 }
 
-func (u *Usecase) GetAllRoles() ([]userentity.Role, error) {
-	ctx := context.Background()
+// GetAllRoles returns roles inside namespaces visible to the requester
+func (u *Usecase) GetAllRoles(ctx context.Context, requestedBy int) ([]userentity.Role, error) {
+	ctx, finish := u.start(ctx, "user.Usecase.GetAllRoles", u.timeout.Read)
+	defer finish()
+
+	roles, err := u.userRepo.GetAllRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	return u.userRepo.GetAllRoles(ctx)
+	return u.visibleRoles(ctx, requestedBy, roles)
 }
 
-func (u *Usecase) GetRole(prefix, permission string) (userentity.Role, error) {
-	ctx := context.Background()
+func (u *Usecase) GetRole(ctx context.Context, prefix, permission string) (userentity.Role, error) {
+	ctx, finish := u.start(ctx, "user.Usecase.GetRole", u.timeout.Read)
+	defer finish()
 
 	return u.userRepo.GetRole(ctx, prefix, permission)
 }
 
-func (u *Usecase) RevokeUserAccess(userID, requestedBy int, roles []userentity.Role) error {
-	ctx := context.Background()
+func (u *Usecase) RevokeUserAccess(ctx context.Context, userID, requestedBy int, roles []userentity.Role) error {
+	ctx, finish := u.start(ctx, "user.Usecase.RevokeUserAccess", u.timeout.Write)
+	defer finish()
+
+	if err := u.authorizeRoles(ctx, requestedBy, roles); err != nil {
+		return err
+	}
 
 	for _, role := range roles {
 		if err := u.userRepo.RevokeUserAccess(ctx, userID, role.ID, requestedBy); err != nil {
@@ -117,11 +201,18 @@ func (u *Usecase) RevokeUserAccess(userID, requestedBy int, roles []userentity.R
 		}
 	}
 
+	u.logRoles(ctx, "user access revoked", userID, requestedBy, roles)
 	return nil
 }
 
-func (u *Usecase) SearchRole(prefix string) ([]userentity.Role, error) {
-	ctx := context.Background()
+func (u *Usecase) SearchRole(ctx context.Context, prefix string, requestedBy int) ([]userentity.Role, error) {
+	ctx, finish := u.start(ctx, "user.Usecase.SearchRole", u.timeout.Read)
+	defer finish()
+
+	roles, err := u.userRepo.SearchRole(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
 
-	return u.userRepo.SearchRole(ctx, prefix)
+	return u.visibleRoles(ctx, requestedBy, roles)
 }
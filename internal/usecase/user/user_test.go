@@ -0,0 +1,47 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	userentity "github.com/marde12345/key-flag/internal/entity/user"
+)
+
+func TestAuthenticate(t *testing.T) {
+	alice := userentity.User{ID: 7, Username: "alice", Email: "alice@example.com", Token: "secret"}
+
+	tests := []struct {
+		name     string
+		username string
+		token    string
+		wantErr  error
+	}{
+		{name: "valid token", username: "alice", token: "secret"},
+		{name: "wrong token", username: "alice", token: "guess", wantErr: userentity.ErrUnauthenticated},
+		{name: "unknown user", username: "bob", token: "secret", wantErr: userentity.ErrUnauthenticated},
+		{name: "empty token", username: "alice", wantErr: userentity.ErrUnauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockuserRepository(gomock.NewController(t))
+			repo.EXPECT().GetUser(gomock.Any(), "alice").Return(alice, nil).AnyTimes()
+			repo.EXPECT().GetUser(gomock.Any(), "bob").Return(userentity.User{}, sql.ErrNoRows).AnyTimes()
+			repo.EXPECT().VerifyUser(gomock.Any(), alice.Email, gomock.Any()).DoAndReturn(func(ctx context.Context, email, token string) (bool, error) {
+				return token == alice.Token, nil
+			}).AnyTimes()
+
+			user, err := New(repo).Authenticate(context.Background(), tt.username, tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (user.ID != alice.ID || user.Token != "") {
+				t.Fatalf("Authenticate() = %+v, want alice without token", user)
+			}
+		})
+	}
+}
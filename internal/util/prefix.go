@@ -0,0 +1,17 @@
+package util
+
+import "strings"
+
+// IsUnderPrefix check if key is the prefix itself or inside the prefix path.
+// service/risk/sauron2 is not under service/risk/sauron.
+func IsUnderPrefix(key, prefix string) bool {
+	if prefix == "" || key == prefix {
+		return true
+	}
+
+	if strings.HasSuffix(prefix, "/") {
+		return strings.HasPrefix(key, prefix)
+	}
+
+	return strings.HasPrefix(key, prefix+"/")
+}
@@ -0,0 +1,3 @@
+DROP INDEX keys_value_jsonb_idx;
+DROP INDEX keys_value_trgm_idx;
+DROP INDEX keys_key_trgm_idx;
@@ -0,0 +1,34 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX keys_key_trgm_idx ON keys USING GIN (key gin_trgm_ops);
+CREATE INDEX keys_value_trgm_idx ON keys USING GIN (value gin_trgm_ops);
+
+-- json typed rows written before value validation may hold invalid json, the cast below would fail on them.
+-- fix or retype them through the api first, changing their type here would change what the services read
+CREATE FUNCTION kv_is_json(value TEXT) RETURNS BOOLEAN AS $$
+BEGIN
+    PERFORM value::jsonb;
+    RETURN TRUE;
+EXCEPTION WHEN others THEN
+    RETURN FALSE;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+DO $$
+DECLARE
+    invalid TEXT;
+BEGIN
+    SELECT string_agg(format('%s (id %s)', key, id), ', ' ORDER BY id)
+    INTO invalid
+    FROM keys
+    WHERE type = 'json' AND NOT kv_is_json(value);
+
+    IF invalid IS NOT NULL THEN
+        RAISE EXCEPTION 'json keys with invalid value, fix them before migrating: %', invalid;
+    END IF;
+END
+$$;
+
+DROP FUNCTION kv_is_json(TEXT);
+
+CREATE INDEX keys_value_jsonb_idx ON keys USING GIN ((value::jsonb) jsonb_path_ops) WHERE type = 'json';
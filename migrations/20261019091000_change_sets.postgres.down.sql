@@ -0,0 +1,3 @@
+ALTER TABLE keys DROP COLUMN change_set_id;
+
+DROP TABLE change_sets;
@@ -0,0 +1,10 @@
+CREATE TABLE change_sets
+(
+    id SERIAL,
+    description TEXT,
+    create_time TIMESTAMP default current_timestamp,
+    created_by INT,
+    PRIMARY KEY (id)
+);
+
+ALTER TABLE keys ADD COLUMN change_set_id INT default 0;
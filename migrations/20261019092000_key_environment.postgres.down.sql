@@ -0,0 +1,3 @@
+DROP INDEX keys_environment_key_status_idx;
+
+ALTER TABLE keys DROP COLUMN environment;
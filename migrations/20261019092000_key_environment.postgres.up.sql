@@ -0,0 +1,3 @@
+ALTER TABLE keys ADD COLUMN environment VARCHAR(30) NOT NULL default 'production';
+
+CREATE INDEX keys_environment_key_status_idx ON keys (environment, key, status);
@@ -0,0 +1,14 @@
+CREATE TABLE namespaces
+(
+    id SERIAL,
+    name VARCHAR(150) UNIQUE,
+    root VARCHAR(150) UNIQUE,
+    key_quota INT default 0,
+    create_time TIMESTAMP default current_timestamp,
+    created_by INT,
+    status INT,
+    PRIMARY KEY (id)
+);
+
+-- every existing key lives under service
+INSERT INTO namespaces(name, root, key_quota, status, created_by) VALUES('service', 'service', 0, 1, 0);
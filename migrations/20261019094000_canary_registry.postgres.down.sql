@@ -0,0 +1,2 @@
+DROP TABLE canary_groups;
+DROP TABLE services;
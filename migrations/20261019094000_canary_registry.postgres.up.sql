@@ -0,0 +1,29 @@
+CREATE TABLE services
+(
+    id SERIAL,
+    namespace VARCHAR(150),
+    tribe VARCHAR(150),
+    name VARCHAR(150),
+    prefix VARCHAR(150) UNIQUE,
+    create_time TIMESTAMP default current_timestamp,
+    created_by INT,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE canary_groups
+(
+    id SERIAL,
+    service_id INT,
+    name VARCHAR(150),
+    create_time TIMESTAMP default current_timestamp,
+    created_by INT,
+    status INT,
+    PRIMARY KEY (id),
+    UNIQUE (service_id, name)
+);
+
+-- register services created before this migration from their default key
+INSERT INTO services(namespace, tribe, name, prefix, created_by)
+SELECT 'service', split_part(key, '/', 2), split_part(key, '/', 3), regexp_replace(key, '/default$', ''), created_by
+FROM keys
+WHERE key LIKE 'service/%/%/default' AND status = 2;
@@ -0,0 +1,2 @@
+DROP TABLE canary_decisions;
+DROP TABLE canary_gates;
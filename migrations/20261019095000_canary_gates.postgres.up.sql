@@ -0,0 +1,22 @@
+CREATE TABLE canary_gates
+(
+    key_id INT,
+    min_bake_seconds INT,
+    checker VARCHAR(20),
+    target TEXT,
+    threshold DOUBLE PRECISION default 0,
+    start_time TIMESTAMP default current_timestamp,
+    created_by INT,
+    status INT,
+    PRIMARY KEY (key_id)
+);
+
+CREATE TABLE canary_decisions
+(
+    id SERIAL,
+    key_id INT,
+    decision VARCHAR(20),
+    reason TEXT,
+    create_time TIMESTAMP default current_timestamp,
+    PRIMARY KEY (id)
+);
@@ -0,0 +1,5 @@
+DROP INDEX canary_keys_ip_idx;
+
+DELETE FROM canary_keys WHERE family(ip) = 6;
+
+ALTER TABLE canary_keys ALTER COLUMN ip TYPE VARCHAR(20) USING (CASE WHEN masklen(ip) = 32 THEN host(ip) ELSE text(ip) END);
@@ -0,0 +1,17 @@
+-- rows truncated by the old VARCHAR(20) column, e.g. long ipv6, can not be parsed, drop only them before converting
+CREATE FUNCTION canary_is_inet(ip TEXT) RETURNS BOOLEAN AS $$
+BEGIN
+    PERFORM ip::inet;
+    RETURN TRUE;
+EXCEPTION WHEN others THEN
+    RETURN FALSE;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+DELETE FROM canary_keys WHERE ip IS NOT NULL AND NOT canary_is_inet(ip);
+
+DROP FUNCTION canary_is_inet(TEXT);
+
+ALTER TABLE canary_keys ALTER COLUMN ip TYPE INET USING ip::inet;
+
+CREATE INDEX canary_keys_ip_idx ON canary_keys USING gist (ip inet_ops);
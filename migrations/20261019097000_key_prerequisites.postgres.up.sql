@@ -0,0 +1,14 @@
+CREATE TABLE key_prerequisites
+(
+    id SERIAL,
+    key VARCHAR(300),
+    required_key VARCHAR(300),
+    required_value TEXT,
+    fallback_value TEXT,
+    create_time TIMESTAMP default current_timestamp,
+    created_by INT,
+    status INT,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX key_prerequisites_required_key_idx ON key_prerequisites (required_key);
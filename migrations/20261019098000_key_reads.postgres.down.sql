@@ -0,0 +1 @@
+DROP TABLE key_reads;
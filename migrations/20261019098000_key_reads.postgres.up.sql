@@ -0,0 +1,10 @@
+CREATE TABLE key_reads
+(
+    environment VARCHAR(30) NOT NULL default 'production',
+    key VARCHAR(300),
+    client VARCHAR(150),
+    ip VARCHAR(45),
+    count BIGINT default 0,
+    last_read_time TIMESTAMP,
+    PRIMARY KEY (environment, key, client, ip)
+);
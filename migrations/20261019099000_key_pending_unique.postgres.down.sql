@@ -0,0 +1 @@
+DROP INDEX keys_pending_key_idx;
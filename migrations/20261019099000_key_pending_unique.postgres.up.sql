@@ -0,0 +1,24 @@
+-- keys with more than one pending row must be resolved first, approve, disapprove or withdraw them through the api
+-- so canary ips and cache follow, disapproving them here would leave canary rows serving a disapproved value
+DO $$
+DECLARE
+    conflicts TEXT;
+BEGIN
+    SELECT string_agg(format('%s %s (id:status %s)', environment, key, ids), ', ')
+    INTO conflicts
+    FROM (
+        SELECT environment, key, string_agg(id::TEXT || ':' || status::TEXT, ', ' ORDER BY id) AS ids
+        FROM keys
+        WHERE status IN (3, 5, 6)
+        GROUP BY environment, key
+        HAVING count(*) > 1
+    ) pending;
+
+    IF conflicts IS NOT NULL THEN
+        RAISE EXCEPTION 'keys with more than one pending row, resolve them before migrating: %', conflicts;
+    END IF;
+END
+$$;
+
+-- at most one placed (3), canary (5) or placed delete (6) row per key
+CREATE UNIQUE INDEX keys_pending_key_idx ON keys (environment, key) WHERE status IN (3, 5, 6);
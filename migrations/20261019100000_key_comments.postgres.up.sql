@@ -0,0 +1,13 @@
+CREATE TABLE key_comments
+(
+    id SERIAL,
+    key_id INT NOT NULL,
+    parent_id INT NOT NULL default 0,
+    kind VARCHAR(20) NOT NULL,
+    body TEXT NOT NULL,
+    created_by INT,
+    create_time TIMESTAMP default current_timestamp,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX key_comments_key_id_idx ON key_comments (key_id);
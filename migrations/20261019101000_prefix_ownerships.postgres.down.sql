@@ -0,0 +1 @@
+DROP TABLE prefix_ownerships;
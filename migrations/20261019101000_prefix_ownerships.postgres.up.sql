@@ -0,0 +1,14 @@
+CREATE TABLE prefix_ownerships
+(
+    id SERIAL,
+    prefix VARCHAR(300) NOT NULL,
+    owners JSONB NOT NULL default '[]',
+    description TEXT,
+    on_call VARCHAR(100),
+    tags TEXT[],
+    update_time TIMESTAMP default current_timestamp,
+    updated_by INT,
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX prefix_ownerships_prefix_idx ON prefix_ownerships (prefix);
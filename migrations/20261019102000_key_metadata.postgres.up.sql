@@ -0,0 +1,16 @@
+CREATE TABLE key_metadata
+(
+    key VARCHAR(300) NOT NULL,
+    description TEXT,
+    tags TEXT[] NOT NULL default '{}',
+    owner VARCHAR(100),
+    ticket VARCHAR(300),
+    lifetime VARCHAR(20) NOT NULL default 'permanent',
+    expiry_date DATE,
+    update_time TIMESTAMP default current_timestamp,
+    updated_by INT,
+    PRIMARY KEY (key)
+);
+
+CREATE INDEX key_metadata_tags_idx ON key_metadata USING GIN (tags);
+CREATE INDEX key_metadata_description_trgm_idx ON key_metadata USING GIN (description gin_trgm_ops);
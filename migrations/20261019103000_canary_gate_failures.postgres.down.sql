@@ -0,0 +1,2 @@
+ALTER TABLE canary_gates DROP COLUMN failures;
+ALTER TABLE canary_gates DROP COLUMN failure_threshold;
@@ -0,0 +1,2 @@
+ALTER TABLE canary_gates ADD COLUMN failure_threshold INT NOT NULL default 3;
+ALTER TABLE canary_gates ADD COLUMN failures INT NOT NULL default 0;
@@ -0,0 +1 @@
+DROP TABLE canary_group_keys;
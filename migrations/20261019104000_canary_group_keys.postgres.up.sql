@@ -0,0 +1,9 @@
+CREATE TABLE canary_group_keys
+(
+    key_id INT,
+    canary_group VARCHAR(150),
+    status INT,
+    PRIMARY KEY (key_id, canary_group)
+);
+
+CREATE INDEX canary_group_keys_group_idx ON canary_group_keys (canary_group) WHERE status = 1;
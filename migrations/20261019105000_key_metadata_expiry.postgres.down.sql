@@ -0,0 +1 @@
+DROP INDEX key_metadata_expiry_date_idx;
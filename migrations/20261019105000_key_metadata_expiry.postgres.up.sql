@@ -0,0 +1,2 @@
+-- search of expired keys filters on the expiry date in the query
+CREATE INDEX key_metadata_expiry_date_idx ON key_metadata (expiry_date) WHERE lifetime = 'temporary';